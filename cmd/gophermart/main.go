@@ -6,6 +6,7 @@ import (
 	"gophermart-service/internal/config"
 	"os"
 	"os/signal"
+	"sync"
 	"syscall"
 )
 
@@ -29,19 +30,55 @@ func main() {
 	httpApp.SetupCommonMiddleware()
 	httpApp.SetupRoutes()
 
-	err = httpApp.Start()
-	if err != nil {
-		logger.Error("Failed to start server", "error", err)
-		os.Exit(1)
+	// Серверы работают до сигнала остановки; ошибка любого из них тоже завершает процесс
+	serveErrors := make(chan error, 2)
+	go func() {
+		serveErrors <- httpApp.Start()
+	}()
+
+	var grpcApp *app.GRPCApp
+	if httpApp.Settings().Environment.GRPC.Enabled {
+		grpcApp = app.NewGRPCApp(logger, httpApp.Settings(), httpApp.Services())
+		go func() {
+			serveErrors <- grpcApp.Start()
+		}()
 	}
 
-	<-ctx.Done()
+	exitCode := 0
+	select {
+	case <-ctx.Done():
+	case err = <-serveErrors:
+		if err != nil {
+			logger.Error("Server failed", "error", err)
+			exitCode = 1
+		}
+	}
 
+	// Порядок остановки: перестаем принимать запросы и дожидаемся текущих,
+	// затем останавливаем фоновые обработчики и только после них закрываем пул БД
 	logger.Info("Shutting down server...")
-	err = httpApp.Stop()
-	if err != nil {
-		logger.Error("Failed to stop server gracefully", "error", err)
-		os.Exit(1)
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), httpApp.Settings().Environment.Server.ShutdownTimeout)
+	defer cancel()
+
+	var wg sync.WaitGroup
+	if grpcApp != nil {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			grpcApp.Stop(shutdownCtx)
+		}()
+	}
+	if err = httpApp.Shutdown(shutdownCtx); err != nil {
+		logger.Error("Failed to drain HTTP server", "error", err)
+		exitCode = 1
 	}
+	wg.Wait()
+
+	httpApp.Stop()
 	logger.Info("Server stopped")
+
+	if exitCode != 0 {
+		config.SyncLogger(logger)
+		os.Exit(exitCode)
+	}
 }
@@ -0,0 +1,243 @@
+// Команда oidcmock запускает локальный OpenID Connect провайдер для ручной проверки входа через OIDC.
+// Провайдер не показывает форму входа: пользователь берется из параметра login_hint
+// (по умолчанию mock-user) и сразу возвращается код авторизации.
+package main
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/json"
+	"flag"
+	"log"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	keyID       = "oidcmock-1"
+	codeTTL     = time.Minute
+	idTokenTTL  = 5 * time.Minute
+	defaultUser = "mock-user"
+)
+
+type authCode struct {
+	clientID      string
+	redirectURI   string
+	nonce         string
+	codeChallenge string
+	username      string
+	expiresAt     time.Time
+}
+
+type provider struct {
+	issuer       string
+	clientID     string
+	clientSecret string
+	key          *rsa.PrivateKey
+
+	mu    sync.Mutex
+	codes map[string]*authCode
+}
+
+func main() {
+	address := flag.String("a", "localhost:9096", "адрес запуска провайдера")
+	issuer := flag.String("issuer", "http://localhost:9096", "идентификатор провайдера (iss)")
+	clientID := flag.String("client-id", "gophermart", "client_id приложения")
+	clientSecret := flag.String("client-secret", "secret", "client_secret приложения")
+	flag.Parse()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		log.Fatalf("failed to generate signing key: %v", err)
+	}
+
+	p := &provider{
+		issuer:       strings.TrimSuffix(*issuer, "/"),
+		clientID:     *clientID,
+		clientSecret: *clientSecret,
+		key:          key,
+		codes:        make(map[string]*authCode),
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", p.handleDiscovery)
+	mux.HandleFunc("/authorize", p.handleAuthorize)
+	mux.HandleFunc("/token", p.handleToken)
+	mux.HandleFunc("/jwks", p.handleJWKS)
+
+	log.Printf("oidcmock listening on %s (issuer %s)", *address, p.issuer)
+	server := &http.Server{Addr: *address, Handler: mux, ReadHeaderTimeout: 5 * time.Second}
+	log.Fatal(server.ListenAndServe())
+}
+
+func (p *provider) handleDiscovery(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"issuer":                                p.issuer,
+		"authorization_endpoint":                p.issuer + "/authorize",
+		"token_endpoint":                        p.issuer + "/token",
+		"jwks_uri":                              p.issuer + "/jwks",
+		"response_types_supported":              []string{"code"},
+		"subject_types_supported":               []string{"public"},
+		"id_token_signing_alg_values_supported": []string{"RS256"},
+		"code_challenge_methods_supported":      []string{"S256"},
+		"grant_types_supported":                 []string{"authorization_code"},
+	})
+}
+
+func (p *provider) handleAuthorize(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	if query.Get("response_type") != "code" || query.Get("client_id") != p.clientID {
+		http.Error(w, "invalid authorization request", http.StatusBadRequest)
+		return
+	}
+	if query.Get("code_challenge") == "" || query.Get("code_challenge_method") != "S256" {
+		http.Error(w, "PKCE S256 is required", http.StatusBadRequest)
+		return
+	}
+	redirectURI, err := url.Parse(query.Get("redirect_uri"))
+	if err != nil || redirectURI.Scheme == "" {
+		http.Error(w, "invalid redirect_uri", http.StatusBadRequest)
+		return
+	}
+
+	username := query.Get("login_hint")
+	if username == "" {
+		username = defaultUser
+	}
+
+	code := randomString(24)
+	p.mu.Lock()
+	p.codes[code] = &authCode{
+		clientID:      p.clientID,
+		redirectURI:   redirectURI.String(),
+		nonce:         query.Get("nonce"),
+		codeChallenge: query.Get("code_challenge"),
+		username:      username,
+		expiresAt:     time.Now().Add(codeTTL),
+	}
+	p.mu.Unlock()
+
+	params := redirectURI.Query()
+	params.Set("code", code)
+	params.Set("state", query.Get("state"))
+	redirectURI.RawQuery = params.Encode()
+
+	http.Redirect(w, r, redirectURI.String(), http.StatusFound)
+}
+
+func (p *provider) handleToken(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if err := r.ParseForm(); err != nil {
+		writeOAuthError(w, "invalid_request")
+		return
+	}
+
+	clientID, clientSecret, ok := r.BasicAuth()
+	if ok {
+		clientID, _ = url.QueryUnescape(clientID)
+		clientSecret, _ = url.QueryUnescape(clientSecret)
+	} else {
+		clientID, clientSecret = r.PostForm.Get("client_id"), r.PostForm.Get("client_secret")
+	}
+	if clientID != p.clientID || subtle.ConstantTimeCompare([]byte(clientSecret), []byte(p.clientSecret)) != 1 {
+		writeOAuthError(w, "invalid_client")
+		return
+	}
+
+	if r.PostForm.Get("grant_type") != "authorization_code" {
+		writeOAuthError(w, "unsupported_grant_type")
+		return
+	}
+
+	code := r.PostForm.Get("code")
+	p.mu.Lock()
+	issued, found := p.codes[code]
+	delete(p.codes, code)
+	p.mu.Unlock()
+
+	if !found || time.Now().After(issued.expiresAt) || issued.redirectURI != r.PostForm.Get("redirect_uri") {
+		writeOAuthError(w, "invalid_grant")
+		return
+	}
+
+	verifierHash := sha256.Sum256([]byte(r.PostForm.Get("code_verifier")))
+	if base64.RawURLEncoding.EncodeToString(verifierHash[:]) != issued.codeChallenge {
+		writeOAuthError(w, "invalid_grant")
+		return
+	}
+
+	now := time.Now()
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.MapClaims{
+		"iss":                p.issuer,
+		"sub":                "mock|" + issued.username,
+		"aud":                issued.clientID,
+		"iat":                now.Unix(),
+		"exp":                now.Add(idTokenTTL).Unix(),
+		"nonce":              issued.nonce,
+		"preferred_username": issued.username,
+		"name":               issued.username,
+		"email":              issued.username + "@example.test",
+		"email_verified":     true,
+	})
+	token.Header["kid"] = keyID
+
+	idToken, err := token.SignedString(p.key)
+	if err != nil {
+		http.Error(w, "failed to sign id_token", http.StatusInternalServerError)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"access_token": randomString(32),
+		"token_type":   "Bearer",
+		"expires_in":   int(idTokenTTL.Seconds()),
+		"id_token":     idToken,
+	})
+}
+
+func (p *provider) handleJWKS(w http.ResponseWriter, _ *http.Request) {
+	publicKey := p.key.PublicKey
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"keys": []map[string]string{{
+			"kid": keyID,
+			"kty": "RSA",
+			"alg": "RS256",
+			"use": "sig",
+			"n":   base64.RawURLEncoding.EncodeToString(publicKey.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(publicKey.E)).Bytes()),
+		}},
+	})
+}
+
+func writeOAuthError(w http.ResponseWriter, code string) {
+	writeJSON(w, http.StatusBadRequest, map[string]string{"error": code})
+}
+
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(body); err != nil {
+		log.Printf("failed to write response: %v", err)
+	}
+}
+
+func randomString(size int) string {
+	buf := make([]byte, size)
+	if _, err := rand.Read(buf); err != nil {
+		log.Fatalf("failed to read random bytes: %v", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(buf)
+}
@@ -0,0 +1,54 @@
+package app
+
+import (
+	"context"
+	"gophermart-service/internal/config"
+	grpcServer "gophermart-service/internal/grpc/server"
+	"gophermart-service/internal/service"
+	"net"
+
+	"google.golang.org/grpc"
+)
+
+// GRPCApp gRPC API, работающий рядом с HTTP API поверх тех же сервисов
+type GRPCApp struct {
+	server   *grpc.Server
+	settings *config.GRPCSettings
+	logger   config.LoggerInterface
+}
+
+func NewGRPCApp(logger config.LoggerInterface, settings *config.Settings, services *service.Services) *GRPCApp {
+	return &GRPCApp{
+		server:   grpcServer.New(logger, settings, services),
+		settings: settings.Environment.GRPC,
+		logger:   logger,
+	}
+}
+
+func (a *GRPCApp) Start() error {
+	listener, err := net.Listen("tcp", a.settings.Address)
+	if err != nil {
+		return err
+	}
+
+	a.logger.Infow("gRPC server started", "address", a.settings.Address)
+	return a.server.Serve(listener)
+}
+
+// Stop дожидается завершения выполняющихся вызовов и закрывает соединения.
+// Если вызовы не завершились до истечения ctx, они прерываются.
+func (a *GRPCApp) Stop(ctx context.Context) {
+	done := make(chan struct{})
+	go func() {
+		a.server.GracefulStop()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-ctx.Done():
+		a.logger.Warn("gRPC drain deadline exceeded, closing remaining connections")
+		a.server.Stop()
+		<-done
+	}
+}
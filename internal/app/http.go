@@ -2,19 +2,77 @@ package app
 
 import (
 	"context"
+	"errors"
+	"gophermart-service/internal/base"
 	"gophermart-service/internal/config"
 	"gophermart-service/internal/handler"
 	"gophermart-service/internal/integration"
+	"gophermart-service/internal/metrics"
 	"gophermart-service/internal/middleware"
 	"gophermart-service/internal/repository"
 	"gophermart-service/internal/service"
+	"gophermart-service/internal/service/apikey"
+	userAuth "gophermart-service/internal/service/user/auth"
+	"gophermart-service/internal/tracing"
+	"net/http"
+	"strings"
+	"time"
 
 	"github.com/gin-contrib/requestid"
 	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
 )
 
+// tracingFlushTimeout сколько ждать выгрузки спанов при остановке
+const tracingFlushTimeout = 5 * time.Second
+
+// apiKeyRouteScopes перечисляет маршруты, доступные по API-ключу, и необходимые для них права
+var apiKeyRouteScopes = middleware.RouteScopes{
+	"POST /api/user/orders":            apikey.ScopeOrdersWrite,
+	"POST /api/user/orders/batch":      apikey.ScopeOrdersWrite,
+	"GET /api/user/balance":            apikey.ScopeBalanceRead,
+	"GET /api/user/withdrawals":        apikey.ScopeBalanceRead,
+	"GET /api/user/withdrawals/export": apikey.ScopeBalanceRead,
+	"POST /api/user/balance/withdraw":  apikey.ScopeWithdrawWrite,
+}
+
+// publicRoutes перечисляет маршруты, которые не требуют аутентификации
+var publicRoutes = middleware.PublicRoutes{
+	"GET /health":                           true,
+	"GET /livez":                            true,
+	"GET /readyz":                           true,
+	"GET /openapi.json":                     true,
+	"GET /docs":                             true,
+	"GET /metrics":                          true,
+	"GET /api/user/oidc/login":              true,
+	"GET /api/user/oidc/callback":           true,
+	"POST /api/user/register":               true,
+	"POST /api/user/login":                  true,
+	"POST /api/user/login/2fa":              true,
+	"POST /api/user/password/reset-request": true,
+	"POST /api/user/password/reset":         true,
+}
+
+// withV2Routes добавляет те же значения для копий маршрутов /api во второй версии API
+func withV2Routes[M ~map[string]V, V any](routes M) M {
+	result := make(M, len(routes)*2)
+	for route, value := range routes {
+		result[route] = value
+		method, path, _ := strings.Cut(route, " ")
+		if !strings.HasPrefix(path, "/api/") {
+			continue
+		}
+		result[method+" "+middleware.V2PathPrefix+strings.TrimPrefix(path, "/api")] = value
+	}
+	return result
+}
+
 type HTTPApp struct {
 	router   *gin.Engine
+	server   *http.Server
+	pool     *pgxpool.Pool
+	tracing  tracing.ShutdownFunc
 	settings *config.Settings
 	logger   config.LoggerInterface
 	services *service.Services
@@ -30,23 +88,51 @@ func NewHTTPApp(logger config.LoggerInterface) (*HTTPApp, error) {
 		return nil, err
 	}
 
+	shutdownTracing, err := tracing.Setup(ctx, settings.Environment.Tracing)
+	if err != nil {
+		return nil, err
+	}
+
 	pool, err := config.SetupDB(
 		ctx,
 		logger,
 		settings.GetDatabaseURI(),
 		settings.Environment.Database.MigrationsPath,
+		tracing.NewQueryTracer(),
 	)
 	if err != nil {
 		return nil, err
 	}
 
 	repos := repository.NewRepositories(logger, pool)
+	if err = registerMetrics(logger, pool, repos); err != nil {
+		pool.Close()
+		return nil, err
+	}
 	integrations := integration.NewIntegrations(logger, settings)
-	services := service.NewServices(logger, settings, repos, integrations)
+	services, err := service.NewServices(logger, settings, repos, integrations)
+	if err != nil {
+		pool.Close()
+		return nil, err
+	}
 	handlers := handler.NewHandlers(logger, services, settings)
 
+	serverSettings := settings.Environment.Server
+	server := &http.Server{
+		Addr:              settings.GetServerAddress(),
+		Handler:           router,
+		ReadHeaderTimeout: serverSettings.ReadHeaderTimeout,
+		WriteTimeout:      serverSettings.WriteTimeout,
+		IdleTimeout:       serverSettings.IdleTimeout,
+	}
+	// Shutdown не прерывает активные соединения, поэтому потоки обновлений закрываем сами
+	server.RegisterOnShutdown(services.Updates.Close)
+
 	return &HTTPApp{
 		router:   router,
+		server:   server,
+		pool:     pool,
+		tracing:  shutdownTracing,
 		logger:   logger,
 		settings: settings,
 		services: services,
@@ -55,31 +141,180 @@ func NewHTTPApp(logger config.LoggerInterface) (*HTTPApp, error) {
 }
 
 func (a *HTTPApp) SetupCommonMiddleware() {
+	a.router.Use(otelgin.Middleware(
+		a.settings.Environment.Tracing.ServiceName,
+		otelgin.WithFilter(isTracedRequest),
+	))
+	a.router.Use(middleware.Metrics())
 	a.router.Use(requestid.New())
 	a.router.Use(middleware.RequestIDMiddleware())
-	a.router.Use(middleware.JWTMiddleware(a.logger, a.settings.Environment.JWT, a.services.JWT))
+	a.router.Use(middleware.JWTMiddleware(
+		a.logger,
+		a.settings.Environment.JWT,
+		a.services.JWT,
+		a.services.UserAuth,
+		a.services.Session,
+		a.services.APIKey,
+		withV2Routes(apiKeyRouteScopes),
+		withV2Routes(publicRoutes),
+	))
 }
 
 func (a *HTTPApp) SetupRoutes() {
 	a.router.GET("/health", a.handlers.GetHealth.Handle)
-	a.router.POST("/api/user/register", a.handlers.PostUserRegister.Handle)
-	a.router.POST("/api/user/login", a.handlers.PostUserLogin.Handle)
-	a.router.POST("/api/user/orders", a.handlers.PostUserOrders.Handle)
-	a.router.GET("/api/user/orders", a.handlers.GetUserOrders.Handle)
-	a.router.GET("/api/user/balance", a.handlers.GetUserBalance.Handle)
-	a.router.POST("/api/user/balance/withdraw", a.handlers.PostUserBalanceWithdraw.Handle)
-	a.router.GET("/api/user/withdrawals", a.handlers.GetUserWithdrawals.Handle)
+	a.router.GET("/livez", a.handlers.GetLivez.Handle)
+	a.router.GET("/readyz", a.handlers.GetReadyz.Handle)
+	a.router.GET("/openapi.json", a.handlers.GetOpenAPI.Handle)
+	a.router.GET("/docs", a.handlers.GetSwaggerUI.Handle)
+	a.router.GET("/metrics", a.handlers.GetMetrics.Handle)
+
+	// Адрес возврата OIDC зарегистрирован у провайдера, поэтому вход через OIDC не версионируется
+	a.router.GET("/api/user/oidc/login", a.handlers.GetUserOIDCLogin.Handle)
+	a.router.GET("/api/user/oidc/callback", a.handlers.GetUserOIDCCallback.Handle)
+
+	// Маршруты без версии в пути обслуживают v1 (или v2 по согласованию), /api/v2 — всегда v2
+	v1 := a.router.Group("/api")
+	v2 := a.router.Group(middleware.V2PathPrefix, middleware.APIVersion(middleware.APIVersion2))
+	for _, route := range a.userRoutes() {
+		v2Handler := route.v1
+		if route.v2 != nil {
+			v2Handler = route.v2
+		}
+		v1.Handle(route.method, route.path, middleware.NegotiateAPIVersion(
+			a.logger,
+			a.settings.Environment.API,
+			route.v1.Handle,
+			v2Handler.Handle,
+		))
+		v2.Handle(route.method, route.path, v2Handler.Handle)
+	}
+
+	// Служебные маршруты операторов: поддержка может просматривать, изменять роли может только администратор
+	admin := a.router.Group("/api/admin", middleware.RequireRole(a.logger, userAuth.RoleAdmin, userAuth.RoleSupport))
+	admin.GET("/users", a.handlers.GetAdminUsers.Handle)
+	admin.PUT("/users/:id/role", middleware.RequireRole(a.logger, userAuth.RoleAdmin), a.handlers.PutAdminUserRole.Handle)
+}
+
+// versionedRoute маршрут пользовательского API. Если v2 не задан, во второй версии
+// представление не изменилось и используется обработчик v1.
+type versionedRoute struct {
+	method string
+	path   string
+	v1     base.HandlerInterface
+	v2     base.HandlerInterface
 }
 
+// userRoutes перечисляет маршруты пользовательского API относительно /api и /api/v2.
+// Обработчики v1 не меняются: их ответы должны совпадать с SPECIFICATION.md.
+func (a *HTTPApp) userRoutes() []versionedRoute {
+	return []versionedRoute{
+		{http.MethodPost, "/user/register", a.handlers.PostUserRegister, nil},
+		{http.MethodPost, "/user/login", a.handlers.PostUserLogin, nil},
+		{http.MethodPost, "/user/login/2fa", a.handlers.PostUserLoginTwoFactor, nil},
+		{http.MethodPost, "/user/orders", a.handlers.PostUserOrders, a.handlers.V2PostUserOrders},
+		{http.MethodPost, "/user/orders/batch", a.handlers.PostUserOrdersBatch, nil},
+		{http.MethodGet, "/user/orders", a.handlers.GetUserOrders, a.handlers.V2GetUserOrders},
+		{http.MethodGet, "/user/orders/export", a.handlers.GetUserOrdersStatement, nil},
+		{http.MethodGet, "/user/orders/:number", a.handlers.GetUserOrder, a.handlers.V2GetUserOrder},
+		{http.MethodGet, "/user/updates", a.handlers.GetUserUpdates, nil},
+		{http.MethodGet, "/user/balance", a.handlers.GetUserBalance, a.handlers.V2GetUserBalance},
+		{http.MethodPost, "/user/balance/withdraw", a.handlers.PostUserBalanceWithdraw, a.handlers.V2PostUserBalanceWithdraw},
+		{http.MethodGet, "/user/withdrawals", a.handlers.GetUserWithdrawals, a.handlers.V2GetUserWithdrawals},
+		{http.MethodGet, "/user/withdrawals/export", a.handlers.GetUserWithdrawalsStatement, nil},
+		{http.MethodPost, "/user/password", a.handlers.PostUserPassword, nil},
+		{http.MethodPost, "/user/password/reset-request", a.handlers.PostUserPasswordResetRequest, nil},
+		{http.MethodPost, "/user/password/reset", a.handlers.PostUserPasswordReset, nil},
+		{http.MethodPost, "/user/2fa/enroll", a.handlers.PostUserTwoFactorEnroll, nil},
+		{http.MethodPost, "/user/2fa/confirm", a.handlers.PostUserTwoFactorConfirm, nil},
+		{http.MethodPost, "/user/2fa/disable", a.handlers.PostUserTwoFactorDisable, nil},
+		{http.MethodPost, "/user/api-keys", a.handlers.PostUserAPIKeys, nil},
+		{http.MethodGet, "/user/api-keys", a.handlers.GetUserAPIKeys, nil},
+		{http.MethodDelete, "/user/api-keys/:id", a.handlers.DeleteUserAPIKey, nil},
+		{http.MethodPost, "/user/webhooks", a.handlers.PostUserWebhooks, nil},
+		{http.MethodGet, "/user/webhooks", a.handlers.GetUserWebhooks, nil},
+		{http.MethodDelete, "/user/webhooks/:id", a.handlers.DeleteUserWebhook, nil},
+		{http.MethodGet, "/user/webhooks/:id/deliveries", a.handlers.GetUserWebhookDeliveries, nil},
+		{http.MethodGet, "/user/sessions", a.handlers.GetUserSessions, nil},
+		{http.MethodDelete, "/user/sessions/:id", a.handlers.DeleteUserSession, nil},
+		{http.MethodGet, "/user/export", a.handlers.GetUserExport, nil},
+		{http.MethodDelete, "/user", a.handlers.DeleteUser, nil},
+		{http.MethodGet, "/user/profile", a.handlers.GetUserProfile, nil},
+		{http.MethodPatch, "/user/profile", a.handlers.PatchUserProfile, nil},
+		{http.MethodPost, "/user/profile/email/verify", a.handlers.PostUserProfileEmailVerify, nil},
+	}
+}
+
+// untracedPaths служебные маршруты, которые опрашиваются постоянно и не трассируются
+var untracedPaths = map[string]bool{
+	"/health":  true,
+	"/livez":   true,
+	"/readyz":  true,
+	"/metrics": true,
+}
+
+func isTracedRequest(r *http.Request) bool {
+	return !untracedPaths[r.URL.Path]
+}
+
+// registerMetrics добавляет в реестр метрики, которые снимаются с пула и БД в момент опроса
+func registerMetrics(logger config.LoggerInterface, pool *pgxpool.Pool, repos *repository.Repositories) error {
+	if err := metrics.RegisterPool(pool); err != nil {
+		return err
+	}
+	return metrics.RegisterOrderQueue(logger, repos.Orders)
+}
+
+// Settings возвращает настройки приложения
+func (a *HTTPApp) Settings() *config.Settings {
+	return a.settings
+}
+
+// Services возвращает сервисы приложения для других транспортов (gRPC)
+func (a *HTTPApp) Services() *service.Services {
+	return a.services
+}
+
+// Start обслуживает запросы до вызова Shutdown; штатная остановка не считается ошибкой
 func (a *HTTPApp) Start() error {
-	address := a.settings.GetServerAddress()
+	a.logger.Infow("HTTP server started", "address", a.server.Addr)
+
+	if err := a.server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
 
-	err := a.router.Run(address)
+// Shutdown переводит экземпляр в неготовое состояние, выжидает ShutdownDelay, чтобы балансировщик
+// успел убрать его из ротации, затем перестает принимать соединения и дожидается выполняющихся
+// запросов до истечения ctx. Не успевшие завершиться соединения закрываются принудительно.
+func (a *HTTPApp) Shutdown(ctx context.Context) error {
+	a.services.Health.StartShutdown()
+	if delay := a.settings.Environment.Server.ShutdownDelay; delay > 0 {
+		a.logger.Infow("Waiting before closing listeners", "delay", delay)
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+		}
+	}
+
+	err := a.server.Shutdown(ctx)
+	if err != nil {
+		a.logger.Warnw("HTTP server drain deadline exceeded, closing remaining connections", "error", err)
+		_ = a.server.Close()
+	}
 	return err
 }
 
-func (a *HTTPApp) Stop() error {
+// Stop останавливает фоновые обработчики, закрывает пул соединений с БД и выгружает
+// оставшиеся спаны. Вызывается после остановки всех транспортов, которые используют сервисы.
+func (a *HTTPApp) Stop() {
 	a.services.UserOrder.Stop()
+	a.services.Webhook.Stop()
+	a.pool.Close()
 
-	return nil
+	ctx, cancel := context.WithTimeout(context.Background(), tracingFlushTimeout)
+	defer cancel()
+	if err := a.tracing(ctx); err != nil {
+		a.logger.Warnw("Failed to flush traces", "error", err)
+	}
 }
@@ -0,0 +1,61 @@
+package app
+
+import (
+	"gophermart-service/internal/base"
+	"gophermart-service/internal/config"
+	"gophermart-service/internal/handler"
+	"gophermart-service/internal/openapi"
+	"reflect"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+type stubHandler struct{}
+
+func (stubHandler) Handle(*gin.Context) {}
+
+// newRoutesOnlyApp собирает приложение без БД и сервисов: для регистрации маршрутов
+// достаточно, чтобы у каждого обработчика был метод Handle
+func newRoutesOnlyApp(t *testing.T) *HTTPApp {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	handlers := &handler.Handlers{}
+	handlerType := reflect.TypeOf((*base.HandlerInterface)(nil)).Elem()
+	fields := reflect.ValueOf(handlers).Elem()
+	for i := range fields.NumField() {
+		if fields.Field(i).Type() == handlerType {
+			fields.Field(i).Set(reflect.ValueOf(stubHandler{}))
+		}
+	}
+
+	return &HTTPApp{
+		router: gin.New(),
+		logger: zap.NewNop().Sugar(),
+		settings: &config.Settings{
+			Environment: &config.EnvironmentSettings{API: &config.APISettings{}},
+		},
+		handlers: handlers,
+	}
+}
+
+func TestRoutesAreDescribedInOpenAPISpec(t *testing.T) {
+	a := newRoutesOnlyApp(t)
+	a.SetupRoutes()
+
+	if err := openapi.CheckRoutes(a.router.Routes()); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestCheckRoutesReportsUndescribedRoute(t *testing.T) {
+	a := newRoutesOnlyApp(t)
+	a.SetupRoutes()
+	a.router.GET("/api/user/undocumented", stubHandler{}.Handle)
+
+	if err := openapi.CheckRoutes(a.router.Routes()); err == nil {
+		t.Fatal("CheckRoutes accepted a route missing from the spec")
+	}
+}
@@ -0,0 +1,90 @@
+package base
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// ErrInvalidAmount сумма не является неотрицательным числом с не более чем двумя знаками после точки
+var ErrInvalidAmount = errors.New("amount must be a non-negative decimal with at most two fractional digits")
+
+// Amount денежная сумма в баллах для API v2, хранится в копейках. Передается строкой с двумя знаками
+// после точки ("500.50"), чтобы клиенты не теряли точность на числах с плавающей точкой.
+// На вход принимается и строка, и число.
+type Amount int64
+
+// NewAmount переводит сумму с плавающей точкой в сумму API, округляя до копеек
+func NewAmount[T float32 | float64](value T) Amount {
+	return Amount(math.Round(float64(value) * 100))
+}
+
+// Cents возвращает сумму в копейках
+func (a Amount) Cents() int64 {
+	return int64(a)
+}
+
+func (a Amount) String() string {
+	cents := int64(a)
+	sign := ""
+	if cents < 0 {
+		sign, cents = "-", -cents
+	}
+	return fmt.Sprintf("%s%d.%02d", sign, cents/100, cents%100)
+}
+
+func (a Amount) MarshalJSON() ([]byte, error) {
+	return []byte(`"` + a.String() + `"`), nil
+}
+
+func (a *Amount) UnmarshalJSON(data []byte) error {
+	raw := string(bytes.TrimSpace(data))
+	if strings.HasPrefix(raw, `"`) {
+		if err := json.Unmarshal(data, &raw); err != nil {
+			return ErrInvalidAmount
+		}
+	}
+
+	value, err := ParseAmount(raw)
+	if err != nil {
+		return err
+	}
+	*a = value
+	return nil
+}
+
+// ParseAmount разбирает десятичную запись суммы без экспоненты
+func ParseAmount(raw string) (Amount, error) {
+	raw = strings.TrimSpace(raw)
+	integer, fraction, _ := strings.Cut(raw, ".")
+	if !isDigits(integer) || len(fraction) > 2 || (fraction != "" && !isDigits(fraction)) ||
+		strings.HasSuffix(raw, ".") {
+		return 0, ErrInvalidAmount
+	}
+
+	units, err := strconv.ParseInt(integer, 10, 64)
+	if err != nil || units > (math.MaxInt64-99)/100 {
+		return 0, ErrInvalidAmount
+	}
+	cents := int64(0)
+	if fraction != "" {
+		cents, _ = strconv.ParseInt((fraction + "0")[:2], 10, 64)
+	}
+	return Amount(units*100 + cents), nil
+}
+
+func isDigits(value string) bool {
+	if value == "" {
+		return false
+	}
+	for _, r := range value {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
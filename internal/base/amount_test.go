@@ -0,0 +1,70 @@
+package base
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestAmountUnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     string
+		wantCents int64
+		wantErr   error
+	}{
+		{name: "string with cents", input: `"751.50"`, wantCents: 75150},
+		{name: "number with cents", input: `751.5`, wantCents: 75150},
+		{name: "integer string", input: `"500"`, wantCents: 50000},
+		{name: "single fractional digit", input: `"0.1"`, wantCents: 10},
+		// float32 хранит 16777217.01 как 16777216: копейки и единица теряются
+		{name: "beyond float32 precision", input: `"16777217.01"`, wantCents: 1677721701},
+		{name: "three fractional digits", input: `"1.001"`, wantErr: ErrInvalidAmount},
+		{name: "negative", input: `"-1.00"`, wantErr: ErrInvalidAmount},
+		{name: "exponent", input: `5e2`, wantErr: ErrInvalidAmount},
+		{name: "trailing dot", input: `"5."`, wantErr: ErrInvalidAmount},
+		{name: "overflow", input: `"99999999999999999999"`, wantErr: ErrInvalidAmount},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var amount Amount
+			err := json.Unmarshal([]byte(tt.input), &amount)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if amount.Cents() != tt.wantCents {
+				t.Errorf("cents = %d, want %d", amount.Cents(), tt.wantCents)
+			}
+		})
+	}
+}
+
+func TestAmountMarshalJSON(t *testing.T) {
+	tests := []struct {
+		name   string
+		amount Amount
+		want   string
+	}{
+		{name: "whole", amount: 50000, want: `"500.00"`},
+		{name: "cents", amount: 75105, want: `"751.05"`},
+		{name: "from float32", amount: NewAmount(float32(0.29)), want: `"0.29"`},
+		{name: "negative", amount: -150, want: `"-1.50"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := json.Marshal(tt.amount)
+			if err != nil {
+				t.Fatalf("marshal: %v", err)
+			}
+			if string(data) != tt.want {
+				t.Errorf("json = %s, want %s", data, tt.want)
+			}
+		})
+	}
+}
@@ -3,4 +3,6 @@ package base
 const (
 	AuthorizationHeader = "Authorization"
 	BearerPrefix        = "Bearer "
+	APIKeyHeader        = "X-API-Key"
+	LinkHeader          = "Link"
 )
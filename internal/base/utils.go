@@ -3,6 +3,8 @@ package base
 import (
 	"context"
 	"gophermart-service/internal/config"
+	"net/http"
+	"net/url"
 	"strings"
 	"time"
 
@@ -55,3 +57,25 @@ func SetTokenToCookie(c *gin.Context, token string, jwtSettings *config.JWTSetti
 func SetTokenToHeader(c *gin.Context, token string) {
 	c.Header(AuthorizationHeader, BearerPrefix+token)
 }
+
+// SetNextPageLink выставляет заголовок Link со ссылкой на следующую страницу списка.
+// В ссылке сохраняются исходные параметры запроса, offset заменяется курсором.
+func SetNextPageLink(c *gin.Context, cursor string) {
+	if cursor == "" {
+		return
+	}
+
+	query := c.Request.URL.Query()
+	query.Del("offset")
+	query.Set("cursor", cursor)
+
+	next := url.URL{Path: c.Request.URL.Path, RawQuery: query.Encode()}
+	// Add, а не Set: ответы v1 уже содержат в Link ссылку на маршрут второй версии
+	c.Writer.Header().Add(LinkHeader, "<"+next.String()+`>; rel="next"`)
+}
+
+// DisableWriteDeadline снимает таймаут записи сервера для длительных ответов (потоки событий,
+// выгрузки), которые иначе обрывались бы по WriteTimeout
+func DisableWriteDeadline(c *gin.Context) error {
+	return http.NewResponseController(c.Writer).SetWriteDeadline(time.Time{})
+}
@@ -0,0 +1,10 @@
+package config
+
+import "time"
+
+// APISettings содержит настройки версионирования HTTP API.
+// Заголовки Deprecation и Sunset в ответах v1 отправляются, только если оператор задал соответствующую дату.
+type APISettings struct {
+	V1DeprecatedAt time.Time `envconfig:"API_V1_DEPRECATED_AT"`
+	V1Sunset       time.Time `envconfig:"API_V1_SUNSET"`
+}
@@ -5,11 +5,14 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"os"
 	"time"
 
 	"github.com/golang-migrate/migrate/v4"
 	"github.com/golang-migrate/migrate/v4/database/postgres"
+	"github.com/golang-migrate/migrate/v4/source"
 	_ "github.com/golang-migrate/migrate/v4/source/file"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/jackc/pgx/v5/stdlib"
 )
@@ -21,8 +24,13 @@ type PGSettings struct {
 	MigrationsPath string `envconfig:"MIGRATIONS_PATH" default:"migrations"`
 }
 
-func SetupDB(ctx context.Context, logger LoggerInterface, pgDSN, migrationsPath string) (*pgxpool.Pool, error) {
-	pool, err := InitPostgresDB(ctx, pgDSN)
+func SetupDB(
+	ctx context.Context,
+	logger LoggerInterface,
+	pgDSN, migrationsPath string,
+	tracer pgx.QueryTracer,
+) (*pgxpool.Pool, error) {
+	pool, err := InitPostgresDB(ctx, pgDSN, tracer)
 	if err != nil {
 		return nil, fmt.Errorf("failed to initialize postgres database: %w", err)
 	}
@@ -37,7 +45,8 @@ func SetupDB(ctx context.Context, logger LoggerInterface, pgDSN, migrationsPath
 	return pool, nil
 }
 
-func InitPostgresDB(ctx context.Context, pgDSN string) (*pgxpool.Pool, error) {
+// InitPostgresDB создает пул соединений; tracer, если задан, получает все запросы пула
+func InitPostgresDB(ctx context.Context, pgDSN string, tracer pgx.QueryTracer) (*pgxpool.Pool, error) {
 	config, err := pgxpool.ParseConfig(pgDSN)
 	if err != nil {
 		return nil, err
@@ -47,6 +56,7 @@ func InitPostgresDB(ctx context.Context, pgDSN string) (*pgxpool.Pool, error) {
 	config.MinConns = 5                       // Минимальное количество соединений
 	config.MaxConnLifetime = time.Hour        // Время жизни соединения
 	config.MaxConnIdleTime = time.Minute * 30 // Время простоя соединения
+	config.ConnConfig.Tracer = tracer
 
 	return pgxpool.NewWithConfig(ctx, config)
 }
@@ -85,3 +95,28 @@ func RunMigrations(logger LoggerInterface, pool *pgxpool.Pool, migrationsPath st
 
 	return nil
 }
+
+// LatestMigrationVersion возвращает номер последней миграции в каталоге: до этой версии
+// приложение приводит схему БД при запуске и ее же ожидает проверка готовности
+func LatestMigrationVersion(migrationsPath string) (uint, error) {
+	driver, err := source.Open(fmt.Sprintf("file://%s", migrationsPath))
+	if err != nil {
+		return 0, fmt.Errorf("failed to open migrations source: %w", err)
+	}
+	defer driver.Close()
+
+	version, err := driver.First()
+	if err != nil {
+		return 0, fmt.Errorf("failed to read first migration: %w", err)
+	}
+	for {
+		next, err := driver.Next(version)
+		if errors.Is(err, os.ErrNotExist) {
+			return version, nil
+		}
+		if err != nil {
+			return 0, fmt.Errorf("failed to read migration after %d: %w", version, err)
+		}
+		version = next
+	}
+}
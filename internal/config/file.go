@@ -0,0 +1,266 @@
+package config
+
+import (
+	"encoding"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/pelletier/go-toml/v2"
+	"gopkg.in/yaml.v3"
+)
+
+// ConfigFileEnv переменная окружения с путем к файлу настроек; флаг -config приоритетнее нее
+const ConfigFileEnv = "CONFIG_FILE"
+
+var ErrUnsupportedConfigFormat = errors.New("unsupported config file format, expected .yaml, .yml or .toml")
+
+// applyConfigFile записывает значения из файла настроек в поля, для которых не задана переменная окружения.
+// Настройки уже разобраны envconfig, поэтому переменные окружения остаются приоритетнее файла, а файл —
+// приоритетнее значений по умолчанию. Окружение процесса не меняется: секреты из файла не наследуются
+// дочерними процессами и не видны в /proc/<pid>/environ.
+func applyConfigFile(path string, settings *EnvironmentSettings) error {
+	values, err := readConfigFile(path)
+	if err != nil {
+		return err
+	}
+
+	sections := reflect.ValueOf(settings).Elem()
+	var problems []string
+	for i := range sections.NumField() {
+		prefix := strings.ToUpper(sections.Type().Field(i).Name)
+		section := sections.Field(i).Elem()
+
+		for j := range section.NumField() {
+			field := section.Type().Field(j)
+			name := field.Tag.Get("envconfig")
+			value, ok := values[name]
+			if !ok || isEnvSet(prefix, name) {
+				continue
+			}
+			if err = setFieldValue(section.Field(j), value); err != nil {
+				problems = append(problems, fmt.Sprintf("%s: invalid %s value %q", name, field.Type, value))
+			}
+		}
+	}
+
+	if len(problems) > 0 {
+		sort.Strings(problems)
+		return &ValidationError{Source: path, Problems: problems}
+	}
+	return nil
+}
+
+// isEnvSet повторяет поиск envconfig: сначала PREFIX_NAME, затем имя из тега
+func isEnvSet(prefix, name string) bool {
+	if _, ok := os.LookupEnv(strings.ToUpper(prefix + "_" + name)); ok {
+		return true
+	}
+	_, ok := os.LookupEnv(name)
+	return ok
+}
+
+// setFieldValue разбирает строковое значение по тем же правилам, что и envconfig
+func setFieldValue(field reflect.Value, value string) error {
+	if unmarshaler, ok := field.Addr().Interface().(encoding.TextUnmarshaler); ok {
+		return unmarshaler.UnmarshalText([]byte(value))
+	}
+	if field.Type() == reflect.TypeOf(time.Duration(0)) {
+		duration, err := time.ParseDuration(value)
+		if err != nil {
+			return err
+		}
+		field.SetInt(int64(duration))
+		return nil
+	}
+
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(value)
+	case reflect.Bool:
+		parsed, err := strconv.ParseBool(value)
+		if err != nil {
+			return err
+		}
+		field.SetBool(parsed)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		parsed, err := strconv.ParseInt(value, 0, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+		field.SetInt(parsed)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		parsed, err := strconv.ParseUint(value, 0, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+		field.SetUint(parsed)
+	case reflect.Float32, reflect.Float64:
+		parsed, err := strconv.ParseFloat(value, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+		field.SetFloat(parsed)
+	case reflect.Slice:
+		items := reflect.MakeSlice(field.Type(), 0, 0)
+		if strings.TrimSpace(value) != "" {
+			parts := strings.Split(value, ",")
+			items = reflect.MakeSlice(field.Type(), len(parts), len(parts))
+			for i, part := range parts {
+				if err := setFieldValue(items.Index(i), part); err != nil {
+					return err
+				}
+			}
+		}
+		field.Set(items)
+	default:
+		return fmt.Errorf("unsupported setting type %s", field.Type())
+	}
+	return nil
+}
+
+// readConfigFile читает файл YAML или TOML. Разделы файла соответствуют полям EnvironmentSettings,
+// ключи — полям разделов в snake_case (server.write_timeout, jwt.secret_key). Возвращает значения
+// по именам переменных окружения; неизвестные разделы и ключи считаются ошибкой, чтобы опечатка
+// не оставляла настройку со значением по умолчанию.
+func readConfigFile(path string) (map[string]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	document := make(map[string]any)
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, &document)
+	case ".toml":
+		err = toml.Unmarshal(data, &document)
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnsupportedConfigFormat, ext)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse config file %s: %w", path, err)
+	}
+
+	keys := fileKeys()
+	values := make(map[string]string)
+	var problems []string
+	for section, raw := range document {
+		options, ok := keys[section]
+		if !ok {
+			problems = append(problems, fmt.Sprintf("%s: unknown section", section))
+			continue
+		}
+		table, ok := raw.(map[string]any)
+		if !ok {
+			problems = append(problems, fmt.Sprintf("%s: must be a table of settings", section))
+			continue
+		}
+		for key, value := range table {
+			name, ok := options[key]
+			if !ok {
+				problems = append(problems, fmt.Sprintf("%s.%s: unknown setting", section, key))
+				continue
+			}
+			// Пустое значение оставляет значение по умолчанию
+			if value == nil {
+				continue
+			}
+			formatted, err := formatFileValue(value)
+			if err != nil {
+				problems = append(problems, fmt.Sprintf("%s.%s: %v", section, key, err))
+				continue
+			}
+			values[name] = formatted
+		}
+	}
+
+	if len(problems) > 0 {
+		sort.Strings(problems)
+		return nil, &ValidationError{Source: path, Problems: problems}
+	}
+	return values, nil
+}
+
+// fileKeys строит соответствие "раздел -> ключ -> переменная окружения" по тегам envconfig
+func fileKeys() map[string]map[string]string {
+	sections := reflect.TypeOf(EnvironmentSettings{})
+	keys := make(map[string]map[string]string, sections.NumField())
+
+	for i := range sections.NumField() {
+		section := sections.Field(i)
+		fields := section.Type.Elem()
+
+		options := make(map[string]string, fields.NumField())
+		for j := range fields.NumField() {
+			field := fields.Field(j)
+			if name := field.Tag.Get("envconfig"); name != "" {
+				options[snakeCase(field.Name)] = name
+			}
+		}
+		keys[snakeCase(section.Name)] = options
+	}
+	return keys
+}
+
+// formatFileValue приводит значение из файла к строке в том виде, в каком его ждет envconfig
+func formatFileValue(value any) (string, error) {
+	switch v := value.(type) {
+	case string:
+		return v, nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	case int:
+		return strconv.Itoa(v), nil
+	case int64:
+		return strconv.FormatInt(v, 10), nil
+	case uint64:
+		return strconv.FormatUint(v, 10), nil
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), nil
+	case time.Time:
+		return v.Format(time.RFC3339Nano), nil
+	case []any:
+		// Списки envconfig принимает через запятую
+		items := make([]string, 0, len(v))
+		for _, item := range v {
+			formatted, err := formatFileValue(item)
+			if err != nil {
+				return "", err
+			}
+			items = append(items, formatted)
+		}
+		return strings.Join(items, ","), nil
+	case map[string]any:
+		return "", errors.New("must be a value, not a table")
+	case fmt.Stringer:
+		// Локальные дата и время TOML
+		return v.String(), nil
+	default:
+		return "", fmt.Errorf("unsupported value type %T", value)
+	}
+}
+
+// snakeCase переводит имя поля Go в ключ файла: HTTPTimeout -> http_timeout, V1Sunset -> v1_sunset
+func snakeCase(name string) string {
+	runes := []rune(name)
+	var b strings.Builder
+	for i, r := range runes {
+		if i > 0 && unicode.IsUpper(r) {
+			prev := runes[i-1]
+			nextIsLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) || (unicode.IsUpper(prev) && nextIsLower) {
+				b.WriteByte('_')
+			}
+		}
+		b.WriteRune(unicode.ToLower(r))
+	}
+	return b.String()
+}
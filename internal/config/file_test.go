@@ -0,0 +1,119 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+const testConfigFile = `
+server:
+  address: file:8080
+integration:
+  accrual_system_address: http://file-accrual
+database:
+  uri: postgres://file
+jwt:
+  secret_key: file-secret
+  issuer: file-issuer
+  token_duration: 2h
+webhook:
+  allow_private_networks: true
+`
+
+func writeConfigFile(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("write config file: %v", err)
+	}
+	return path
+}
+
+// loadTestSettings собирает настройки так же, как NewSettings, но с заданными флагами вместо разбора os.Args
+func loadTestSettings(t *testing.T, path string, flags *Flags) *Settings {
+	t.Helper()
+	envSettings, err := NewEnvironmentSettings()
+	if err != nil {
+		t.Fatalf("NewEnvironmentSettings: %v", err)
+	}
+	if err = applyConfigFile(path, envSettings); err != nil {
+		t.Fatalf("applyConfigFile: %v", err)
+	}
+	return &Settings{Environment: envSettings, Flags: flags}
+}
+
+func TestSettingsPrecedence(t *testing.T) {
+	path := writeConfigFile(t, testConfigFile)
+	t.Setenv("RUN_ADDRESS", "env:8080")
+	t.Setenv("DATABASE_URI", "postgres://env")
+	t.Setenv("JWT_ISSUER", "env-issuer")
+	// Переменная может остаться от окружения разработчика; для проверки файла она не должна быть задана
+	for _, name := range []string{"ACCRUAL_SYSTEM_ADDRESS", "JWT_SECRET_KEY", "JWT_TOKEN_DURATION", "JWT_ALGORITHM",
+		"WEBHOOK_ALLOW_PRIVATE_NETWORKS"} {
+		unsetEnv(t, name)
+	}
+
+	settings := loadTestSettings(t, path, &Flags{RunAddress: "flag:8080"})
+
+	tests := []struct {
+		name string
+		got  any
+		want any
+	}{
+		{name: "flag over env", got: settings.GetServerAddress(), want: "flag:8080"},
+		{name: "env over file", got: settings.GetDatabaseURI(), want: "postgres://env"},
+		{name: "env over file in section", got: settings.Environment.JWT.Issuer, want: "env-issuer"},
+		{name: "file over default", got: settings.GetAccrualSystemAddress(), want: "http://file-accrual"},
+		{name: "file duration", got: settings.Environment.JWT.TokenDuration, want: 2 * time.Hour},
+		{name: "file bool", got: settings.Environment.Webhook.AllowPrivateNetworks, want: true},
+		{name: "file secret", got: settings.Environment.JWT.SecretKey, want: "file-secret"},
+		{name: "default", got: settings.Environment.JWT.Algorithm, want: "HS256"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.got != tt.want {
+				t.Errorf("got %v, want %v", tt.got, tt.want)
+			}
+		})
+	}
+
+	// Значения из файла не попадают в окружение процесса
+	if value, ok := os.LookupEnv("JWT_SECRET_KEY"); ok {
+		t.Errorf("JWT_SECRET_KEY leaked into the environment: %q", value)
+	}
+}
+
+func TestApplyConfigFileInvalidValue(t *testing.T) {
+	path := writeConfigFile(t, "jwt:\n  token_duration: soon\n")
+	unsetEnv(t, "JWT_TOKEN_DURATION")
+
+	envSettings, err := NewEnvironmentSettings()
+	if err != nil {
+		t.Fatalf("NewEnvironmentSettings: %v", err)
+	}
+
+	err = applyConfigFile(path, envSettings)
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("error = %v, want *ValidationError", err)
+	}
+	if validationErr.Source != path || len(validationErr.Problems) != 1 {
+		t.Errorf("error = %v, want one problem in %s", validationErr, path)
+	}
+}
+
+// unsetEnv удаляет переменную на время теста и восстанавливает ее прежнее значение
+func unsetEnv(t *testing.T, name string) {
+	t.Helper()
+	value, ok := os.LookupEnv(name)
+	if !ok {
+		return
+	}
+	t.Setenv(name, value)
+	if err := os.Unsetenv(name); err != nil {
+		t.Fatalf("unset %s: %v", name, err)
+	}
+}
@@ -6,6 +6,7 @@ type Flags struct {
 	RunAddress           string
 	AccrualSystemAddress string
 	DatabaseURI          string
+	ConfigPath           string
 }
 
 func NewFlags() *Flags {
@@ -27,11 +28,18 @@ func NewFlags() *Flags {
 		"адрес подключения к базе данных",
 	)
 
+	configPath := flag.String(
+		"config",
+		"",
+		"путь к файлу настроек YAML или TOML",
+	)
+
 	flag.Parse()
 
 	return &Flags{
 		RunAddress:           *runAddress,
 		AccrualSystemAddress: *accrualSystemAddress,
 		DatabaseURI:          *databaseURI,
+		ConfigPath:           *configPath,
 	}
 }
@@ -0,0 +1,8 @@
+package config
+
+// GRPCSettings содержит настройки gRPC API, запускаемого вместе с HTTP API
+type GRPCSettings struct {
+	Enabled    bool   `envconfig:"GRPC_ENABLED" default:"true"`
+	Address    string `envconfig:"GRPC_ADDRESS" default:"localhost:9090"`
+	Reflection bool   `envconfig:"GRPC_REFLECTION" default:"true"`
+}
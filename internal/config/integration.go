@@ -1,6 +1,6 @@
 package config
 
-const DefaultAccrualSystemAddress = "localhost:8081"
+const DefaultAccrualSystemAddress = "http://localhost:8081"
 
 type IntegrationSettings struct {
 	AccrualSystemAddress string `envconfig:"ACCRUAL_SYSTEM_ADDRESS"`
@@ -9,6 +9,9 @@ type JWTSettings struct {
 	Issuer        string        `envconfig:"JWT_ISSUER" default:"gophermart-service" required:"false"`
 	Algorithm     string        `envconfig:"JWT_ALGORITHM" default:"HS256" required:"false"`
 
+	// Время жизни промежуточного токена второго шага входа (2FA)
+	ChallengeTokenDuration time.Duration `envconfig:"JWT_CHALLENGE_TOKEN_DURATION" default:"5m" required:"false"`
+
 	// Настройки куки
 	CookieName     string `envconfig:"JWT_COOKIE_NAME" default:"token" required:"false"`
 	CookiePath     string `envconfig:"JWT_COOKIE_PATH" default:"/" required:"false"`
@@ -0,0 +1,12 @@
+package config
+
+const (
+	NotifierTypeLog  = "log"
+	NotifierTypeFile = "file"
+)
+
+// NotifierSettings содержит настройки доставки уведомлений пользователям
+type NotifierSettings struct {
+	Type     string `envconfig:"NOTIFIER_TYPE" default:"log"`
+	FilePath string `envconfig:"NOTIFIER_FILE_PATH" default:"notifications.log"`
+}
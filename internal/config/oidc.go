@@ -0,0 +1,15 @@
+package config
+
+import "time"
+
+// OIDCSettings содержит настройки входа через внешнего OpenID Connect провайдера
+type OIDCSettings struct {
+	Enabled      bool          `envconfig:"OIDC_ENABLED" default:"false"`
+	IssuerURL    string        `envconfig:"OIDC_ISSUER_URL" default:""`
+	ClientID     string        `envconfig:"OIDC_CLIENT_ID" default:""`
+	ClientSecret string        `envconfig:"OIDC_CLIENT_SECRET" default:""`
+	RedirectURL  string        `envconfig:"OIDC_REDIRECT_URL" default:"http://localhost:8080/api/user/oidc/callback"`
+	Scopes       []string      `envconfig:"OIDC_SCOPES" default:"openid,profile,email"`
+	StateTTL     time.Duration `envconfig:"OIDC_STATE_TTL" default:"10m"`
+	HTTPTimeout  time.Duration `envconfig:"OIDC_HTTP_TIMEOUT" default:"10s"`
+}
@@ -0,0 +1,45 @@
+package config
+
+import "time"
+
+const (
+	PasswordHashAlgorithmBcrypt   = "bcrypt"
+	PasswordHashAlgorithmArgon2id = "argon2id"
+)
+
+// BcryptMaxPasswordBytes наибольшая длина пароля в байтах, которую принимает bcrypt
+const BcryptMaxPasswordBytes = 72
+
+// PasswordSettings содержит настройки хеширования, смены и сброса пароля
+type PasswordSettings struct {
+	ResetTokenTTL time.Duration `envconfig:"PASSWORD_RESET_TOKEN_TTL" default:"30m"`
+	ResetURL      string        `envconfig:"PASSWORD_RESET_URL" default:""`
+
+	// Настройки хеширования
+	HashAlgorithm    string `envconfig:"PASSWORD_HASH_ALGORITHM" default:"bcrypt"`
+	BcryptCost       int    `envconfig:"PASSWORD_BCRYPT_COST" default:"10"`
+	Argon2Time       uint32 `envconfig:"PASSWORD_ARGON2_TIME" default:"3"`
+	Argon2Memory     uint32 `envconfig:"PASSWORD_ARGON2_MEMORY" default:"65536"` // в KiB
+	Argon2Threads    uint8  `envconfig:"PASSWORD_ARGON2_THREADS" default:"2"`
+	Argon2KeyLength  uint32 `envconfig:"PASSWORD_ARGON2_KEY_LENGTH" default:"32"`
+	Argon2SaltLength uint32 `envconfig:"PASSWORD_ARGON2_SALT_LENGTH" default:"16"`
+
+	// Политика сложности пароля
+	PolicyMinLength        int  `envconfig:"PASSWORD_MIN_LENGTH" default:"6"`
+	PolicyMaxLength        int  `envconfig:"PASSWORD_MAX_LENGTH" default:"72"`
+	PolicyRequireUppercase bool `envconfig:"PASSWORD_REQUIRE_UPPERCASE" default:"false"`
+	PolicyRequireLowercase bool `envconfig:"PASSWORD_REQUIRE_LOWERCASE" default:"false"`
+	PolicyRequireDigit     bool `envconfig:"PASSWORD_REQUIRE_DIGIT" default:"false"`
+	PolicyRequireSpecial   bool `envconfig:"PASSWORD_REQUIRE_SPECIAL" default:"false"`
+	PolicyRejectLogin      bool `envconfig:"PASSWORD_REJECT_LOGIN" default:"true"`
+	PolicyRejectCommon     bool `envconfig:"PASSWORD_REJECT_COMMON" default:"true"`
+}
+
+// MaxPasswordBytes возвращает наибольшую допустимую длину пароля в байтах: PASSWORD_MAX_LENGTH,
+// но не больше, чем принимает алгоритм хеширования
+func (s *PasswordSettings) MaxPasswordBytes() int {
+	if s.HashAlgorithm == PasswordHashAlgorithmBcrypt {
+		return min(s.PolicyMaxLength, BcryptMaxPasswordBytes)
+	}
+	return s.PolicyMaxLength
+}
@@ -0,0 +1,9 @@
+package config
+
+import "time"
+
+// ProfileSettings содержит настройки профиля пользователя и подтверждения email
+type ProfileSettings struct {
+	EmailVerificationTTL time.Duration `envconfig:"EMAIL_VERIFICATION_TTL" default:"24h"`
+	EmailVerificationURL string        `envconfig:"EMAIL_VERIFICATION_URL" default:""`
+}
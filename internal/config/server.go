@@ -1,7 +1,18 @@
 package config
 
+import "time"
+
 const DefaultServerAddress = "localhost:8080"
 
 type ServerSettings struct {
 	Address string `envconfig:"RUN_ADDRESS"`
+
+	// Таймауты HTTP-сервера. Потоки событий и выгрузки снимают WriteTimeout для своего ответа.
+	ReadHeaderTimeout time.Duration `envconfig:"SERVER_READ_HEADER_TIMEOUT" default:"5s"`
+	WriteTimeout      time.Duration `envconfig:"SERVER_WRITE_TIMEOUT" default:"30s"`
+	IdleTimeout       time.Duration `envconfig:"SERVER_IDLE_TIMEOUT" default:"120s"`
+	// ShutdownTimeout сколько ждать завершения выполняющихся запросов при остановке
+	ShutdownTimeout time.Duration `envconfig:"SERVER_SHUTDOWN_TIMEOUT" default:"20s"`
+	// ShutdownDelay сколько /readyz отвечает 503 до закрытия слушателя; входит в ShutdownTimeout
+	ShutdownDelay time.Duration `envconfig:"SERVER_SHUTDOWN_DELAY" default:"0s"`
 }
@@ -1,6 +1,10 @@
 package config
 
 import (
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
 	"strings"
 
 	"github.com/kelseyhightower/envconfig"
@@ -16,71 +20,129 @@ type EnvironmentSettings struct {
 	Database    *PGSettings
 	Server      *ServerSettings
 	JWT         *JWTSettings
+	Notifier    *NotifierSettings
+	Password    *PasswordSettings
+	TwoFactor   *TwoFactorSettings
+	OIDC        *OIDCSettings
+	Profile     *ProfileSettings
+	Webhook     *WebhookSettings
+	GRPC        *GRPCSettings
+	API         *APISettings
+	Tracing     *TracingSettings
 }
 
+// NewSettings собирает настройки с приоритетом: флаг > переменная окружения > файл настроек > значение по умолчанию.
+// Итоговые настройки проверяются целиком, и при ошибке возвращается список всех некорректных значений.
 func NewSettings() (*Settings, error) {
+	flags := NewFlags()
+
 	envSettings, err := NewEnvironmentSettings()
 	if err != nil {
 		return nil, err
 	}
-	flags := NewFlags()
 
-	return &Settings{
+	configPath := strings.TrimSpace(flags.ConfigPath)
+	if configPath == "" {
+		configPath = strings.TrimSpace(os.Getenv(ConfigFileEnv))
+	}
+	if configPath != "" {
+		if err = applyConfigFile(configPath, envSettings); err != nil {
+			return nil, err
+		}
+	}
+
+	settings := &Settings{
 		Environment: envSettings,
 		Flags:       flags,
-	}, nil
+	}
+	if err = settings.Validate(); err != nil {
+		return nil, err
+	}
+
+	return settings, nil
 }
 
+// NewEnvironmentSettings разбирает каждый раздел отдельно, чтобы сообщить обо всех нераспознанных значениях сразу
 func NewEnvironmentSettings() (*EnvironmentSettings, error) {
 	var settings EnvironmentSettings
-	if err := envconfig.Process("", &settings); err != nil {
-		return nil, err
+	sections := reflect.ValueOf(&settings).Elem()
+
+	var problems []string
+	for i := range sections.NumField() {
+		field := sections.Type().Field(i)
+		section := reflect.New(field.Type.Elem())
+		sections.Field(i).Set(section)
+
+		err := envconfig.Process(strings.ToUpper(field.Name), section.Interface())
+		if err == nil {
+			continue
+		}
+
+		var parseErr *envconfig.ParseError
+		if !errors.As(err, &parseErr) {
+			return nil, err
+		}
+		problems = append(problems, formatParseError(field.Type.Elem(), parseErr))
 	}
 
+	if len(problems) > 0 {
+		return nil, &ValidationError{Problems: problems}
+	}
 	return &settings, nil
 }
 
+// formatParseError называет настройку по переменной окружения из тега, а не по внутреннему ключу envconfig
+func formatParseError(section reflect.Type, err *envconfig.ParseError) string {
+	name := err.KeyName
+	if field, ok := section.FieldByName(err.FieldName); ok {
+		if tag := field.Tag.Get("envconfig"); tag != "" {
+			name = tag
+		}
+	}
+	return fmt.Sprintf("%s: invalid %s value %q", name, err.TypeName, err.Value)
+}
+
 func (s *Settings) GetServerAddress() string {
-	// Если указана переменная окружения, то используется она
-	if serverAddr := strings.TrimSpace(s.Environment.Server.Address); serverAddr != "" {
+	// Если указан аргумент командной строки(флаг), то используется он
+	if serverAddr := strings.TrimSpace(s.Flags.RunAddress); serverAddr != "" {
 		return serverAddr
 	}
 
-	// Если нет переменной окружения, но есть аргумент командной строки(флаг), то используется он
-	if serverAddr := strings.TrimSpace(s.Flags.RunAddress); serverAddr != "" {
+	// Если нет флага, но есть переменная окружения или значение из файла настроек, то используется оно
+	if serverAddr := strings.TrimSpace(s.Environment.Server.Address); serverAddr != "" {
 		return serverAddr
 	}
 
-	// Если нет ни переменной окружения, ни флага, то используются значения по умолчанию
+	// Если нет ни флага, ни переменной окружения, ни файла, то используются значения по умолчанию
 	return DefaultServerAddress
 }
 
 func (s *Settings) GetDatabaseURI() string {
-	// Если указана переменная окружения, то используется она
-	if dbURI := strings.TrimSpace(s.Environment.Database.URI); dbURI != "" {
+	// Если указан аргумент командной строки(флаг), то используется он
+	if dbURI := strings.TrimSpace(s.Flags.DatabaseURI); dbURI != "" {
 		return dbURI
 	}
 
-	// Если нет переменной окружения, но есть аргумент командной строки(флаг), то используется он
-	if dbURI := strings.TrimSpace(s.Flags.DatabaseURI); dbURI != "" {
+	// Если нет флага, но есть переменная окружения или значение из файла настроек, то используется оно
+	if dbURI := strings.TrimSpace(s.Environment.Database.URI); dbURI != "" {
 		return dbURI
 	}
 
-	// Если нет ни переменной окружения, ни флага, то используются значения по умолчанию
+	// Если нет ни флага, ни переменной окружения, ни файла, то используются значения по умолчанию
 	return DefaultPostgresDSN
 }
 
 func (s *Settings) GetAccrualSystemAddress() string {
-	// Если указана переменная окружения, то используется она
-	if addr := strings.TrimSpace(s.Environment.Integration.AccrualSystemAddress); addr != "" {
+	// Если указан аргумент командной строки(флаг), то используется он
+	if addr := strings.TrimSpace(s.Flags.AccrualSystemAddress); addr != "" {
 		return addr
 	}
 
-	// Если нет переменной окружения, но есть аргумент командной строки(флаг), то используется он
-	if addr := strings.TrimSpace(s.Flags.AccrualSystemAddress); addr != "" {
+	// Если нет флага, но есть переменная окружения или значение из файла настроек, то используется оно
+	if addr := strings.TrimSpace(s.Environment.Integration.AccrualSystemAddress); addr != "" {
 		return addr
 	}
 
-	// Если нет ни переменной окружения, ни флага, то используются значения по умолчанию
+	// Если нет ни флага, ни переменной окружения, ни файла, то используются значения по умолчанию
 	return DefaultAccrualSystemAddress
 }
@@ -0,0 +1,19 @@
+package config
+
+// Экспортеры трассировки
+const (
+	TracingExporterOTLP   = "otlp"
+	TracingExporterStdout = "stdout"
+)
+
+// TracingSettings содержит настройки трассировки OpenTelemetry. При выключенной трассировке
+// контекст W3C из входящих запросов все равно передается дальше, но спаны не записываются.
+type TracingSettings struct {
+	Enabled     bool    `envconfig:"TRACING_ENABLED" default:"false"`
+	ServiceName string  `envconfig:"TRACING_SERVICE_NAME" default:"gophermart"`
+	Exporter    string  `envconfig:"TRACING_EXPORTER" default:"otlp"`
+	SampleRatio float64 `envconfig:"TRACING_SAMPLE_RATIO" default:"1"`
+	// OTLPEndpoint адрес коллектора OTLP/gRPC
+	OTLPEndpoint string `envconfig:"TRACING_OTLP_ENDPOINT" default:"localhost:4317"`
+	OTLPInsecure bool   `envconfig:"TRACING_OTLP_INSECURE" default:"true"`
+}
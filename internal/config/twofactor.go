@@ -0,0 +1,13 @@
+package config
+
+import "time"
+
+// TwoFactorSettings содержит настройки двухфакторной аутентификации (TOTP)
+type TwoFactorSettings struct {
+	Issuer             string `envconfig:"TOTP_ISSUER" default:"Gophermart"`
+	RecoveryCodesCount int    `envconfig:"TOTP_RECOVERY_CODES_COUNT" default:"10"`
+
+	// После MaxFailedAttempts неверных кодов подряд проверка кодов блокируется на LockoutDuration
+	MaxFailedAttempts int           `envconfig:"TOTP_MAX_FAILED_ATTEMPTS" default:"5"`
+	LockoutDuration   time.Duration `envconfig:"TOTP_LOCKOUT_DURATION" default:"15m"`
+}
@@ -0,0 +1,189 @@
+package config
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// ValidationError перечисляет все некорректные настройки сразу, чтобы их можно было исправить за один запуск
+type ValidationError struct {
+	// Source файл настроек, если ошибки найдены в нем
+	Source   string
+	Problems []string
+}
+
+func (e *ValidationError) Error() string {
+	prefix := "invalid settings"
+	if e.Source != "" {
+		prefix += " in " + e.Source
+	}
+	return fmt.Sprintf("%s: %s", prefix, strings.Join(e.Problems, "; "))
+}
+
+// validator накапливает нарушения; имя настройки — переменная окружения, как в документации
+type validator struct {
+	problems []string
+}
+
+func (v *validator) check(ok bool, name, format string, args ...any) {
+	if !ok {
+		v.problems = append(v.problems, name+": "+fmt.Sprintf(format, args...))
+	}
+}
+
+func (v *validator) positive(name string, value time.Duration) {
+	v.check(value > 0, name, "must be positive, got %s", value)
+}
+
+func (v *validator) oneOf(name, value string, allowed ...string) {
+	v.check(slices.Contains(allowed, value), name, "must be one of %s, got %q", strings.Join(allowed, ", "), value)
+}
+
+func (v *validator) hostPort(name, value string) {
+	_, port, err := net.SplitHostPort(value)
+	v.check(err == nil && port != "", name, "must be host:port, got %q", value)
+}
+
+func (v *validator) httpURL(name, value string) {
+	u, err := url.Parse(value)
+	v.check(err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != "", name,
+		"must be an absolute http(s) URL, got %q", value)
+}
+
+// Validate проверяет итоговые настройки после применения флагов, окружения, файла и значений по умолчанию
+func (s *Settings) Validate() error {
+	v := &validator{}
+	env := s.Environment
+
+	v.hostPort("RUN_ADDRESS (-a)", s.GetServerAddress())
+	_, err := pgxpool.ParseConfig(s.GetDatabaseURI())
+	v.check(err == nil, "DATABASE_URI (-d)", "must be a valid PostgreSQL connection string: %v", err)
+	v.httpURL("ACCRUAL_SYSTEM_ADDRESS (-r)", s.GetAccrualSystemAddress())
+	v.check(env.Integration.AccrualSystemTimeout > 0, "ACCRUAL_SYSTEM_TIMEOUT",
+		"must be a positive number of seconds, got %d", env.Integration.AccrualSystemTimeout)
+
+	info, err := os.Stat(env.Database.MigrationsPath)
+	v.check(err == nil && info.IsDir(), "MIGRATIONS_PATH", "must be an existing directory, got %q", env.Database.MigrationsPath)
+
+	validateServer(v, env.Server)
+	validateJWT(v, env.JWT)
+	validateNotifier(v, env.Notifier)
+	validatePassword(v, env.Password)
+	validateTwoFactor(v, env.TwoFactor)
+	validateOIDC(v, env.OIDC)
+	validateWebhook(v, env.Webhook)
+	validateGRPC(v, env.GRPC, s.GetServerAddress())
+	validateTracing(v, env.Tracing)
+
+	v.positive("EMAIL_VERIFICATION_TTL", env.Profile.EmailVerificationTTL)
+	v.check(env.API.V1Sunset.IsZero() || env.API.V1DeprecatedAt.IsZero() || env.API.V1Sunset.After(env.API.V1DeprecatedAt),
+		"API_V1_SUNSET", "must be after API_V1_DEPRECATED_AT")
+
+	if len(v.problems) > 0 {
+		return &ValidationError{Problems: v.problems}
+	}
+	return nil
+}
+
+func validateServer(v *validator, s *ServerSettings) {
+	v.positive("SERVER_READ_HEADER_TIMEOUT", s.ReadHeaderTimeout)
+	v.positive("SERVER_WRITE_TIMEOUT", s.WriteTimeout)
+	v.positive("SERVER_IDLE_TIMEOUT", s.IdleTimeout)
+	v.positive("SERVER_SHUTDOWN_TIMEOUT", s.ShutdownTimeout)
+	v.check(s.ShutdownDelay >= 0 && s.ShutdownDelay < s.ShutdownTimeout, "SERVER_SHUTDOWN_DELAY",
+		"must be non-negative and less than SERVER_SHUTDOWN_TIMEOUT, got %s", s.ShutdownDelay)
+}
+
+func validateJWT(v *validator, s *JWTSettings) {
+	v.check(strings.TrimSpace(s.SecretKey) != "", "JWT_SECRET_KEY", "must not be empty")
+	v.positive("JWT_TOKEN_DURATION", s.TokenDuration)
+	v.positive("JWT_CHALLENGE_TOKEN_DURATION", s.ChallengeTokenDuration)
+	v.check(s.Issuer != "", "JWT_ISSUER", "must not be empty")
+	// Токены подписываются только HMAC-SHA256
+	v.oneOf("JWT_ALGORITHM", s.Algorithm, "HS256")
+	v.check(s.CookieName != "", "JWT_COOKIE_NAME", "must not be empty")
+	v.oneOf("JWT_COOKIE_SAME_SITE", strings.ToLower(s.CookieSameSite), "lax", "strict", "none")
+}
+
+func validateNotifier(v *validator, s *NotifierSettings) {
+	v.oneOf("NOTIFIER_TYPE", s.Type, NotifierTypeLog, NotifierTypeFile)
+	if s.Type == NotifierTypeFile {
+		v.check(s.FilePath != "", "NOTIFIER_FILE_PATH", "must not be empty when NOTIFIER_TYPE is file")
+	}
+}
+
+func validatePassword(v *validator, s *PasswordSettings) {
+	v.positive("PASSWORD_RESET_TOKEN_TTL", s.ResetTokenTTL)
+	v.oneOf("PASSWORD_HASH_ALGORITHM", s.HashAlgorithm, PasswordHashAlgorithmBcrypt, PasswordHashAlgorithmArgon2id)
+	v.check(s.BcryptCost >= bcrypt.MinCost && s.BcryptCost <= bcrypt.MaxCost, "PASSWORD_BCRYPT_COST",
+		"must be between %d and %d, got %d", bcrypt.MinCost, bcrypt.MaxCost, s.BcryptCost)
+	v.check(s.Argon2Time > 0, "PASSWORD_ARGON2_TIME", "must be positive")
+	v.check(s.Argon2Memory > 0, "PASSWORD_ARGON2_MEMORY", "must be positive")
+	v.check(s.Argon2Threads > 0, "PASSWORD_ARGON2_THREADS", "must be positive")
+	v.check(s.Argon2KeyLength >= 16, "PASSWORD_ARGON2_KEY_LENGTH", "must be at least 16, got %d", s.Argon2KeyLength)
+	v.check(s.Argon2SaltLength >= 8, "PASSWORD_ARGON2_SALT_LENGTH", "must be at least 8, got %d", s.Argon2SaltLength)
+	v.check(s.PolicyMinLength > 0, "PASSWORD_MIN_LENGTH", "must be positive, got %d", s.PolicyMinLength)
+	v.check(s.PolicyMaxLength >= s.PolicyMinLength, "PASSWORD_MAX_LENGTH",
+		"must not be less than PASSWORD_MIN_LENGTH (%d), got %d", s.PolicyMinLength, s.PolicyMaxLength)
+	// Длина считается в байтах; bcrypt отвергает более длинные пароли при хешировании
+	v.check(s.HashAlgorithm != PasswordHashAlgorithmBcrypt || s.PolicyMaxLength <= BcryptMaxPasswordBytes,
+		"PASSWORD_MAX_LENGTH", "must not exceed %d bytes with bcrypt, got %d", BcryptMaxPasswordBytes, s.PolicyMaxLength)
+}
+
+func validateTwoFactor(v *validator, s *TwoFactorSettings) {
+	v.check(s.Issuer != "", "TOTP_ISSUER", "must not be empty")
+	v.check(s.RecoveryCodesCount > 0, "TOTP_RECOVERY_CODES_COUNT", "must be positive, got %d", s.RecoveryCodesCount)
+	v.check(s.MaxFailedAttempts > 0, "TOTP_MAX_FAILED_ATTEMPTS", "must be positive, got %d", s.MaxFailedAttempts)
+	v.positive("TOTP_LOCKOUT_DURATION", s.LockoutDuration)
+}
+
+func validateOIDC(v *validator, s *OIDCSettings) {
+	v.positive("OIDC_STATE_TTL", s.StateTTL)
+	v.positive("OIDC_HTTP_TIMEOUT", s.HTTPTimeout)
+	if !s.Enabled {
+		return
+	}
+	v.httpURL("OIDC_ISSUER_URL", s.IssuerURL)
+	v.httpURL("OIDC_REDIRECT_URL", s.RedirectURL)
+	v.check(s.ClientID != "", "OIDC_CLIENT_ID", "must not be empty when OIDC is enabled")
+	v.check(s.ClientSecret != "", "OIDC_CLIENT_SECRET", "must not be empty when OIDC is enabled")
+	v.check(slices.Contains(s.Scopes, "openid"), "OIDC_SCOPES", "must include openid")
+}
+
+func validateWebhook(v *validator, s *WebhookSettings) {
+	v.check(s.MaxPerUser > 0, "WEBHOOK_MAX_PER_USER", "must be positive, got %d", s.MaxPerUser)
+	v.check(s.MaxAttempts > 0, "WEBHOOK_MAX_ATTEMPTS", "must be positive, got %d", s.MaxAttempts)
+	v.positive("WEBHOOK_INITIAL_BACKOFF", s.InitialBackoff)
+	v.check(s.MaxBackoff >= s.InitialBackoff, "WEBHOOK_MAX_BACKOFF",
+		"must not be less than WEBHOOK_INITIAL_BACKOFF (%s), got %s", s.InitialBackoff, s.MaxBackoff)
+	v.positive("WEBHOOK_POLL_INTERVAL", s.PollInterval)
+	v.positive("WEBHOOK_HTTP_TIMEOUT", s.HTTPTimeout)
+}
+
+func validateGRPC(v *validator, s *GRPCSettings, httpAddress string) {
+	if !s.Enabled {
+		return
+	}
+	v.hostPort("GRPC_ADDRESS", s.Address)
+	v.check(s.Address != httpAddress, "GRPC_ADDRESS", "must differ from RUN_ADDRESS, both are %q", s.Address)
+}
+
+func validateTracing(v *validator, s *TracingSettings) {
+	if !s.Enabled {
+		return
+	}
+	v.check(s.ServiceName != "", "TRACING_SERVICE_NAME", "must not be empty")
+	v.oneOf("TRACING_EXPORTER", s.Exporter, TracingExporterOTLP, TracingExporterStdout)
+	v.check(s.SampleRatio >= 0 && s.SampleRatio <= 1, "TRACING_SAMPLE_RATIO", "must be between 0 and 1, got %g", s.SampleRatio)
+	if s.Exporter == TracingExporterOTLP {
+		v.hostPort("TRACING_OTLP_ENDPOINT", s.OTLPEndpoint)
+	}
+}
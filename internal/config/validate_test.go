@@ -0,0 +1,59 @@
+package config
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidatePasswordMaxLength(t *testing.T) {
+	tests := []struct {
+		name      string
+		algorithm string
+		maxLength int
+		wantErr   bool
+	}{
+		{name: "bcrypt at its limit", algorithm: PasswordHashAlgorithmBcrypt, maxLength: BcryptMaxPasswordBytes},
+		{name: "bcrypt above its limit", algorithm: PasswordHashAlgorithmBcrypt, maxLength: 100, wantErr: true},
+		{name: "argon2id has no limit", algorithm: PasswordHashAlgorithmArgon2id, maxLength: 100},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			settings := &PasswordSettings{
+				ResetTokenTTL:    1,
+				HashAlgorithm:    tt.algorithm,
+				BcryptCost:       10,
+				Argon2Time:       1,
+				Argon2Memory:     64,
+				Argon2Threads:    1,
+				Argon2KeyLength:  16,
+				Argon2SaltLength: 8,
+				PolicyMinLength:  6,
+				PolicyMaxLength:  tt.maxLength,
+			}
+			v := &validator{}
+			validatePassword(v, settings)
+			if tt.wantErr != (len(v.problems) > 0) {
+				t.Fatalf("problems = %q, want error: %v", v.problems, tt.wantErr)
+			}
+			for _, problem := range v.problems {
+				if !strings.HasPrefix(problem, "PASSWORD_MAX_LENGTH: ") {
+					t.Errorf("unexpected problem %q", problem)
+				}
+			}
+		})
+	}
+}
+
+func TestDefaultPasswordSettingsAreValid(t *testing.T) {
+	unsetEnv(t, "PASSWORD_HASH_ALGORITHM")
+	unsetEnv(t, "PASSWORD_MAX_LENGTH")
+	envSettings, err := NewEnvironmentSettings()
+	if err != nil {
+		t.Fatalf("NewEnvironmentSettings: %v", err)
+	}
+	v := &validator{}
+	validatePassword(v, envSettings.Password)
+	if len(v.problems) > 0 {
+		t.Fatalf("default password settings are invalid: %q", v.problems)
+	}
+}
@@ -0,0 +1,16 @@
+package config
+
+import "time"
+
+// WebhookSettings содержит настройки доставки вебхуков партнерским системам
+type WebhookSettings struct {
+	MaxPerUser     int           `envconfig:"WEBHOOK_MAX_PER_USER" default:"10"`
+	MaxAttempts    int           `envconfig:"WEBHOOK_MAX_ATTEMPTS" default:"8"`
+	InitialBackoff time.Duration `envconfig:"WEBHOOK_INITIAL_BACKOFF" default:"30s"`
+	MaxBackoff     time.Duration `envconfig:"WEBHOOK_MAX_BACKOFF" default:"6h"`
+	PollInterval   time.Duration `envconfig:"WEBHOOK_POLL_INTERVAL" default:"2s"`
+	HTTPTimeout    time.Duration `envconfig:"WEBHOOK_HTTP_TIMEOUT" default:"10s"`
+	AllowHTTP      bool          `envconfig:"WEBHOOK_ALLOW_HTTP" default:"false"`
+	// AllowPrivateNetworks разрешает получателей в loopback и частных сетях (только для разработки)
+	AllowPrivateNetworks bool `envconfig:"WEBHOOK_ALLOW_PRIVATE_NETWORKS" default:"false"`
+}
@@ -0,0 +1,174 @@
+package server
+
+import (
+	"context"
+	"gophermart-service/internal/base"
+	"gophermart-service/internal/config"
+	"gophermart-service/internal/grpc/pb"
+	"gophermart-service/internal/problem"
+	"gophermart-service/internal/service"
+	"gophermart-service/internal/service/apikey"
+	"gophermart-service/internal/service/jwt"
+	userSession "gophermart-service/internal/service/user/session"
+	"strings"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+)
+
+const (
+	authorizationMetadata = "authorization"
+	requestIDMetadata     = "x-request-id"
+)
+
+// publicMethods методы, доступные без токена
+var publicMethods = map[string]bool{
+	pb.AuthService_Register_FullMethodName:       true,
+	pb.AuthService_Login_FullMethodName:          true,
+	pb.AuthService_LoginTwoFactor_FullMethodName: true,
+}
+
+// methodScopes перечисляет методы, доступные по API-ключу, и необходимые для них права
+var methodScopes = map[string]string{
+	pb.OrderService_UploadOrder_FullMethodName:       apikey.ScopeOrdersWrite,
+	pb.BalanceService_GetBalance_FullMethodName:      apikey.ScopeBalanceRead,
+	pb.BalanceService_ListWithdrawals_FullMethodName: apikey.ScopeBalanceRead,
+	pb.BalanceService_Withdraw_FullMethodName:        apikey.ScopeWithdrawWrite,
+}
+
+// unaryAuthInterceptor проставляет request id и проверяет токен из метаданных authorization
+// теми же правилами, что и HTTP middleware: подпись, версия токена и активность сессии для JWT,
+// права доступа для API-ключа. Служебные сервисы (reflection) пропускаются без токена.
+func unaryAuthInterceptor(logger config.LoggerInterface, services *service.Services) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		ctx = withRequestID(ctx)
+		if publicMethods[info.FullMethod] || !strings.HasPrefix(info.FullMethod, "/gophermart.") {
+			return handler(ctx, req)
+		}
+
+		ctx, err := authenticate(ctx, logger, services, info.FullMethod)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// streamAuthInterceptor нужен для потоковых служебных методов, например reflection
+func streamAuthInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if strings.HasPrefix(info.FullMethod, "/gophermart.") {
+			return newStatus(codes.Unimplemented, problem.CodeNotFound, "streaming methods are not supported")
+		}
+		return handler(srv, ss)
+	}
+}
+
+func authenticate(
+	ctx context.Context,
+	logger config.LoggerInterface,
+	services *service.Services,
+	method string,
+) (context.Context, error) {
+	requestID := base.GetRequestID(ctx)
+
+	token := bearerToken(ctx)
+	if token == "" {
+		logger.Warnw("gRPC token is missing", "request_id", requestID)
+		return nil, newStatus(codes.Unauthenticated, problem.CodeUnauthorized, "unauthorized")
+	}
+
+	if apikey.IsAPIKey(token) {
+		return authenticateAPIKey(ctx, logger, services.APIKey, method, token)
+	}
+
+	user, err := services.JWT.ValidateToken(ctx, token)
+	if err != nil {
+		logger.Warnw("Invalid gRPC token", "error", err, "request_id", requestID)
+		return nil, newStatus(codes.Unauthenticated, problem.CodeTokenInvalid, err.Error())
+	}
+
+	// Токены, выданные до смены пароля, считаются отозванными
+	tokenVersion, err := services.UserAuth.GetTokenVersion(ctx, user.ID)
+	if err != nil || tokenVersion != user.TokenVersion {
+		logger.Warnw("Revoked gRPC token", "error", err, "user_id", user.ID, "request_id", requestID)
+		return nil, newStatus(codes.Unauthenticated, problem.CodeTokenRevoked, jwt.ErrTokenRevoked.Error())
+	}
+
+	if user.SessionID != 0 {
+		if err = services.Session.Validate(ctx, user.ID, user.SessionID); err != nil {
+			logger.Warnw("Terminated session", "error", err, "session_id", user.SessionID, "request_id", requestID)
+			return nil, newStatus(codes.Unauthenticated, problem.CodeSessionTerminated, userSession.ErrSessionTerminated.Error())
+		}
+	}
+
+	return jwt.SetUserInContext(ctx, user), nil
+}
+
+// authenticateAPIKey аутентифицирует вызов по API-ключу и проверяет право доступа к методу
+func authenticateAPIKey(
+	ctx context.Context,
+	logger config.LoggerInterface,
+	apiKeyService apikey.ServiceInterface,
+	method string,
+	rawKey string,
+) (context.Context, error) {
+	requestID := base.GetRequestID(ctx)
+
+	user, err := apiKeyService.Authenticate(ctx, rawKey)
+	if err != nil {
+		logger.Warnw("Invalid gRPC API key", "error", err, "request_id", requestID)
+		return nil, newStatus(codes.Unauthenticated, problem.CodeAPIKeyInvalid, apikey.ErrInvalidAPIKey.Error())
+	}
+
+	scope, ok := methodScopes[method]
+	if !ok || !user.HasScope(scope) {
+		logger.Warnw("API key scope denied",
+			"method", method,
+			"required_scope", scope,
+			"api_key_id", user.APIKeyID,
+			"request_id", requestID)
+		return nil, newStatus(codes.PermissionDenied, problem.CodeInsufficientScope, "insufficient scope")
+	}
+
+	return jwt.SetUserInContext(ctx, user), nil
+}
+
+// bearerToken извлекает токен из метаданных authorization: Bearer <token>
+func bearerToken(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+	for _, value := range md.Get(authorizationMetadata) {
+		if token, found := strings.CutPrefix(value, base.BearerPrefix); found && token != "" {
+			return token
+		}
+	}
+	return ""
+}
+
+// withRequestID берет request id из метаданных x-request-id или генерирует новый
+func withRequestID(ctx context.Context) context.Context {
+	requestID := ""
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(requestIDMetadata); len(values) > 0 {
+			requestID = values[0]
+		}
+	}
+	if requestID == "" {
+		requestID = uuid.NewString()
+	}
+	return base.SetRequestID(ctx, requestID)
+}
+
+// currentUser возвращает аутентифицированного пользователя; интерцептор гарантирует его наличие
+func currentUser(ctx context.Context) (*jwt.InDTO, error) {
+	user := jwt.ExtractUserFromContext(ctx)
+	if user == nil {
+		return nil, newStatus(codes.Unauthenticated, problem.CodeUnauthorized, "unauthorized")
+	}
+	return user, nil
+}
@@ -0,0 +1,176 @@
+package server
+
+import (
+	"context"
+	"gophermart-service/internal/base"
+	"gophermart-service/internal/config"
+	"gophermart-service/internal/grpc/pb"
+	"gophermart-service/internal/problem"
+	"gophermart-service/internal/service"
+	serviceJWT "gophermart-service/internal/service/jwt"
+	serviceUserAuth "gophermart-service/internal/service/user/auth"
+	serviceSession "gophermart-service/internal/service/user/session"
+	serviceTwoFactor "gophermart-service/internal/service/user/twofactor"
+	"net"
+	"net/http"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+// authServer регистрация и вход; выдает тот же JWT, что и HTTP API
+type authServer struct {
+	pb.UnimplementedAuthServiceServer
+
+	logger      config.LoggerInterface
+	services    *service.Services
+	jwtSettings *config.JWTSettings
+}
+
+func (s *authServer) Register(ctx context.Context, req *pb.RegisterRequest) (*pb.AuthResponse, error) {
+	requestID := base.GetRequestID(ctx)
+	s.logger.Infow("Starting gRPC user registration", "requestID", requestID)
+
+	response, err := s.services.UserAuth.RegisterUser(ctx, &serviceUserAuth.InDTO{
+		Login:    req.GetLogin(),
+		Password: req.GetPassword(),
+	})
+	if err != nil {
+		return nil, toStatus(ctx, s.logger, err)
+	}
+
+	token, err := s.issueToken(ctx, &serviceJWT.InDTO{
+		ID:    response.UserID,
+		Login: req.GetLogin(),
+		Role:  response.Role,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.AuthResponse{UserId: int64(response.UserID), Token: token}, nil
+}
+
+func (s *authServer) Login(ctx context.Context, req *pb.LoginRequest) (*pb.LoginResponse, error) {
+	requestID := base.GetRequestID(ctx)
+	s.logger.Infow("Starting gRPC user auth", "requestID", requestID)
+
+	response, err := s.services.UserAuth.LoginUser(ctx, &serviceUserAuth.InDTO{
+		Login:    req.GetLogin(),
+		Password: req.GetPassword(),
+	})
+	if err != nil {
+		return nil, toStatus(ctx, s.logger, err)
+	}
+
+	user := &serviceJWT.InDTO{
+		ID:           response.UserID,
+		Login:        req.GetLogin(),
+		TokenVersion: response.TokenVersion,
+		Role:         response.Role,
+	}
+
+	// При включенной 2FA токен доступа выдается только после второго шага
+	twoFactorEnabled, err := s.services.TwoFactor.IsEnabled(ctx, response.UserID)
+	if err != nil {
+		s.logger.Errorw("Failed to check two-factor status", "error", err, "request_id", requestID, "login", user.Login)
+		return nil, internalError()
+	}
+	if twoFactorEnabled {
+		challengeToken, err := s.services.JWT.GenerateChallengeToken(ctx, user)
+		if err != nil {
+			s.logger.Errorw("Failed to generate two-factor challenge token", "error", err, "request_id", requestID, "login", user.Login)
+			return nil, internalError()
+		}
+		return &pb.LoginResponse{
+			UserId:            int64(response.UserID),
+			TwoFactorRequired: true,
+			ChallengeToken:    challengeToken,
+		}, nil
+	}
+
+	token, err := s.issueToken(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.LoginResponse{UserId: int64(response.UserID), Token: token}, nil
+}
+
+func (s *authServer) LoginTwoFactor(ctx context.Context, req *pb.LoginTwoFactorRequest) (*pb.AuthResponse, error) {
+	requestID := base.GetRequestID(ctx)
+
+	user, err := s.services.JWT.ValidateChallengeToken(ctx, req.GetChallengeToken())
+	if err != nil {
+		s.logger.Warnw("Invalid two-factor challenge token", "error", err, "request_id", requestID)
+		return nil, newStatus(codes.Unauthenticated, problem.CodeTwoFactorChallengeInvalid, serviceTwoFactor.ErrChallengeIsInvalid.Error())
+	}
+
+	err = s.services.TwoFactor.VerifyChallenge(ctx, &serviceTwoFactor.ChallengeInDTO{
+		UserID:      user.ID,
+		ChallengeID: user.ChallengeID,
+		ExpiresAt:   user.ChallengeExpiresAt,
+		Code:        req.GetCode(),
+	})
+	if err != nil {
+		// На этапе входа неверный код означает неуспешную аутентификацию
+		return nil, toStatus(ctx, s.logger, err,
+			problem.Mapping{Err: serviceTwoFactor.ErrInvalidCode, Status: http.StatusUnauthorized, Code: problem.CodeTwoFactorCodeInvalid},
+			problem.Mapping{Err: serviceTwoFactor.ErrNotEnabled, Status: http.StatusUnauthorized, Code: problem.CodeTwoFactorCodeInvalid},
+		)
+	}
+
+	token, err := s.issueToken(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.AuthResponse{UserId: int64(user.ID), Token: token}, nil
+}
+
+// issueToken создает сессию и подписывает для нее токен доступа
+func (s *authServer) issueToken(ctx context.Context, user *serviceJWT.InDTO) (string, error) {
+	requestID := base.GetRequestID(ctx)
+
+	sessionID, err := s.services.Session.Create(ctx, &serviceSession.CreateInDTO{
+		UserID:    user.ID,
+		UserAgent: userAgent(ctx),
+		IPAddress: peerIP(ctx),
+		ExpiresAt: time.Now().Add(s.jwtSettings.TokenDuration),
+	})
+	if err != nil {
+		s.logger.Errorw("Failed to create session", "error", err, "request_id", requestID, "login", user.Login)
+		return "", internalError()
+	}
+	user.SessionID = sessionID
+
+	token, err := s.services.JWT.GenerateToken(ctx, user)
+	if err != nil {
+		s.logger.Errorw("Failed to generate JWT token", "error", err, "request_id", requestID, "login", user.Login)
+		return "", internalError()
+	}
+	return token, nil
+}
+
+func userAgent(ctx context.Context) string {
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get("user-agent"); len(values) > 0 {
+			return values[0]
+		}
+	}
+	return ""
+}
+
+func peerIP(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return ""
+	}
+	host, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		return p.Addr.String()
+	}
+	return host
+}
@@ -0,0 +1,91 @@
+package server
+
+import (
+	"context"
+	"gophermart-service/internal/base"
+	"gophermart-service/internal/config"
+	"gophermart-service/internal/grpc/pb"
+	serviceUserBalance "gophermart-service/internal/service/user/balance"
+	serviceUserOrders "gophermart-service/internal/service/user/order"
+	serviceUserWithdraw "gophermart-service/internal/service/user/withdraw"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// balanceServer баланс, списание баллов и история списаний
+type balanceServer struct {
+	pb.UnimplementedBalanceServiceServer
+
+	logger              config.LoggerInterface
+	userBalanceService  serviceUserBalance.ServiceInterface
+	userOrdersService   serviceUserOrders.ServiceInterface
+	userWithdrawService serviceUserWithdraw.ServiceInterface
+}
+
+func (s *balanceServer) GetBalance(ctx context.Context, _ *pb.GetBalanceRequest) (*pb.Balance, error) {
+	user, err := currentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	balance, err := s.userBalanceService.GetBalance(ctx, user.ID)
+	if err != nil {
+		return nil, toStatus(ctx, s.logger, err)
+	}
+
+	return &pb.Balance{
+		Current:   float64(balance.CurrentBalance),
+		Withdrawn: float64(balance.TotalWithdrawn),
+	}, nil
+}
+
+func (s *balanceServer) Withdraw(ctx context.Context, req *pb.WithdrawRequest) (*pb.WithdrawResponse, error) {
+	user, err := currentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = s.userOrdersService.ValidateOrderNumber(ctx, req.GetOrder()); err != nil {
+		return nil, toStatus(ctx, s.logger, err)
+	}
+	if err = s.userWithdrawService.MakeNewWithdraw(ctx, user.ID, req.GetOrder(), base.NewAmount(req.GetSum()).Cents()); err != nil {
+		return nil, toStatus(ctx, s.logger, err)
+	}
+
+	return &pb.WithdrawResponse{}, nil
+}
+
+func (s *balanceServer) ListWithdrawals(ctx context.Context, req *pb.ListWithdrawalsRequest) (*pb.ListWithdrawalsResponse, error) {
+	user, err := currentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	limit := int(req.GetLimit())
+	if limit <= 0 {
+		limit = defaultListLimit
+	}
+	page, err := s.userWithdrawService.ListUserWithdrawals(ctx, user.ID, &serviceUserWithdraw.ListInDTO{
+		From:   req.GetFrom(),
+		To:     req.GetTo(),
+		Sort:   req.GetSort(),
+		Cursor: req.GetCursor(),
+		Limit:  limit,
+	})
+	if err != nil {
+		return nil, toStatus(ctx, s.logger, err)
+	}
+
+	response := &pb.ListWithdrawalsResponse{
+		Withdrawals: make([]*pb.Withdrawal, 0, len(page.Withdrawals)),
+		NextCursor:  page.NextCursor,
+	}
+	for _, withdrawal := range page.Withdrawals {
+		response.Withdrawals = append(response.Withdrawals, &pb.Withdrawal{
+			Order:       withdrawal.Order,
+			Sum:         float64(withdrawal.Sum),
+			ProcessedAt: timestamppb.New(withdrawal.ProcessedAt),
+		})
+	}
+	return response, nil
+}
@@ -0,0 +1,70 @@
+package server
+
+import (
+	"context"
+	"gophermart-service/internal/base"
+	"gophermart-service/internal/config"
+	"gophermart-service/internal/problem"
+	"net/http"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const errorDomain = "gophermart"
+
+// httpToCode переводит HTTP-статус из единой таблицы ошибок в код gRPC
+var httpToCode = map[int]codes.Code{
+	http.StatusBadRequest:            codes.InvalidArgument,
+	http.StatusUnauthorized:          codes.Unauthenticated,
+	http.StatusPaymentRequired:       codes.FailedPrecondition,
+	http.StatusForbidden:             codes.PermissionDenied,
+	http.StatusNotFound:              codes.NotFound,
+	http.StatusConflict:              codes.AlreadyExists,
+	http.StatusRequestEntityTooLarge: codes.InvalidArgument,
+	http.StatusUnprocessableEntity:   codes.InvalidArgument,
+	http.StatusTooManyRequests:       codes.ResourceExhausted,
+	http.StatusBadGateway:            codes.Unavailable,
+}
+
+// toStatus преобразует ошибку сервисного слоя в статус gRPC по той же таблице, что и HTTP API.
+// Машиночитаемый код ошибки передается в деталях ErrorInfo.Reason.
+func toStatus(ctx context.Context, logger config.LoggerInterface, err error, overrides ...problem.Mapping) error {
+	requestID := base.GetRequestID(ctx)
+
+	mapping, ok := problem.Lookup(err, overrides...)
+	if !ok {
+		logger.Errorw("Unhandled gRPC error", "request_id", requestID, "error", err)
+		return newStatus(codes.Internal, problem.CodeInternal, "internal server error")
+	}
+
+	code, ok := httpToCode[mapping.Status]
+	if !ok {
+		code = codes.Internal
+	}
+	detail := err.Error()
+	if mapping.Err != nil {
+		detail = mapping.Err.Error()
+	}
+
+	logger.Warnw("gRPC request failed",
+		"request_id", requestID,
+		"grpc_code", code.String(),
+		"code", mapping.Code,
+		"error", err)
+	return newStatus(code, mapping.Code, detail)
+}
+
+func newStatus(code codes.Code, reason problem.Code, message string) error {
+	st := status.New(code, message)
+	withDetails, err := st.WithDetails(&errdetails.ErrorInfo{Reason: string(reason), Domain: errorDomain})
+	if err != nil {
+		return st.Err()
+	}
+	return withDetails.Err()
+}
+
+func internalError() error {
+	return newStatus(codes.Internal, problem.CodeInternal, "internal server error")
+}
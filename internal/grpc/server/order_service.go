@@ -0,0 +1,80 @@
+package server
+
+import (
+	"context"
+	"gophermart-service/internal/base"
+	"gophermart-service/internal/config"
+	"gophermart-service/internal/grpc/pb"
+	serviceUserOrders "gophermart-service/internal/service/user/order"
+	"strings"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const defaultListLimit = 100
+
+// orderServer загрузка номеров заказов и список заказов пользователя
+type orderServer struct {
+	pb.UnimplementedOrderServiceServer
+
+	logger            config.LoggerInterface
+	userOrdersService serviceUserOrders.ServiceInterface
+}
+
+func (s *orderServer) UploadOrder(ctx context.Context, req *pb.UploadOrderRequest) (*pb.UploadOrderResponse, error) {
+	user, err := currentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	orderNumber := strings.TrimSpace(req.GetNumber())
+	if err = s.userOrdersService.LoadNewOrderNumber(ctx, user.ID, orderNumber); err != nil {
+		if serviceUserOrders.IsErrOrderAlreadyExistsForUser(err) {
+			return &pb.UploadOrderResponse{AlreadyUploaded: true}, nil
+		}
+		return nil, toStatus(ctx, s.logger, err)
+	}
+
+	s.logger.Infow("Order successfully processed",
+		"userID", user.ID,
+		"orderNumber", orderNumber,
+		"requestID", base.GetRequestID(ctx))
+	return &pb.UploadOrderResponse{}, nil
+}
+
+func (s *orderServer) ListOrders(ctx context.Context, req *pb.ListOrdersRequest) (*pb.ListOrdersResponse, error) {
+	user, err := currentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	limit := int(req.GetLimit())
+	if limit <= 0 {
+		limit = defaultListLimit
+	}
+	page, err := s.userOrdersService.ListUserOrders(ctx, user.ID, &serviceUserOrders.ListInDTO{
+		Statuses: req.GetStatuses(),
+		From:     req.GetFrom(),
+		To:       req.GetTo(),
+		Sort:     req.GetSort(),
+		Cursor:   req.GetCursor(),
+		Limit:    limit,
+	})
+	if err != nil {
+		return nil, toStatus(ctx, s.logger, err)
+	}
+
+	response := &pb.ListOrdersResponse{
+		Orders:     make([]*pb.Order, 0, len(page.Orders)),
+		NextCursor: page.NextCursor,
+	}
+	for _, order := range page.Orders {
+		response.Orders = append(response.Orders, &pb.Order{
+			Number:     order.OrderNumber,
+			Status:     order.Status,
+			Accrual:    float64(order.Accrual),
+			UploadedAt: timestamppb.New(order.UploadedAt),
+		})
+	}
+	return response, nil
+}
@@ -0,0 +1,43 @@
+package server
+
+import (
+	"gophermart-service/internal/config"
+	"gophermart-service/internal/grpc/pb"
+	"gophermart-service/internal/service"
+
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/reflection"
+)
+
+// New создает gRPC-сервер поверх тех же сервисов, что использует HTTP API
+func New(logger config.LoggerInterface, settings *config.Settings, services *service.Services) *grpc.Server {
+	server := grpc.NewServer(
+		// Обработчик статистики открывает серверный спан вызова из metadata traceparent
+		grpc.StatsHandler(otelgrpc.NewServerHandler()),
+		grpc.ChainUnaryInterceptor(unaryAuthInterceptor(logger, services)),
+		grpc.ChainStreamInterceptor(streamAuthInterceptor()),
+	)
+
+	pb.RegisterAuthServiceServer(server, &authServer{
+		logger:      logger,
+		services:    services,
+		jwtSettings: settings.Environment.JWT,
+	})
+	pb.RegisterOrderServiceServer(server, &orderServer{
+		logger:            logger,
+		userOrdersService: services.UserOrder,
+	})
+	pb.RegisterBalanceServiceServer(server, &balanceServer{
+		logger:              logger,
+		userBalanceService:  services.UserBalance,
+		userOrdersService:   services.UserOrder,
+		userWithdrawService: services.UserWithdraw,
+	})
+
+	if settings.Environment.GRPC.Reflection {
+		reflection.Register(server)
+	}
+
+	return server
+}
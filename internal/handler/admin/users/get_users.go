@@ -0,0 +1,52 @@
+package users
+
+import (
+	"gophermart-service/internal/base"
+	"gophermart-service/internal/config"
+	"gophermart-service/internal/problem"
+	serviceAdmin "gophermart-service/internal/service/admin"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-contrib/requestid"
+	"github.com/gin-gonic/gin"
+)
+
+type getUsersHandler struct {
+	logger       config.LoggerInterface
+	adminService serviceAdmin.ServiceInterface
+}
+
+func NewGetUsersHandler(
+	logger config.LoggerInterface,
+	adminService serviceAdmin.ServiceInterface,
+) base.HandlerInterface {
+	return &getUsersHandler{
+		logger:       logger,
+		adminService: adminService,
+	}
+}
+
+func (h *getUsersHandler) Handle(c *gin.Context) {
+	requestID := requestid.Get(c)
+
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "0"))
+	if err != nil {
+		problem.Write(c, http.StatusBadRequest, problem.CodeInvalidRequest, "invalid limit")
+		return
+	}
+	offset, err := strconv.Atoi(c.DefaultQuery("offset", "0"))
+	if err != nil {
+		problem.Write(c, http.StatusBadRequest, problem.CodeInvalidRequest, "invalid offset")
+		return
+	}
+
+	users, err := h.adminService.ListUsers(c.Request.Context(), limit, offset)
+	if err != nil {
+		h.logger.Errorw("Failed to list users", "requestID", requestID, "error", err)
+		problem.Internal(c)
+		return
+	}
+
+	c.JSON(http.StatusOK, users)
+}
@@ -0,0 +1,64 @@
+package users
+
+import (
+	"gophermart-service/internal/base"
+	"gophermart-service/internal/config"
+	"gophermart-service/internal/problem"
+	serviceAdmin "gophermart-service/internal/service/admin"
+	"gophermart-service/internal/service/jwt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-contrib/requestid"
+	"github.com/gin-gonic/gin"
+)
+
+type putUserRoleHandler struct {
+	logger       config.LoggerInterface
+	adminService serviceAdmin.ServiceInterface
+}
+
+type RoleRequestBody struct {
+	Role string `json:"role" binding:"required"`
+}
+
+func NewPutUserRoleHandler(
+	logger config.LoggerInterface,
+	adminService serviceAdmin.ServiceInterface,
+) base.HandlerInterface {
+	return &putUserRoleHandler{
+		logger:       logger,
+		adminService: adminService,
+	}
+}
+
+func (h *putUserRoleHandler) Handle(c *gin.Context) {
+	requestID := requestid.Get(c)
+
+	actor := jwt.ExtractUserFromContext(c.Request.Context())
+	if actor == nil {
+		h.logger.Warnw("user not found in context", "requestID", requestID)
+		problem.Unauthorized(c)
+		return
+	}
+
+	userID, err := strconv.Atoi(c.Param("id"))
+	if err != nil || userID <= 0 {
+		problem.Write(c, http.StatusBadRequest, problem.CodeInvalidRequest, "invalid user id")
+		return
+	}
+
+	var requestBody RoleRequestBody
+	if err = c.ShouldBindJSON(&requestBody); err != nil {
+		h.logger.Warnw("Invalid JSON in request body", "requestID", requestID, "error", err)
+		problem.InvalidRequest(c, err)
+		return
+	}
+
+	if err = h.adminService.SetRole(c.Request.Context(), actor.ID, userID, requestBody.Role); err != nil {
+		problem.Respond(c, h.logger, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "User role updated"})
+}
@@ -3,37 +3,104 @@ package handler
 import (
 	"gophermart-service/internal/base"
 	"gophermart-service/internal/config"
+	adminUsers "gophermart-service/internal/handler/admin/users"
+	"gophermart-service/internal/handler/docs"
 	"gophermart-service/internal/handler/health"
+	"gophermart-service/internal/handler/metrics"
+	userAccount "gophermart-service/internal/handler/user/account"
+	userAPIKeys "gophermart-service/internal/handler/user/apikeys"
 	userBalance "gophermart-service/internal/handler/user/balance"
 	userLogin "gophermart-service/internal/handler/user/login"
+	userOIDC "gophermart-service/internal/handler/user/oidc"
 	userOrders "gophermart-service/internal/handler/user/orders"
+	userPassword "gophermart-service/internal/handler/user/password"
+	userProfile "gophermart-service/internal/handler/user/profile"
 	userRegister "gophermart-service/internal/handler/user/register"
+	userSessions "gophermart-service/internal/handler/user/sessions"
+	userStatement "gophermart-service/internal/handler/user/statement"
+	userTwoFactor "gophermart-service/internal/handler/user/twofactor"
+	userUpdates "gophermart-service/internal/handler/user/updates"
+	userWebhooks "gophermart-service/internal/handler/user/webhooks"
 	userBalanceWithdraw "gophermart-service/internal/handler/user/withdraw"
+	v2UserBalance "gophermart-service/internal/handler/v2/user/balance"
+	v2UserOrders "gophermart-service/internal/handler/v2/user/orders"
+	v2UserWithdraw "gophermart-service/internal/handler/v2/user/withdraw"
 	"gophermart-service/internal/service"
 )
 
 type Handlers struct {
-	GetHealth               base.HandlerInterface
-	PostUserRegister        base.HandlerInterface
-	PostUserLogin           base.HandlerInterface
-	PostUserOrders          base.HandlerInterface
-	GetUserOrders           base.HandlerInterface
-	GetUserBalance          base.HandlerInterface
-	PostUserBalanceWithdraw base.HandlerInterface
-	GetUserWithdrawals      base.HandlerInterface
+	GetHealth                    base.HandlerInterface
+	GetLivez                     base.HandlerInterface
+	GetReadyz                    base.HandlerInterface
+	GetOpenAPI                   base.HandlerInterface
+	GetSwaggerUI                 base.HandlerInterface
+	GetMetrics                   base.HandlerInterface
+	PostUserRegister             base.HandlerInterface
+	PostUserLogin                base.HandlerInterface
+	PostUserOrders               base.HandlerInterface
+	PostUserOrdersBatch          base.HandlerInterface
+	GetUserOrders                base.HandlerInterface
+	GetUserOrder                 base.HandlerInterface
+	GetUserBalance               base.HandlerInterface
+	PostUserBalanceWithdraw      base.HandlerInterface
+	GetUserWithdrawals           base.HandlerInterface
+	GetUserOrdersStatement       base.HandlerInterface
+	GetUserWithdrawalsStatement  base.HandlerInterface
+	PostUserPassword             base.HandlerInterface
+	PostUserPasswordResetRequest base.HandlerInterface
+	PostUserPasswordReset        base.HandlerInterface
+	PostUserLoginTwoFactor       base.HandlerInterface
+	PostUserTwoFactorEnroll      base.HandlerInterface
+	PostUserTwoFactorConfirm     base.HandlerInterface
+	PostUserTwoFactorDisable     base.HandlerInterface
+	PostUserAPIKeys              base.HandlerInterface
+	GetUserAPIKeys               base.HandlerInterface
+	DeleteUserAPIKey             base.HandlerInterface
+	GetAdminUsers                base.HandlerInterface
+	PutAdminUserRole             base.HandlerInterface
+	GetUserOIDCLogin             base.HandlerInterface
+	GetUserOIDCCallback          base.HandlerInterface
+	GetUserSessions              base.HandlerInterface
+	DeleteUserSession            base.HandlerInterface
+	GetUserExport                base.HandlerInterface
+	DeleteUser                   base.HandlerInterface
+	GetUserProfile               base.HandlerInterface
+	PatchUserProfile             base.HandlerInterface
+	PostUserProfileEmailVerify   base.HandlerInterface
+	GetUserUpdates               base.HandlerInterface
+	PostUserWebhooks             base.HandlerInterface
+	GetUserWebhooks              base.HandlerInterface
+	DeleteUserWebhook            base.HandlerInterface
+	GetUserWebhookDeliveries     base.HandlerInterface
+
+	// Обработчики API v2, представление которых отличается от v1
+	V2PostUserOrders          base.HandlerInterface
+	V2GetUserOrders           base.HandlerInterface
+	V2GetUserOrder            base.HandlerInterface
+	V2GetUserBalance          base.HandlerInterface
+	V2PostUserBalanceWithdraw base.HandlerInterface
+	V2GetUserWithdrawals      base.HandlerInterface
 }
 
 func NewHandlers(logger config.LoggerInterface, services *service.Services, settings *config.Settings) *Handlers {
 	getHealthHandler := health.NewGetHealthHandler(logger, services.Health)
+	getLivezHandler := health.NewGetLivezHandler(logger, services.Health)
+	getReadyzHandler := health.NewGetReadyzHandler(logger, services.Health)
+	getOpenAPI := docs.NewGetOpenAPIHandler()
+	getSwaggerUI := docs.NewGetSwaggerUIHandler()
+	getMetrics := metrics.NewGetMetricsHandler(logger)
 	postRegisterHandler := userRegister.NewPostRegisterHandler(
 		logger,
 		services.UserAuth,
+		services.Session,
 		services.JWT,
 		settings.Environment.JWT,
 	)
 	postLoginHandler := userLogin.NewPostLoginHandler(
 		logger,
 		services.UserAuth,
+		services.TwoFactor,
+		services.Session,
 		services.JWT,
 		settings.Environment.JWT,
 	)
@@ -41,10 +108,18 @@ func NewHandlers(logger config.LoggerInterface, services *service.Services, sett
 		logger,
 		services.UserOrder,
 	)
+	postUserOrdersBatchHandler := userOrders.NewPostUserOrdersBatchHandler(
+		logger,
+		services.UserOrder,
+	)
 	getUserOrdersHandler := userOrders.NewGetUserOrdersHandler(
 		logger,
 		services.UserOrder,
 	)
+	getUserOrderHandler := userOrders.NewGetUserOrderHandler(
+		logger,
+		services.UserOrder,
+	)
 	getUserBalanceHandler := userBalance.NewGetUserBalanceHandler(
 		logger,
 		services.UserBalance,
@@ -58,15 +133,125 @@ func NewHandlers(logger config.LoggerInterface, services *service.Services, sett
 		logger,
 		services.UserWithdraw,
 	)
+	getUserOrdersStatement := userStatement.NewGetUserOrdersStatementHandler(logger, services.Statement)
+	getUserWithdrawalsStatement := userStatement.NewGetUserWithdrawalsStatementHandler(logger, services.Statement)
+	postUserPassword := userPassword.NewPostUserPasswordHandler(
+		logger,
+		services.UserPassword,
+		services.JWT,
+		settings.Environment.JWT,
+	)
+	postUserPasswordResetRequest := userPassword.NewPostUserPasswordResetRequestHandler(
+		logger,
+		services.UserPassword,
+	)
+	postUserPasswordReset := userPassword.NewPostUserPasswordResetHandler(
+		logger,
+		services.UserPassword,
+	)
+	postUserLoginTwoFactor := userLogin.NewPostLoginTwoFactorHandler(
+		logger,
+		services.TwoFactor,
+		services.Session,
+		services.JWT,
+		settings.Environment.JWT,
+	)
+	postUserTwoFactorEnroll := userTwoFactor.NewPostTwoFactorEnrollHandler(logger, services.TwoFactor)
+	postUserTwoFactorConfirm := userTwoFactor.NewPostTwoFactorConfirmHandler(logger, services.TwoFactor)
+	postUserTwoFactorDisable := userTwoFactor.NewPostTwoFactorDisableHandler(logger, services.TwoFactor)
+	postUserAPIKeys := userAPIKeys.NewPostAPIKeysHandler(logger, services.APIKey)
+	getUserAPIKeys := userAPIKeys.NewGetAPIKeysHandler(logger, services.APIKey)
+	deleteUserAPIKey := userAPIKeys.NewDeleteAPIKeyHandler(logger, services.APIKey)
+	getAdminUsers := adminUsers.NewGetUsersHandler(logger, services.Admin)
+	putAdminUserRole := adminUsers.NewPutUserRoleHandler(logger, services.Admin)
+	getUserOIDCLogin := userOIDC.NewGetOIDCLoginHandler(
+		logger,
+		services.OIDC,
+		settings.Environment.OIDC,
+		settings.Environment.JWT,
+	)
+	getUserOIDCCallback := userOIDC.NewGetOIDCCallbackHandler(
+		logger,
+		services.OIDC,
+		services.TwoFactor,
+		services.Session,
+		services.JWT,
+		settings.Environment.JWT,
+	)
+	getUserSessions := userSessions.NewGetSessionsHandler(logger, services.Session)
+	deleteUserSession := userSessions.NewDeleteSessionHandler(logger, services.Session)
+	getUserExport := userAccount.NewGetExportHandler(logger, services.Account)
+	deleteUser := userAccount.NewDeleteAccountHandler(logger, services.Account, settings.Environment.JWT)
+	getUserProfile := userProfile.NewGetProfileHandler(logger, services.Profile)
+	patchUserProfile := userProfile.NewPatchProfileHandler(logger, services.Profile)
+	postUserProfileEmailVerify := userProfile.NewPostVerifyEmailHandler(logger, services.Profile)
+	getUserUpdates := userUpdates.NewGetUserUpdatesHandler(logger, services.Updates)
+	postUserWebhooks := userWebhooks.NewPostWebhooksHandler(logger, services.Webhook)
+	getUserWebhooks := userWebhooks.NewGetWebhooksHandler(logger, services.Webhook)
+	deleteUserWebhook := userWebhooks.NewDeleteWebhookHandler(logger, services.Webhook)
+	getUserWebhookDeliveries := userWebhooks.NewGetWebhookDeliveriesHandler(logger, services.Webhook)
+
+	v2PostUserOrders := v2UserOrders.NewPostUserOrdersHandler(logger, services.UserOrder)
+	v2GetUserOrders := v2UserOrders.NewGetUserOrdersHandler(logger, services.UserOrder)
+	v2GetUserOrder := v2UserOrders.NewGetUserOrderHandler(logger, services.UserOrder)
+	v2GetUserBalance := v2UserBalance.NewGetUserBalanceHandler(logger, services.UserBalance)
+	v2PostUserBalanceWithdraw := v2UserWithdraw.NewPostUserBalanceWithdraw(
+		logger,
+		services.UserOrder,
+		services.UserWithdraw,
+	)
+	v2GetUserWithdrawals := v2UserWithdraw.NewGetUserWithdrawals(logger, services.UserWithdraw)
 
 	return &Handlers{
-		GetHealth:               getHealthHandler,
-		PostUserRegister:        postRegisterHandler,
-		PostUserLogin:           postLoginHandler,
-		PostUserOrders:          postUserOrdersHandler,
-		GetUserOrders:           getUserOrdersHandler,
-		GetUserBalance:          getUserBalanceHandler,
-		PostUserBalanceWithdraw: postUserBalanceWithdraw,
-		GetUserWithdrawals:      getUserWithdrawals,
+		GetHealth:                    getHealthHandler,
+		GetLivez:                     getLivezHandler,
+		GetReadyz:                    getReadyzHandler,
+		GetOpenAPI:                   getOpenAPI,
+		GetSwaggerUI:                 getSwaggerUI,
+		GetMetrics:                   getMetrics,
+		PostUserRegister:             postRegisterHandler,
+		PostUserLogin:                postLoginHandler,
+		PostUserOrders:               postUserOrdersHandler,
+		PostUserOrdersBatch:          postUserOrdersBatchHandler,
+		GetUserOrders:                getUserOrdersHandler,
+		GetUserOrder:                 getUserOrderHandler,
+		GetUserBalance:               getUserBalanceHandler,
+		PostUserBalanceWithdraw:      postUserBalanceWithdraw,
+		GetUserWithdrawals:           getUserWithdrawals,
+		GetUserOrdersStatement:       getUserOrdersStatement,
+		GetUserWithdrawalsStatement:  getUserWithdrawalsStatement,
+		PostUserPassword:             postUserPassword,
+		PostUserPasswordResetRequest: postUserPasswordResetRequest,
+		PostUserPasswordReset:        postUserPasswordReset,
+		PostUserLoginTwoFactor:       postUserLoginTwoFactor,
+		PostUserTwoFactorEnroll:      postUserTwoFactorEnroll,
+		PostUserTwoFactorConfirm:     postUserTwoFactorConfirm,
+		PostUserTwoFactorDisable:     postUserTwoFactorDisable,
+		PostUserAPIKeys:              postUserAPIKeys,
+		GetUserAPIKeys:               getUserAPIKeys,
+		DeleteUserAPIKey:             deleteUserAPIKey,
+		GetAdminUsers:                getAdminUsers,
+		PutAdminUserRole:             putAdminUserRole,
+		GetUserOIDCLogin:             getUserOIDCLogin,
+		GetUserOIDCCallback:          getUserOIDCCallback,
+		GetUserSessions:              getUserSessions,
+		DeleteUserSession:            deleteUserSession,
+		GetUserExport:                getUserExport,
+		DeleteUser:                   deleteUser,
+		GetUserProfile:               getUserProfile,
+		PatchUserProfile:             patchUserProfile,
+		PostUserProfileEmailVerify:   postUserProfileEmailVerify,
+		GetUserUpdates:               getUserUpdates,
+		PostUserWebhooks:             postUserWebhooks,
+		GetUserWebhooks:              getUserWebhooks,
+		DeleteUserWebhook:            deleteUserWebhook,
+		GetUserWebhookDeliveries:     getUserWebhookDeliveries,
+
+		V2PostUserOrders:          v2PostUserOrders,
+		V2GetUserOrders:           v2GetUserOrders,
+		V2GetUserOrder:            v2GetUserOrder,
+		V2GetUserBalance:          v2GetUserBalance,
+		V2PostUserBalanceWithdraw: v2PostUserBalanceWithdraw,
+		V2GetUserWithdrawals:      v2GetUserWithdrawals,
 	}
 }
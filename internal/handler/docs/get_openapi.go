@@ -0,0 +1,19 @@
+package docs
+
+import (
+	"gophermart-service/internal/base"
+	"gophermart-service/internal/openapi"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type getOpenAPIHandler struct{}
+
+func NewGetOpenAPIHandler() base.HandlerInterface {
+	return &getOpenAPIHandler{}
+}
+
+func (h *getOpenAPIHandler) Handle(c *gin.Context) {
+	c.Data(http.StatusOK, "application/json; charset=utf-8", openapi.Spec())
+}
@@ -0,0 +1,19 @@
+package docs
+
+import (
+	"gophermart-service/internal/base"
+	"gophermart-service/internal/openapi"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type getSwaggerUIHandler struct{}
+
+func NewGetSwaggerUIHandler() base.HandlerInterface {
+	return &getSwaggerUIHandler{}
+}
+
+func (h *getSwaggerUIHandler) Handle(c *gin.Context) {
+	c.Data(http.StatusOK, "text/html; charset=utf-8", openapi.SwaggerUI())
+}
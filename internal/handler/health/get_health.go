@@ -3,6 +3,7 @@ package health
 import (
 	"gophermart-service/internal/base"
 	"gophermart-service/internal/config"
+	"gophermart-service/internal/problem"
 	serviceHealth "gophermart-service/internal/service/health"
 	"net/http"
 
@@ -33,7 +34,7 @@ func (h *getHealthHandler) Handle(c *gin.Context) {
 		h.logger.Errorw("Health check failed",
 			"error", err,
 		)
-		c.String(http.StatusInternalServerError, `{"error": "health check failed"}`)
+		problem.Write(c, http.StatusInternalServerError, problem.CodeInternal, "health check failed")
 		return
 	}
 
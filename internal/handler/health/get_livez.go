@@ -0,0 +1,30 @@
+package health
+
+import (
+	"gophermart-service/internal/base"
+	"gophermart-service/internal/config"
+	serviceHealth "gophermart-service/internal/service/health"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type getLivezHandler struct {
+	logger  config.LoggerInterface
+	service serviceHealth.ServiceInterface
+}
+
+func NewGetLivezHandler(
+	logger config.LoggerInterface,
+	service serviceHealth.ServiceInterface,
+) base.HandlerInterface {
+	return &getLivezHandler{
+		logger:  logger,
+		service: service,
+	}
+}
+
+// Handle отвечает, пока процесс обслуживает запросы; зависимости не проверяются
+func (h *getLivezHandler) Handle(c *gin.Context) {
+	c.JSON(http.StatusOK, h.service.Live(c.Request.Context()))
+}
@@ -0,0 +1,37 @@
+package health
+
+import (
+	"gophermart-service/internal/base"
+	"gophermart-service/internal/config"
+	serviceHealth "gophermart-service/internal/service/health"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type getReadyzHandler struct {
+	logger  config.LoggerInterface
+	service serviceHealth.ServiceInterface
+}
+
+func NewGetReadyzHandler(
+	logger config.LoggerInterface,
+	service serviceHealth.ServiceInterface,
+) base.HandlerInterface {
+	return &getReadyzHandler{
+		logger:  logger,
+		service: service,
+	}
+}
+
+// Handle возвращает состояние компонентов: 200, если экземпляр готов принимать запросы,
+// и 503, если недоступен критичный компонент или идет остановка
+func (h *getReadyzHandler) Handle(c *gin.Context) {
+	readiness := h.service.Ready(c.Request.Context())
+
+	status := http.StatusOK
+	if !readiness.Ready() {
+		status = http.StatusServiceUnavailable
+	}
+	c.JSON(status, readiness)
+}
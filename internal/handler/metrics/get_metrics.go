@@ -0,0 +1,39 @@
+package metrics
+
+import (
+	"gophermart-service/internal/base"
+	"gophermart-service/internal/config"
+	"gophermart-service/internal/metrics"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+type getMetricsHandler struct {
+	handler http.Handler
+}
+
+// NewGetMetricsHandler отдает метрики в формате Prometheus. Ошибки отдельных коллекторов
+// логируются, а остальные метрики отдаются, чтобы сбой одной выборки не ломал весь опрос.
+func NewGetMetricsHandler(logger config.LoggerInterface) base.HandlerInterface {
+	return &getMetricsHandler{
+		handler: promhttp.HandlerFor(metrics.Registry, promhttp.HandlerOpts{
+			ErrorLog:      promErrorLogger{logger: logger},
+			ErrorHandling: promhttp.ContinueOnError,
+		}),
+	}
+}
+
+func (h *getMetricsHandler) Handle(c *gin.Context) {
+	h.handler.ServeHTTP(c.Writer, c.Request)
+}
+
+// promErrorLogger передает ошибки promhttp в логгер сервиса
+type promErrorLogger struct {
+	logger config.LoggerInterface
+}
+
+func (l promErrorLogger) Println(v ...any) {
+	l.logger.Warnw("Metrics collection error", "error", v)
+}
@@ -0,0 +1,70 @@
+package account
+
+import (
+	"gophermart-service/internal/base"
+	"gophermart-service/internal/config"
+	"gophermart-service/internal/problem"
+	"gophermart-service/internal/service/jwt"
+	serviceAccount "gophermart-service/internal/service/user/account"
+	"net/http"
+	"time"
+
+	"github.com/gin-contrib/requestid"
+	"github.com/gin-gonic/gin"
+)
+
+type deleteAccountHandler struct {
+	logger         config.LoggerInterface
+	accountService serviceAccount.ServiceInterface
+	jwtSettings    *config.JWTSettings
+}
+
+// DeleteRequestBody подтверждает удаление учетной записи
+type DeleteRequestBody struct {
+	Password     string `json:"password"`
+	ConfirmLogin string `json:"confirm_login"`
+}
+
+func NewDeleteAccountHandler(
+	logger config.LoggerInterface,
+	accountService serviceAccount.ServiceInterface,
+	jwtSettings *config.JWTSettings,
+) base.HandlerInterface {
+	return &deleteAccountHandler{
+		logger:         logger,
+		accountService: accountService,
+		jwtSettings:    jwtSettings,
+	}
+}
+
+func (h *deleteAccountHandler) Handle(c *gin.Context) {
+	requestID := requestid.Get(c)
+
+	user := jwt.ExtractUserFromContext(c.Request.Context())
+	if user == nil {
+		h.logger.Warnw("user not found in context", "requestID", requestID)
+		problem.Unauthorized(c)
+		return
+	}
+
+	var requestBody DeleteRequestBody
+	if err := c.ShouldBindJSON(&requestBody); err != nil {
+		h.logger.Warnw("Invalid JSON in request body", "requestID", requestID, "error", err)
+		problem.InvalidRequest(c, err)
+		return
+	}
+
+	err := h.accountService.Delete(c.Request.Context(), user.ID, &serviceAccount.DeleteInDTO{
+		Password:     requestBody.Password,
+		ConfirmLogin: requestBody.ConfirmLogin,
+	})
+	if err != nil {
+		problem.Respond(c, h.logger, err)
+		return
+	}
+
+	// Удаляем куку с токеном: все токены пользователя уже отозваны
+	base.SetTokenToCookie(c, "", h.jwtSettings, -time.Second)
+
+	c.JSON(http.StatusOK, gin.H{"message": "Account deleted"})
+}
@@ -0,0 +1,51 @@
+package account
+
+import (
+	"fmt"
+	"gophermart-service/internal/base"
+	"gophermart-service/internal/config"
+	"gophermart-service/internal/problem"
+	"gophermart-service/internal/service/jwt"
+	serviceAccount "gophermart-service/internal/service/user/account"
+	"net/http"
+
+	"github.com/gin-contrib/requestid"
+	"github.com/gin-gonic/gin"
+)
+
+type getExportHandler struct {
+	logger         config.LoggerInterface
+	accountService serviceAccount.ServiceInterface
+}
+
+func NewGetExportHandler(
+	logger config.LoggerInterface,
+	accountService serviceAccount.ServiceInterface,
+) base.HandlerInterface {
+	return &getExportHandler{
+		logger:         logger,
+		accountService: accountService,
+	}
+}
+
+// Handle отдает все данные пользователя одним JSON файлом
+func (h *getExportHandler) Handle(c *gin.Context) {
+	requestID := requestid.Get(c)
+
+	user := jwt.ExtractUserFromContext(c.Request.Context())
+	if user == nil {
+		h.logger.Warnw("user not found in context", "requestID", requestID)
+		problem.Unauthorized(c)
+		return
+	}
+
+	export, err := h.accountService.Export(c.Request.Context(), user.ID)
+	if err != nil {
+		problem.Respond(c, h.logger, err)
+		return
+	}
+
+	c.Header("Content-Disposition",
+		fmt.Sprintf(`attachment; filename="gophermart-export-%d-%s.json"`, user.ID, export.ExportedAt.Format("20060102")))
+	c.IndentedJSON(http.StatusOK, export)
+}
@@ -0,0 +1,53 @@
+package apikeys
+
+import (
+	"gophermart-service/internal/base"
+	"gophermart-service/internal/config"
+	"gophermart-service/internal/problem"
+	serviceAPIKey "gophermart-service/internal/service/apikey"
+	"gophermart-service/internal/service/jwt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-contrib/requestid"
+	"github.com/gin-gonic/gin"
+)
+
+type deleteAPIKeyHandler struct {
+	logger        config.LoggerInterface
+	apiKeyService serviceAPIKey.ServiceInterface
+}
+
+func NewDeleteAPIKeyHandler(
+	logger config.LoggerInterface,
+	apiKeyService serviceAPIKey.ServiceInterface,
+) base.HandlerInterface {
+	return &deleteAPIKeyHandler{
+		logger:        logger,
+		apiKeyService: apiKeyService,
+	}
+}
+
+func (h *deleteAPIKeyHandler) Handle(c *gin.Context) {
+	requestID := requestid.Get(c)
+
+	user := jwt.ExtractUserFromContext(c.Request.Context())
+	if user == nil {
+		h.logger.Warnw("user not found in context", "requestID", requestID)
+		problem.Unauthorized(c)
+		return
+	}
+
+	apiKeyID, err := strconv.Atoi(c.Param("id"))
+	if err != nil || apiKeyID <= 0 {
+		problem.Write(c, http.StatusBadRequest, problem.CodeInvalidRequest, "invalid api key id")
+		return
+	}
+
+	if err = h.apiKeyService.Revoke(c.Request.Context(), user.ID, apiKeyID); err != nil {
+		problem.Respond(c, h.logger, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "API key revoked"})
+}
@@ -0,0 +1,53 @@
+package apikeys
+
+import (
+	"gophermart-service/internal/base"
+	"gophermart-service/internal/config"
+	"gophermart-service/internal/problem"
+	serviceAPIKey "gophermart-service/internal/service/apikey"
+	"gophermart-service/internal/service/jwt"
+	"net/http"
+
+	"github.com/gin-contrib/requestid"
+	"github.com/gin-gonic/gin"
+)
+
+type getAPIKeysHandler struct {
+	logger        config.LoggerInterface
+	apiKeyService serviceAPIKey.ServiceInterface
+}
+
+func NewGetAPIKeysHandler(
+	logger config.LoggerInterface,
+	apiKeyService serviceAPIKey.ServiceInterface,
+) base.HandlerInterface {
+	return &getAPIKeysHandler{
+		logger:        logger,
+		apiKeyService: apiKeyService,
+	}
+}
+
+func (h *getAPIKeysHandler) Handle(c *gin.Context) {
+	requestID := requestid.Get(c)
+
+	user := jwt.ExtractUserFromContext(c.Request.Context())
+	if user == nil {
+		h.logger.Warnw("user not found in context", "requestID", requestID)
+		problem.Unauthorized(c)
+		return
+	}
+
+	apiKeys, err := h.apiKeyService.List(c.Request.Context(), user.ID)
+	if err != nil {
+		h.logger.Errorw("Failed to get api keys", "requestID", requestID, "userID", user.ID, "error", err)
+		problem.Internal(c)
+		return
+	}
+
+	if len(apiKeys) == 0 {
+		c.Status(http.StatusNoContent)
+		return
+	}
+
+	c.JSON(http.StatusOK, apiKeys)
+}
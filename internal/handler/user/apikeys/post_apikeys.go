@@ -0,0 +1,62 @@
+package apikeys
+
+import (
+	"gophermart-service/internal/base"
+	"gophermart-service/internal/config"
+	"gophermart-service/internal/problem"
+	serviceAPIKey "gophermart-service/internal/service/apikey"
+	"gophermart-service/internal/service/jwt"
+	"net/http"
+
+	"github.com/gin-contrib/requestid"
+	"github.com/gin-gonic/gin"
+)
+
+type postAPIKeysHandler struct {
+	logger        config.LoggerInterface
+	apiKeyService serviceAPIKey.ServiceInterface
+}
+
+type CreateRequestBody struct {
+	Name   string   `json:"name" binding:"required"`
+	Scopes []string `json:"scopes" binding:"required"`
+}
+
+func NewPostAPIKeysHandler(
+	logger config.LoggerInterface,
+	apiKeyService serviceAPIKey.ServiceInterface,
+) base.HandlerInterface {
+	return &postAPIKeysHandler{
+		logger:        logger,
+		apiKeyService: apiKeyService,
+	}
+}
+
+func (h *postAPIKeysHandler) Handle(c *gin.Context) {
+	requestID := requestid.Get(c)
+
+	user := jwt.ExtractUserFromContext(c.Request.Context())
+	if user == nil {
+		h.logger.Warnw("user not found in context", "requestID", requestID)
+		problem.Unauthorized(c)
+		return
+	}
+
+	var requestBody CreateRequestBody
+	if err := c.ShouldBindJSON(&requestBody); err != nil {
+		h.logger.Warnw("Invalid JSON in request body", "requestID", requestID, "error", err)
+		problem.InvalidRequest(c, err)
+		return
+	}
+
+	result, err := h.apiKeyService.Create(c.Request.Context(), user.ID, &serviceAPIKey.CreateInDTO{
+		Name:   requestBody.Name,
+		Scopes: requestBody.Scopes,
+	})
+	if err != nil {
+		problem.Respond(c, h.logger, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, result)
+}
@@ -3,6 +3,7 @@ package balance
 import (
 	"gophermart-service/internal/base"
 	"gophermart-service/internal/config"
+	"gophermart-service/internal/problem"
 	"gophermart-service/internal/service/jwt"
 	userBalance "gophermart-service/internal/service/user/balance"
 	"net/http"
@@ -32,7 +33,7 @@ func (h *getUserBalanceHandler) Handle(c *gin.Context) {
 	user := jwt.ExtractUserFromContext(c.Request.Context())
 	if user == nil {
 		h.logger.Warnw("user not found in context", "requestID", requestID)
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		problem.Unauthorized(c)
 		return
 	}
 
@@ -41,7 +42,7 @@ func (h *getUserBalanceHandler) Handle(c *gin.Context) {
 	balance, err := h.userBalanceService.GetBalance(c.Request.Context(), user.ID)
 	if err != nil {
 		h.logger.Errorw("Failed to get user balance", "requestID", requestID, "error", err)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		problem.Internal(c)
 		return
 	}
 
@@ -3,32 +3,42 @@ package login
 import (
 	"gophermart-service/internal/base"
 	"gophermart-service/internal/config"
+	"gophermart-service/internal/problem"
 	serviceJWT "gophermart-service/internal/service/jwt"
 	serviceUserAuth "gophermart-service/internal/service/user/auth"
+	serviceSession "gophermart-service/internal/service/user/session"
+	serviceTwoFactor "gophermart-service/internal/service/user/twofactor"
 	"net/http"
+	"time"
 
 	"github.com/gin-contrib/requestid"
 	"github.com/gin-gonic/gin"
 )
 
 type postUserLoginHandler struct {
-	logger          config.LoggerInterface
-	userAuthService serviceUserAuth.ServiceInterface
-	jwtService      serviceJWT.ServiceInterface
-	jwtSettings     *config.JWTSettings
+	logger           config.LoggerInterface
+	userAuthService  serviceUserAuth.ServiceInterface
+	twoFactorService serviceTwoFactor.ServiceInterface
+	sessionService   serviceSession.ServiceInterface
+	jwtService       serviceJWT.ServiceInterface
+	jwtSettings      *config.JWTSettings
 }
 
 func NewPostLoginHandler(
 	logger config.LoggerInterface,
 	userAuthService serviceUserAuth.ServiceInterface,
+	twoFactorService serviceTwoFactor.ServiceInterface,
+	sessionService serviceSession.ServiceInterface,
 	jwtService serviceJWT.ServiceInterface,
 	jwtSettings *config.JWTSettings,
 ) base.HandlerInterface {
 	return &postUserLoginHandler{
-		logger:          logger,
-		userAuthService: userAuthService,
-		jwtService:      jwtService,
-		jwtSettings:     jwtSettings,
+		logger:           logger,
+		userAuthService:  userAuthService,
+		twoFactorService: twoFactorService,
+		sessionService:   sessionService,
+		jwtService:       jwtService,
+		jwtSettings:      jwtSettings,
 	}
 }
 
@@ -59,52 +69,56 @@ func (h *postUserLoginHandler) Handle(c *gin.Context) {
 			Password: dtoIn.Password,
 		})
 	if err != nil {
-		if serviceUserAuth.IsErrLoginIsRequired(err) ||
-			serviceUserAuth.IsErrPasswordIsRequired(err) ||
-			serviceUserAuth.IsErrLoginTooShort(err) ||
-			serviceUserAuth.IsErrPasswordTooShort(err) ||
-			serviceUserAuth.IsErrLoginTooLong(err) ||
-			serviceUserAuth.IsErrPasswordTooLong(err) {
-			h.logger.Warnw("Validation error during user auth",
-				"error", err,
-				"request_id", requestID,
-				"login", dtoIn.Login)
-			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
-			return
-
-		} else if serviceUserAuth.IsBadPassword(err) {
-			h.logger.Warnw("User login or password is wrong",
-				"request_id", requestID,
-				"login", dtoIn.Login)
-			c.JSON(
-				http.StatusUnauthorized,
-				gin.H{"error": "User login or password is wrong"})
-			return
-		} else if serviceUserAuth.IsErrUserNotFound(err) {
-			h.logger.Warnw("User not found",
-				"request_id", requestID,
-				"login", dtoIn.Login)
-			c.JSON(
-				http.StatusUnauthorized,
-				gin.H{"error": "User not found"})
-			return
-		}
-
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		problem.Respond(c, h.logger, err)
 		return
 	}
 
-	jwtToken, err := h.jwtService.GenerateToken(c.Request.Context(), &serviceJWT.InDTO{
-		ID:    response.UserID,
-		Login: dtoIn.Login,
+	user := &serviceJWT.InDTO{
+		ID:           response.UserID,
+		Login:        dtoIn.Login,
+		TokenVersion: response.TokenVersion,
+		Role:         response.Role,
+	}
+
+	// При включенной 2FA токен доступа выдается только после второго шага
+	twoFactorEnabled, err := h.twoFactorService.IsEnabled(c.Request.Context(), response.UserID)
+	if err != nil {
+		h.logger.Errorw("Failed to check two-factor status",
+			"error", err,
+			"request_id", requestID,
+			"login", dtoIn.Login)
+		problem.Internal(c)
+		return
+	}
+	if twoFactorEnabled {
+		h.respondTwoFactorChallenge(c, user)
+		return
+	}
+
+	sessionID, err := h.sessionService.Create(c.Request.Context(), &serviceSession.CreateInDTO{
+		UserID:    response.UserID,
+		UserAgent: c.Request.UserAgent(),
+		IPAddress: c.ClientIP(),
+		ExpiresAt: time.Now().Add(h.jwtSettings.TokenDuration),
 	})
+	if err != nil {
+		h.logger.Errorw("Failed to create session",
+			"error", err,
+			"request_id", requestID,
+			"login", dtoIn.Login)
+		problem.Internal(c)
+		return
+	}
+	user.SessionID = sessionID
+
+	jwtToken, err := h.jwtService.GenerateToken(c.Request.Context(), user)
 
 	if err != nil {
 		h.logger.Errorw("Failed to generate JWT token",
 			"error", err,
 			"request_id", requestID,
 			"login", dtoIn.Login)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to generate auth token"})
+		problem.Internal(c)
 		return
 	}
 
@@ -118,6 +132,30 @@ func (h *postUserLoginHandler) Handle(c *gin.Context) {
 	})
 }
 
+func (h *postUserLoginHandler) respondTwoFactorChallenge(c *gin.Context, user *serviceJWT.InDTO) {
+	requestID := requestid.Get(c)
+
+	challengeToken, err := h.jwtService.GenerateChallengeToken(c.Request.Context(), user)
+	if err != nil {
+		h.logger.Errorw("Failed to generate two-factor challenge token",
+			"error", err,
+			"request_id", requestID,
+			"login", user.Login)
+		problem.Internal(c)
+		return
+	}
+
+	h.logger.Infow("Two-factor authentication required",
+		"request_id", requestID,
+		"login", user.Login)
+	p := problem.New(c, http.StatusUnauthorized, problem.CodeTwoFactorRequired, serviceTwoFactor.ErrTwoFactorRequired.Error())
+	p.Details = gin.H{
+		"two_factor_required": true,
+		"challenge_token":     challengeToken,
+	}
+	p.Send(c)
+}
+
 func (h *postUserLoginHandler) parseRequestBody(c *gin.Context, dtoIn *RequestBodyInDTO) error {
 	requestID := requestid.Get(c)
 
@@ -126,7 +164,7 @@ func (h *postUserLoginHandler) parseRequestBody(c *gin.Context, dtoIn *RequestBo
 			"error", err,
 			"request_id", requestID,
 			"remote_addr", c.Request.RemoteAddr)
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		problem.InvalidRequest(c, err)
 		return err
 	}
 
@@ -0,0 +1,119 @@
+package login
+
+import (
+	"gophermart-service/internal/base"
+	"gophermart-service/internal/config"
+	"gophermart-service/internal/problem"
+	serviceJWT "gophermart-service/internal/service/jwt"
+	serviceSession "gophermart-service/internal/service/user/session"
+	serviceTwoFactor "gophermart-service/internal/service/user/twofactor"
+	"net/http"
+	"time"
+
+	"github.com/gin-contrib/requestid"
+	"github.com/gin-gonic/gin"
+)
+
+type postUserLoginTwoFactorHandler struct {
+	logger           config.LoggerInterface
+	twoFactorService serviceTwoFactor.ServiceInterface
+	sessionService   serviceSession.ServiceInterface
+	jwtService       serviceJWT.ServiceInterface
+	jwtSettings      *config.JWTSettings
+}
+
+func NewPostLoginTwoFactorHandler(
+	logger config.LoggerInterface,
+	twoFactorService serviceTwoFactor.ServiceInterface,
+	sessionService serviceSession.ServiceInterface,
+	jwtService serviceJWT.ServiceInterface,
+	jwtSettings *config.JWTSettings,
+) base.HandlerInterface {
+	return &postUserLoginTwoFactorHandler{
+		logger:           logger,
+		twoFactorService: twoFactorService,
+		sessionService:   sessionService,
+		jwtService:       jwtService,
+		jwtSettings:      jwtSettings,
+	}
+}
+
+// TwoFactorRequestBodyInDTO представляет второй шаг входа: промежуточный токен и код
+type TwoFactorRequestBodyInDTO struct {
+	ChallengeToken string `json:"challenge_token" binding:"required"`
+	Code           string `json:"code" binding:"required"`
+}
+
+func (h *postUserLoginTwoFactorHandler) Handle(c *gin.Context) {
+	var dtoIn TwoFactorRequestBodyInDTO
+	requestID := requestid.Get(c)
+	h.logger.Infow("Starting user two-factor auth", "requestID", requestID)
+
+	if err := c.ShouldBindJSON(&dtoIn); err != nil {
+		h.logger.Warnw("Invalid JSON in request body",
+			"error", err,
+			"request_id", requestID,
+			"remote_addr", c.Request.RemoteAddr)
+		problem.InvalidRequest(c, err)
+		return
+	}
+
+	user, err := h.jwtService.ValidateChallengeToken(c.Request.Context(), dtoIn.ChallengeToken)
+	if err != nil {
+		h.logger.Warnw("Invalid two-factor challenge token",
+			"error", err,
+			"request_id", requestID)
+		problem.Write(c, http.StatusUnauthorized, problem.CodeTwoFactorChallengeInvalid, serviceTwoFactor.ErrChallengeIsInvalid.Error())
+		return
+	}
+
+	err = h.twoFactorService.VerifyChallenge(c.Request.Context(), &serviceTwoFactor.ChallengeInDTO{
+		UserID:      user.ID,
+		ChallengeID: user.ChallengeID,
+		ExpiresAt:   user.ChallengeExpiresAt,
+		Code:        dtoIn.Code,
+	})
+	if err != nil {
+		// На этапе входа неверный код означает неуспешную аутентификацию
+		problem.Respond(c, h.logger, err,
+			problem.Mapping{Err: serviceTwoFactor.ErrInvalidCode, Status: http.StatusUnauthorized, Code: problem.CodeTwoFactorCodeInvalid},
+			problem.Mapping{Err: serviceTwoFactor.ErrNotEnabled, Status: http.StatusUnauthorized, Code: problem.CodeTwoFactorCodeInvalid},
+		)
+		return
+	}
+
+	sessionID, err := h.sessionService.Create(c.Request.Context(), &serviceSession.CreateInDTO{
+		UserID:    user.ID,
+		UserAgent: c.Request.UserAgent(),
+		IPAddress: c.ClientIP(),
+		ExpiresAt: time.Now().Add(h.jwtSettings.TokenDuration),
+	})
+	if err != nil {
+		h.logger.Errorw("Failed to create session",
+			"error", err,
+			"request_id", requestID,
+			"login", user.Login)
+		problem.Internal(c)
+		return
+	}
+	user.SessionID = sessionID
+
+	jwtToken, err := h.jwtService.GenerateToken(c.Request.Context(), user)
+	if err != nil {
+		h.logger.Errorw("Failed to generate JWT token",
+			"error", err,
+			"request_id", requestID,
+			"login", user.Login)
+		problem.Internal(c)
+		return
+	}
+
+	base.SetTokenToCookie(c, jwtToken, h.jwtSettings, h.jwtSettings.TokenDuration)
+	base.SetTokenToHeader(c, jwtToken)
+
+	c.JSON(http.StatusOK, gin.H{
+		"user_id": user.ID,
+		"message": "User auth successfully",
+		"token":   jwtToken,
+	})
+}
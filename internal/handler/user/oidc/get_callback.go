@@ -0,0 +1,154 @@
+package oidc
+
+import (
+	"gophermart-service/internal/base"
+	"gophermart-service/internal/config"
+	"gophermart-service/internal/problem"
+	serviceJWT "gophermart-service/internal/service/jwt"
+	serviceOIDC "gophermart-service/internal/service/user/oidc"
+	serviceSession "gophermart-service/internal/service/user/session"
+	serviceTwoFactor "gophermart-service/internal/service/user/twofactor"
+	"net/http"
+	"time"
+
+	"github.com/gin-contrib/requestid"
+	"github.com/gin-gonic/gin"
+)
+
+type getOIDCCallbackHandler struct {
+	logger           config.LoggerInterface
+	oidcService      serviceOIDC.ServiceInterface
+	twoFactorService serviceTwoFactor.ServiceInterface
+	sessionService   serviceSession.ServiceInterface
+	jwtService       serviceJWT.ServiceInterface
+	jwtSettings      *config.JWTSettings
+}
+
+func NewGetOIDCCallbackHandler(
+	logger config.LoggerInterface,
+	oidcService serviceOIDC.ServiceInterface,
+	twoFactorService serviceTwoFactor.ServiceInterface,
+	sessionService serviceSession.ServiceInterface,
+	jwtService serviceJWT.ServiceInterface,
+	jwtSettings *config.JWTSettings,
+) base.HandlerInterface {
+	return &getOIDCCallbackHandler{
+		logger:           logger,
+		oidcService:      oidcService,
+		twoFactorService: twoFactorService,
+		sessionService:   sessionService,
+		jwtService:       jwtService,
+		jwtSettings:      jwtSettings,
+	}
+}
+
+// Handle завершает вход через OIDC провайдера и выдает JWT токен gophermart
+func (h *getOIDCCallbackHandler) Handle(c *gin.Context) {
+	requestID := requestid.Get(c)
+
+	browserState, _ := c.Cookie(stateCookieName)
+	clearStateCookie(c, h.jwtSettings.CookieSecure)
+
+	if providerError := c.Query("error"); providerError != "" {
+		h.logger.Warnw("OIDC provider returned error",
+			"request_id", requestID,
+			"error", providerError,
+			"description", c.Query("error_description"))
+		problem.Write(c, http.StatusUnauthorized, problem.CodeOIDCAuthFailed, serviceOIDC.ErrAuthenticationFailed.Error())
+		return
+	}
+
+	response, err := h.oidcService.Callback(c.Request.Context(), &serviceOIDC.CallbackInDTO{
+		State:        c.Query("state"),
+		BrowserState: browserState,
+		Code:         c.Query("code"),
+	})
+	if err != nil {
+		problem.Respond(c, h.logger, err)
+		return
+	}
+
+	user := &serviceJWT.InDTO{
+		ID:           response.UserID,
+		Login:        response.Login,
+		TokenVersion: response.TokenVersion,
+		Role:         response.Role,
+	}
+
+	// Вход через провайдера не заменяет второй фактор: при включенной 2FA выдается только challenge-токен
+	twoFactorEnabled, err := h.twoFactorService.IsEnabled(c.Request.Context(), response.UserID)
+	if err != nil {
+		h.logger.Errorw("Failed to check two-factor status",
+			"error", err,
+			"request_id", requestID,
+			"login", response.Login)
+		problem.Internal(c)
+		return
+	}
+	if twoFactorEnabled {
+		h.respondTwoFactorChallenge(c, user)
+		return
+	}
+
+	sessionID, err := h.sessionService.Create(c.Request.Context(), &serviceSession.CreateInDTO{
+		UserID:    response.UserID,
+		UserAgent: c.Request.UserAgent(),
+		IPAddress: c.ClientIP(),
+		ExpiresAt: time.Now().Add(h.jwtSettings.TokenDuration),
+	})
+	if err != nil {
+		h.logger.Errorw("Failed to create session",
+			"error", err,
+			"request_id", requestID,
+			"login", response.Login)
+		problem.Internal(c)
+		return
+	}
+
+	user.SessionID = sessionID
+
+	jwtToken, err := h.jwtService.GenerateToken(c.Request.Context(), user)
+	if err != nil {
+		h.logger.Errorw("Failed to generate JWT token",
+			"error", err,
+			"request_id", requestID,
+			"login", response.Login)
+		problem.Internal(c)
+		return
+	}
+
+	base.SetTokenToCookie(c, jwtToken, h.jwtSettings, h.jwtSettings.TokenDuration)
+	base.SetTokenToHeader(c, jwtToken)
+
+	c.JSON(http.StatusOK, gin.H{
+		"user_id": response.UserID,
+		"login":   response.Login,
+		"created": response.Created,
+		"message": "User auth successfully",
+		"token":   jwtToken,
+	})
+}
+
+func (h *getOIDCCallbackHandler) respondTwoFactorChallenge(c *gin.Context, user *serviceJWT.InDTO) {
+	requestID := requestid.Get(c)
+
+	challengeToken, err := h.jwtService.GenerateChallengeToken(c.Request.Context(), user)
+	if err != nil {
+		h.logger.Errorw("Failed to generate two-factor challenge token",
+			"error", err,
+			"request_id", requestID,
+			"login", user.Login)
+		problem.Internal(c)
+		return
+	}
+
+	h.logger.Infow("Two-factor authentication required",
+		"request_id", requestID,
+		"login", user.Login)
+	p := problem.New(c, http.StatusUnauthorized, problem.CodeTwoFactorRequired, serviceTwoFactor.ErrTwoFactorRequired.Error())
+	p.Details = gin.H{
+		"two_factor_required": true,
+		"challenge_token":     challengeToken,
+	}
+	p.Send(c)
+}
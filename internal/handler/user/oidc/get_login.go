@@ -0,0 +1,52 @@
+package oidc
+
+import (
+	"gophermart-service/internal/base"
+	"gophermart-service/internal/config"
+	"gophermart-service/internal/problem"
+	serviceOIDC "gophermart-service/internal/service/user/oidc"
+	"net/http"
+
+	"github.com/gin-contrib/requestid"
+	"github.com/gin-gonic/gin"
+)
+
+type getOIDCLoginHandler struct {
+	logger       config.LoggerInterface
+	oidcService  serviceOIDC.ServiceInterface
+	oidcSettings *config.OIDCSettings
+	jwtSettings  *config.JWTSettings
+}
+
+func NewGetOIDCLoginHandler(
+	logger config.LoggerInterface,
+	oidcService serviceOIDC.ServiceInterface,
+	oidcSettings *config.OIDCSettings,
+	jwtSettings *config.JWTSettings,
+) base.HandlerInterface {
+	return &getOIDCLoginHandler{
+		logger:       logger,
+		oidcService:  oidcService,
+		oidcSettings: oidcSettings,
+		jwtSettings:  jwtSettings,
+	}
+}
+
+// Handle перенаправляет пользователя на страницу входа OIDC провайдера
+func (h *getOIDCLoginHandler) Handle(c *gin.Context) {
+	requestID := requestid.Get(c)
+
+	response, err := h.oidcService.Start(c.Request.Context())
+	if err != nil {
+		if serviceOIDC.IsErrDisabled(err) {
+			problem.Respond(c, h.logger, err)
+			return
+		}
+		h.logger.Errorw("Failed to start oidc login", "request_id", requestID, "error", err)
+		problem.Write(c, http.StatusBadGateway, problem.CodeIdentityProviderOffline, "identity provider is unavailable")
+		return
+	}
+
+	setStateCookie(c, response.State, int(h.oidcSettings.StateTTL.Seconds()), h.jwtSettings.CookieSecure)
+	c.Redirect(http.StatusFound, response.AuthURL)
+}
@@ -0,0 +1,27 @@
+package oidc
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// stateCookieName кука, привязывающая state запроса авторизации к браузеру, начавшему вход
+	stateCookieName = "oidc_state"
+	// stateCookiePath кука отправляется только на маршруты входа через OIDC
+	stateCookiePath = "/api/user/oidc"
+)
+
+// setStateCookie сохраняет state в браузере. SameSite=Lax: кука отправляется при переходе
+// с провайдера на адрес возврата, но не в подделанных межсайтовых запросах.
+func setStateCookie(c *gin.Context, state string, maxAge int, secure bool) {
+	c.SetSameSite(http.SameSiteLaxMode)
+	c.SetCookie(stateCookieName, state, maxAge, stateCookiePath, "", secure, true)
+}
+
+// clearStateCookie удаляет state: каждый запрос авторизации завершается один раз
+func clearStateCookie(c *gin.Context, secure bool) {
+	c.SetSameSite(http.SameSiteLaxMode)
+	c.SetCookie(stateCookieName, "", -1, stateCookiePath, "", secure, true)
+}
@@ -3,9 +3,11 @@ package orders
 import (
 	"gophermart-service/internal/base"
 	"gophermart-service/internal/config"
+	"gophermart-service/internal/problem"
 	"gophermart-service/internal/service/jwt"
 	serviceUserOrders "gophermart-service/internal/service/user/order"
 	"net/http"
+	"strings"
 
 	"github.com/gin-contrib/requestid"
 	"github.com/gin-gonic/gin"
@@ -16,9 +18,15 @@ type getUserOrdersHandler struct {
 	userOrdersService serviceUserOrders.ServiceInterface
 }
 
-type PaginationParams struct {
-	Limit  int `form:"limit" binding:"min=1,max=100"`
-	Offset int `form:"offset" binding:"min=0"`
+// ListQueryParams параметры фильтрации, сортировки и пагинации списка заказов
+type ListQueryParams struct {
+	Limit  int    `form:"limit" binding:"min=1,max=100"`
+	Offset int    `form:"offset" binding:"min=0"`
+	Cursor string `form:"cursor"`
+	Status string `form:"status"`
+	From   string `form:"from"`
+	To     string `form:"to"`
+	Sort   string `form:"sort"`
 }
 
 func NewGetUserOrdersHandler(
@@ -37,11 +45,11 @@ func (h *getUserOrdersHandler) Handle(c *gin.Context) {
 	user := jwt.ExtractUserFromContext(c.Request.Context())
 	if user == nil {
 		h.logger.Warnw("user not found in context", "requestID", requestID)
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		problem.Unauthorized(c)
 		return
 	}
 
-	var params PaginationParams
+	var params ListQueryParams
 	params.Limit = 100
 	params.Offset = 0
 
@@ -49,20 +57,33 @@ func (h *getUserOrdersHandler) Handle(c *gin.Context) {
 
 	if err := c.ShouldBindQuery(&params); err != nil {
 		h.logger.Warnw("Invalid pagination parameters", "requestID", requestID, "error", err)
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid pagination parameters"})
+		problem.Write(c, http.StatusBadRequest, problem.CodeInvalidRequest, "invalid pagination parameters")
 		return
 	}
-	orders, err := h.userOrdersService.GetUserOrders(c.Request.Context(), user.ID, params.Limit, params.Offset)
+	in := &serviceUserOrders.ListInDTO{
+		From:   params.From,
+		To:     params.To,
+		Sort:   params.Sort,
+		Cursor: params.Cursor,
+		Limit:  params.Limit,
+		Offset: params.Offset,
+	}
+	if params.Status != "" {
+		in.Statuses = strings.Split(params.Status, ",")
+	}
+
+	page, err := h.userOrdersService.ListUserOrders(c.Request.Context(), user.ID, in)
 	if err != nil {
-		h.logger.Errorw("Failed to get user orders", "requestID", requestID, "error", err)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		h.logger.Warnw("Failed to list user orders", "requestID", requestID, "error", err)
+		problem.Respond(c, h.logger, err)
 		return
 	}
 
-	if len(orders) == 0 {
+	if len(page.Orders) == 0 {
 		c.Status(http.StatusNoContent)
 		return
 	}
 
-	c.JSON(http.StatusOK, orders)
+	base.SetNextPageLink(c, page.NextCursor)
+	c.JSON(http.StatusOK, page.Orders)
 }
@@ -1,9 +1,10 @@
 package orders
 
 import (
-	"fmt"
+	"errors"
 	"gophermart-service/internal/base"
 	"gophermart-service/internal/config"
+	"gophermart-service/internal/problem"
 	"gophermart-service/internal/service/jwt"
 	serviceUserOrders "gophermart-service/internal/service/user/order"
 	"io"
@@ -16,6 +17,8 @@ import (
 
 const maxBodySize int64 = 1024 * 1024 // 1 MB
 
+var errEmptyOrderNumber = errors.New("order number is empty")
+
 type postUserOrdersHandler struct {
 	logger            config.LoggerInterface
 	userOrdersService serviceUserOrders.ServiceInterface
@@ -37,7 +40,7 @@ func (h *postUserOrdersHandler) Handle(c *gin.Context) {
 	user := jwt.ExtractUserFromContext(c.Request.Context())
 	if user == nil {
 		h.logger.Warnw("user not found in context", "requestID", requestID)
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		problem.Unauthorized(c)
 		return
 	}
 
@@ -48,12 +51,6 @@ func (h *postUserOrdersHandler) Handle(c *gin.Context) {
 	}
 
 	if err = h.userOrdersService.LoadNewOrderNumber(c.Request.Context(), user.ID, orderNumber); err != nil {
-		if serviceUserOrders.IsErrBadOrderNumber(err) {
-			h.logger.Warnw("User order number is invalid", "requestID", requestID, "error", err)
-			c.String(http.StatusUnprocessableEntity, err.Error())
-			return
-		}
-
 		if serviceUserOrders.IsErrOrderAlreadyExistsForUser(err) {
 			h.logger.Warnw(
 				"Order already exists for user",
@@ -64,36 +61,7 @@ func (h *postUserOrdersHandler) Handle(c *gin.Context) {
 			return
 		}
 
-		if serviceUserOrders.IsErrOrderAlreadyProcessedByAnotherUser(err) {
-			h.logger.Warnw("Order already processed by another user",
-				"userID", user.ID,
-				"orderNumber", orderNumber,
-				"requestID", requestID)
-			c.String(http.StatusConflict, "Заказ уже был загружен другим пользователем")
-			return
-		}
-
-		if serviceUserOrders.IsErrFailedToAddOrder(err) {
-			h.logger.Errorw("Failed to add order",
-				"userID", user.ID,
-				"orderNumber", orderNumber,
-				"error", err,
-				"requestID", requestID)
-			c.String(
-				http.StatusInternalServerError,
-				fmt.Sprintf("Ошибка при добавлении заказа: %v", err.Error()),
-			)
-			return
-		}
-		h.logger.Errorw("Unexpected error when adding order",
-			"userID", user.ID,
-			"orderNumber", orderNumber,
-			"error", err,
-			"requestID", requestID)
-		c.String(
-			http.StatusInternalServerError,
-			fmt.Sprintf("Неожиданная ошибка: %v", err.Error()),
-		)
+		problem.Respond(c, h.logger, err)
 		return
 	}
 
@@ -111,15 +79,15 @@ func (h *postUserOrdersHandler) extractOrderNumber(c *gin.Context) (string, erro
 	body, err := io.ReadAll(io.LimitReader(c.Request.Body, maxBodySize))
 	if err != nil {
 		h.logger.Errorw("Failed to read request body", "body", string(body), "error", err, "requestID", requestID)
-		c.String(http.StatusBadRequest, "Ошибка чтения данных")
+		problem.Write(c, http.StatusBadRequest, problem.CodeInvalidRequest, "failed to read request body")
 		return "", err
 	}
 
 	orderNumber := strings.TrimSpace(string(body))
 	if len(orderNumber) == 0 {
 		h.logger.Warnw("Received empty orderNumber", "request_id", requestID)
-		c.String(http.StatusBadRequest, "Пустой номер заказа")
-		return "", err
+		problem.Write(c, http.StatusBadRequest, problem.CodeInvalidRequest, errEmptyOrderNumber.Error())
+		return "", errEmptyOrderNumber
 	}
 
 	return orderNumber, nil
@@ -0,0 +1,111 @@
+package orders
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"gophermart-service/internal/base"
+	"gophermart-service/internal/config"
+	"gophermart-service/internal/problem"
+	"gophermart-service/internal/service/jwt"
+	serviceUserOrders "gophermart-service/internal/service/user/order"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-contrib/requestid"
+	"github.com/gin-gonic/gin"
+)
+
+var errBatchBodyTooLarge = errors.New("request body is too large")
+
+type postUserOrdersBatchHandler struct {
+	logger            config.LoggerInterface
+	userOrdersService serviceUserOrders.ServiceInterface
+}
+
+func NewPostUserOrdersBatchHandler(
+	logger config.LoggerInterface,
+	userOrdersService serviceUserOrders.ServiceInterface,
+) base.HandlerInterface {
+	return &postUserOrdersBatchHandler{
+		logger:            logger,
+		userOrdersService: userOrdersService,
+	}
+}
+
+func (h *postUserOrdersBatchHandler) Handle(c *gin.Context) {
+	requestID := requestid.Get(c)
+
+	user := jwt.ExtractUserFromContext(c.Request.Context())
+	if user == nil {
+		h.logger.Warnw("user not found in context", "requestID", requestID)
+		problem.Unauthorized(c)
+		return
+	}
+
+	h.logger.Infow("Starting to handle user orders batch", "requestID", requestID)
+
+	body, err := io.ReadAll(io.LimitReader(c.Request.Body, maxBodySize+1))
+	if err != nil {
+		h.logger.Errorw("Failed to read request body", "error", err, "requestID", requestID)
+		problem.Write(c, http.StatusBadRequest, problem.CodeInvalidRequest, "failed to read request body")
+		return
+	}
+	if int64(len(body)) > maxBodySize {
+		problem.Write(c, http.StatusRequestEntityTooLarge, problem.CodeOrderBatchTooLarge, errBatchBodyTooLarge.Error())
+		return
+	}
+
+	orderNumbers, err := parseOrderNumbers(c.ContentType(), body)
+	if err != nil {
+		h.logger.Warnw("Invalid orders batch body", "error", err, "requestID", requestID)
+		problem.InvalidRequest(c, err)
+		return
+	}
+
+	result, err := h.userOrdersService.LoadOrderNumbersBatch(c.Request.Context(), user.ID, orderNumbers)
+	if err != nil {
+		problem.Respond(c, h.logger, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// parseOrderNumbers разбирает тело пакетной загрузки: JSON-массив строк для application/json,
+// иначе по одному номеру на строку (text/plain, application/x-ndjson). Пустые строки пропускаются,
+// строки NDJSON вида "12345678903" раскавычиваются.
+func parseOrderNumbers(contentType string, body []byte) ([]string, error) {
+	if contentType == "application/json" {
+		var orderNumbers []string
+		if err := json.Unmarshal(body, &orderNumbers); err != nil {
+			return nil, err
+		}
+		for i := range orderNumbers {
+			orderNumbers[i] = strings.TrimSpace(orderNumbers[i])
+		}
+		return orderNumbers, nil
+	}
+
+	var orderNumbers []string
+	scanner := bufio.NewScanner(bytes.NewReader(body))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		if strings.HasPrefix(line, `"`) {
+			unquoted, err := strconv.Unquote(line)
+			if err != nil {
+				return nil, err
+			}
+			line = strings.TrimSpace(unquoted)
+		}
+		orderNumbers = append(orderNumbers, line)
+	}
+
+	return orderNumbers, scanner.Err()
+}
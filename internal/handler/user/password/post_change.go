@@ -0,0 +1,93 @@
+package password
+
+import (
+	"gophermart-service/internal/base"
+	"gophermart-service/internal/config"
+	"gophermart-service/internal/problem"
+	serviceJWT "gophermart-service/internal/service/jwt"
+	serviceUserPassword "gophermart-service/internal/service/user/password"
+	"net/http"
+
+	"github.com/gin-contrib/requestid"
+	"github.com/gin-gonic/gin"
+)
+
+type postUserPasswordHandler struct {
+	logger              config.LoggerInterface
+	userPasswordService serviceUserPassword.ServiceInterface
+	jwtService          serviceJWT.ServiceInterface
+	jwtSettings         *config.JWTSettings
+}
+
+func NewPostUserPasswordHandler(
+	logger config.LoggerInterface,
+	userPasswordService serviceUserPassword.ServiceInterface,
+	jwtService serviceJWT.ServiceInterface,
+	jwtSettings *config.JWTSettings,
+) base.HandlerInterface {
+	return &postUserPasswordHandler{
+		logger:              logger,
+		userPasswordService: userPasswordService,
+		jwtService:          jwtService,
+		jwtSettings:         jwtSettings,
+	}
+}
+
+// ChangeRequestBody представляет запрос на смену пароля
+type ChangeRequestBody struct {
+	OldPassword string `json:"old_password" binding:"required"`
+	NewPassword string `json:"new_password" binding:"required"`
+}
+
+func (h *postUserPasswordHandler) Handle(c *gin.Context) {
+	requestID := requestid.Get(c)
+
+	user := serviceJWT.ExtractUserFromContext(c.Request.Context())
+	if user == nil {
+		h.logger.Warnw("user not found in context", "requestID", requestID)
+		problem.Unauthorized(c)
+		return
+	}
+
+	var requestBody ChangeRequestBody
+	if err := c.ShouldBindJSON(&requestBody); err != nil {
+		h.logger.Warnw("Invalid JSON in request body", "requestID", requestID, "error", err)
+		problem.InvalidRequest(c, err)
+		return
+	}
+
+	h.logger.Infow("Starting user password change", "requestID", requestID, "userID", user.ID)
+
+	response, err := h.userPasswordService.ChangePassword(c.Request.Context(), user.ID, &serviceUserPassword.ChangeInDTO{
+		OldPassword: requestBody.OldPassword,
+		NewPassword: requestBody.NewPassword,
+		SessionID:   user.SessionID,
+	})
+	if err != nil {
+		problem.Respond(c, h.logger, err)
+		return
+	}
+
+	// Все ранее выданные токены отозваны, текущему клиенту выдаем новый
+	jwtToken, err := h.jwtService.GenerateToken(c.Request.Context(), &serviceJWT.InDTO{
+		ID:           user.ID,
+		Login:        user.Login,
+		TokenVersion: response.TokenVersion,
+		Role:         user.Role,
+		SessionID:    user.SessionID,
+	})
+	if err != nil {
+		h.logger.Errorw("Failed to generate JWT token", "requestID", requestID, "userID", user.ID, "error", err)
+		problem.Internal(c)
+		return
+	}
+
+	base.SetTokenToCookie(c, jwtToken, h.jwtSettings, h.jwtSettings.TokenDuration)
+	base.SetTokenToHeader(c, jwtToken)
+
+	c.JSON(http.StatusOK, gin.H{
+		"user_id": user.ID,
+		"message": "Password changed successfully",
+		"token":   jwtToken,
+	})
+}
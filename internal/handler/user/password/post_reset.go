@@ -0,0 +1,55 @@
+package password
+
+import (
+	"gophermart-service/internal/base"
+	"gophermart-service/internal/config"
+	"gophermart-service/internal/problem"
+	serviceUserPassword "gophermart-service/internal/service/user/password"
+	"net/http"
+
+	"github.com/gin-contrib/requestid"
+	"github.com/gin-gonic/gin"
+)
+
+type postUserPasswordResetHandler struct {
+	logger              config.LoggerInterface
+	userPasswordService serviceUserPassword.ServiceInterface
+}
+
+func NewPostUserPasswordResetHandler(
+	logger config.LoggerInterface,
+	userPasswordService serviceUserPassword.ServiceInterface,
+) base.HandlerInterface {
+	return &postUserPasswordResetHandler{
+		logger:              logger,
+		userPasswordService: userPasswordService,
+	}
+}
+
+// ResetRequestBodyWithToken представляет запрос на установку нового пароля по токену сброса
+type ResetRequestBodyWithToken struct {
+	Token       string `json:"token" binding:"required"`
+	NewPassword string `json:"new_password" binding:"required"`
+}
+
+func (h *postUserPasswordResetHandler) Handle(c *gin.Context) {
+	requestID := requestid.Get(c)
+
+	var requestBody ResetRequestBodyWithToken
+	if err := c.ShouldBindJSON(&requestBody); err != nil {
+		h.logger.Warnw("Invalid JSON in request body", "requestID", requestID, "error", err)
+		problem.InvalidRequest(c, err)
+		return
+	}
+
+	err := h.userPasswordService.ResetPassword(c.Request.Context(), &serviceUserPassword.ResetInDTO{
+		Token:       requestBody.Token,
+		NewPassword: requestBody.NewPassword,
+	})
+	if err != nil {
+		problem.Respond(c, h.logger, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Password has been reset"})
+}
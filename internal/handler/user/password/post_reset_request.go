@@ -0,0 +1,54 @@
+package password
+
+import (
+	"gophermart-service/internal/base"
+	"gophermart-service/internal/config"
+	"gophermart-service/internal/problem"
+	serviceUserPassword "gophermart-service/internal/service/user/password"
+	"net/http"
+
+	"github.com/gin-contrib/requestid"
+	"github.com/gin-gonic/gin"
+)
+
+type postUserPasswordResetRequestHandler struct {
+	logger              config.LoggerInterface
+	userPasswordService serviceUserPassword.ServiceInterface
+}
+
+func NewPostUserPasswordResetRequestHandler(
+	logger config.LoggerInterface,
+	userPasswordService serviceUserPassword.ServiceInterface,
+) base.HandlerInterface {
+	return &postUserPasswordResetRequestHandler{
+		logger:              logger,
+		userPasswordService: userPasswordService,
+	}
+}
+
+// ResetRequestBody представляет запрос на выпуск токена сброса пароля
+type ResetRequestBody struct {
+	Login string `json:"login" binding:"required"`
+}
+
+func (h *postUserPasswordResetRequestHandler) Handle(c *gin.Context) {
+	requestID := requestid.Get(c)
+
+	var requestBody ResetRequestBody
+	if err := c.ShouldBindJSON(&requestBody); err != nil {
+		h.logger.Warnw("Invalid JSON in request body", "requestID", requestID, "error", err)
+		problem.InvalidRequest(c, err)
+		return
+	}
+
+	if err := h.userPasswordService.RequestReset(c.Request.Context(), requestBody.Login); err != nil {
+		h.logger.Errorw("Failed to request password reset", "requestID", requestID, "error", err)
+		problem.Internal(c)
+		return
+	}
+
+	// Ответ не зависит от существования логина
+	c.JSON(http.StatusAccepted, gin.H{
+		"message": "If the account exists, a password reset token has been sent",
+	})
+}
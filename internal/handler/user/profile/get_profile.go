@@ -0,0 +1,47 @@
+package profile
+
+import (
+	"gophermart-service/internal/base"
+	"gophermart-service/internal/config"
+	"gophermart-service/internal/problem"
+	"gophermart-service/internal/service/jwt"
+	serviceProfile "gophermart-service/internal/service/user/profile"
+	"net/http"
+
+	"github.com/gin-contrib/requestid"
+	"github.com/gin-gonic/gin"
+)
+
+type getProfileHandler struct {
+	logger         config.LoggerInterface
+	profileService serviceProfile.ServiceInterface
+}
+
+func NewGetProfileHandler(
+	logger config.LoggerInterface,
+	profileService serviceProfile.ServiceInterface,
+) base.HandlerInterface {
+	return &getProfileHandler{
+		logger:         logger,
+		profileService: profileService,
+	}
+}
+
+func (h *getProfileHandler) Handle(c *gin.Context) {
+	requestID := requestid.Get(c)
+
+	user := jwt.ExtractUserFromContext(c.Request.Context())
+	if user == nil {
+		h.logger.Warnw("user not found in context", "requestID", requestID)
+		problem.Unauthorized(c)
+		return
+	}
+
+	profile, err := h.profileService.Get(c.Request.Context(), user.ID)
+	if err != nil {
+		problem.Respond(c, h.logger, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, profile)
+}
@@ -0,0 +1,54 @@
+package profile
+
+import (
+	"gophermart-service/internal/base"
+	"gophermart-service/internal/config"
+	"gophermart-service/internal/problem"
+	"gophermart-service/internal/service/jwt"
+	serviceProfile "gophermart-service/internal/service/user/profile"
+	"net/http"
+
+	"github.com/gin-contrib/requestid"
+	"github.com/gin-gonic/gin"
+)
+
+type patchProfileHandler struct {
+	logger         config.LoggerInterface
+	profileService serviceProfile.ServiceInterface
+}
+
+func NewPatchProfileHandler(
+	logger config.LoggerInterface,
+	profileService serviceProfile.ServiceInterface,
+) base.HandlerInterface {
+	return &patchProfileHandler{
+		logger:         logger,
+		profileService: profileService,
+	}
+}
+
+func (h *patchProfileHandler) Handle(c *gin.Context) {
+	requestID := requestid.Get(c)
+
+	user := jwt.ExtractUserFromContext(c.Request.Context())
+	if user == nil {
+		h.logger.Warnw("user not found in context", "requestID", requestID)
+		problem.Unauthorized(c)
+		return
+	}
+
+	var dtoIn serviceProfile.UpdateInDTO
+	if err := c.ShouldBindJSON(&dtoIn); err != nil {
+		h.logger.Warnw("Invalid JSON in request body", "requestID", requestID, "error", err)
+		problem.InvalidRequest(c, err)
+		return
+	}
+
+	profile, err := h.profileService.Update(c.Request.Context(), user.ID, &dtoIn)
+	if err != nil {
+		problem.Respond(c, h.logger, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, profile)
+}
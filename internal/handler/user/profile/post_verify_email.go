@@ -0,0 +1,50 @@
+package profile
+
+import (
+	"gophermart-service/internal/base"
+	"gophermart-service/internal/config"
+	"gophermart-service/internal/problem"
+	serviceProfile "gophermart-service/internal/service/user/profile"
+	"net/http"
+
+	"github.com/gin-contrib/requestid"
+	"github.com/gin-gonic/gin"
+)
+
+type postVerifyEmailHandler struct {
+	logger         config.LoggerInterface
+	profileService serviceProfile.ServiceInterface
+}
+
+// VerifyEmailRequestBody представляет запрос на подтверждение email
+type VerifyEmailRequestBody struct {
+	Token string `json:"token" binding:"required"`
+}
+
+func NewPostVerifyEmailHandler(
+	logger config.LoggerInterface,
+	profileService serviceProfile.ServiceInterface,
+) base.HandlerInterface {
+	return &postVerifyEmailHandler{
+		logger:         logger,
+		profileService: profileService,
+	}
+}
+
+func (h *postVerifyEmailHandler) Handle(c *gin.Context) {
+	requestID := requestid.Get(c)
+
+	var requestBody VerifyEmailRequestBody
+	if err := c.ShouldBindJSON(&requestBody); err != nil {
+		h.logger.Warnw("Invalid JSON in request body", "requestID", requestID, "error", err)
+		problem.InvalidRequest(c, err)
+		return
+	}
+
+	if err := h.profileService.VerifyEmail(c.Request.Context(), requestBody.Token); err != nil {
+		problem.Respond(c, h.logger, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Email verified"})
+}
@@ -1,12 +1,14 @@
 package register
 
 import (
-	"fmt"
 	"gophermart-service/internal/base"
 	"gophermart-service/internal/config"
+	"gophermart-service/internal/problem"
 	serviceJWT "gophermart-service/internal/service/jwt"
 	serviceUserAuth "gophermart-service/internal/service/user/auth"
+	serviceSession "gophermart-service/internal/service/user/session"
 	"net/http"
+	"time"
 
 	"github.com/gin-contrib/requestid"
 	"github.com/gin-gonic/gin"
@@ -15,6 +17,7 @@ import (
 type postUserRegisterHandler struct {
 	logger          config.LoggerInterface
 	userAuthService serviceUserAuth.ServiceInterface
+	sessionService  serviceSession.ServiceInterface
 	jwtService      serviceJWT.ServiceInterface
 	jwtSettings     *config.JWTSettings
 }
@@ -22,12 +25,14 @@ type postUserRegisterHandler struct {
 func NewPostRegisterHandler(
 	logger config.LoggerInterface,
 	userAuthService serviceUserAuth.ServiceInterface,
+	sessionService serviceSession.ServiceInterface,
 	jwtService serviceJWT.ServiceInterface,
 	jwtSettings *config.JWTSettings,
 ) base.HandlerInterface {
 	return &postUserRegisterHandler{
 		logger:          logger,
 		userAuthService: userAuthService,
+		sessionService:  sessionService,
 		jwtService:      jwtService,
 		jwtSettings:     jwtSettings,
 	}
@@ -50,7 +55,6 @@ func (h *postUserRegisterHandler) Handle(c *gin.Context) {
 	h.logger.Infow("Starting user registration", "requestID", requestID)
 
 	if err := h.parseRequestBody(c, &dtoIn); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 	response, err := h.userAuthService.RegisterUser(
@@ -61,43 +65,37 @@ func (h *postUserRegisterHandler) Handle(c *gin.Context) {
 		})
 
 	if err != nil {
-		if serviceUserAuth.IsErrLoginIsRequired(err) ||
-			serviceUserAuth.IsErrPasswordIsRequired(err) ||
-			serviceUserAuth.IsErrLoginTooShort(err) ||
-			serviceUserAuth.IsErrPasswordTooShort(err) ||
-			serviceUserAuth.IsErrLoginTooLong(err) ||
-			serviceUserAuth.IsErrPasswordTooLong(err) {
-			h.logger.Warnw("Validation error during user registration",
-				"error", err,
-				"request_id", requestID,
-				"login", dtoIn.Login)
-			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
-			return
-		} else if serviceUserAuth.IsErrUserLoginAlreadyExists(err) {
-			h.logger.Warnw("User login already exists",
-				"request_id", requestID,
-				"login", dtoIn.Login)
-			c.JSON(http.StatusConflict, gin.H{"error": fmt.Sprintf("User login '%s' already exists", dtoIn.Login)})
-			return
-		}
-		h.logger.Errorw("Failed to register user",
+		problem.Respond(c, h.logger, err)
+		return
+	}
+
+	sessionID, err := h.sessionService.Create(c.Request.Context(), &serviceSession.CreateInDTO{
+		UserID:    response.UserID,
+		UserAgent: c.Request.UserAgent(),
+		IPAddress: c.ClientIP(),
+		ExpiresAt: time.Now().Add(h.jwtSettings.TokenDuration),
+	})
+	if err != nil {
+		h.logger.Errorw("Failed to create session",
 			"error", err,
 			"request_id", requestID,
 			"login", dtoIn.Login)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error"})
+		problem.Internal(c)
 		return
 	}
 
 	jwtToken, err := h.jwtService.GenerateToken(c.Request.Context(), &serviceJWT.InDTO{
-		ID:    response.UserID,
-		Login: dtoIn.Login,
+		ID:        response.UserID,
+		Login:     dtoIn.Login,
+		Role:      response.Role,
+		SessionID: sessionID,
 	})
 	if err != nil {
 		h.logger.Errorw("Failed to generate JWT token",
 			"error", err,
 			"request_id", requestID,
 			"login", dtoIn.Login)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to generate auth token"})
+		problem.Internal(c)
 		return
 	}
 
@@ -119,7 +117,7 @@ func (h *postUserRegisterHandler) parseRequestBody(c *gin.Context, dtoIn *Reques
 			"error", err,
 			"request_id", requestID,
 			"remote_addr", c.Request.RemoteAddr)
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		problem.InvalidRequest(c, err)
 		return err
 	}
 
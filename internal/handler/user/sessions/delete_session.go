@@ -0,0 +1,53 @@
+package sessions
+
+import (
+	"gophermart-service/internal/base"
+	"gophermart-service/internal/config"
+	"gophermart-service/internal/problem"
+	"gophermart-service/internal/service/jwt"
+	serviceSession "gophermart-service/internal/service/user/session"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-contrib/requestid"
+	"github.com/gin-gonic/gin"
+)
+
+type deleteSessionHandler struct {
+	logger         config.LoggerInterface
+	sessionService serviceSession.ServiceInterface
+}
+
+func NewDeleteSessionHandler(
+	logger config.LoggerInterface,
+	sessionService serviceSession.ServiceInterface,
+) base.HandlerInterface {
+	return &deleteSessionHandler{
+		logger:         logger,
+		sessionService: sessionService,
+	}
+}
+
+func (h *deleteSessionHandler) Handle(c *gin.Context) {
+	requestID := requestid.Get(c)
+
+	user := jwt.ExtractUserFromContext(c.Request.Context())
+	if user == nil {
+		h.logger.Warnw("user not found in context", "requestID", requestID)
+		problem.Unauthorized(c)
+		return
+	}
+
+	sessionID, err := strconv.Atoi(c.Param("id"))
+	if err != nil || sessionID <= 0 {
+		problem.Write(c, http.StatusBadRequest, problem.CodeInvalidRequest, "invalid session id")
+		return
+	}
+
+	if err = h.sessionService.Terminate(c.Request.Context(), user.ID, sessionID); err != nil {
+		problem.Respond(c, h.logger, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Session terminated"})
+}
@@ -0,0 +1,53 @@
+package sessions
+
+import (
+	"gophermart-service/internal/base"
+	"gophermart-service/internal/config"
+	"gophermart-service/internal/problem"
+	"gophermart-service/internal/service/jwt"
+	serviceSession "gophermart-service/internal/service/user/session"
+	"net/http"
+
+	"github.com/gin-contrib/requestid"
+	"github.com/gin-gonic/gin"
+)
+
+type getSessionsHandler struct {
+	logger         config.LoggerInterface
+	sessionService serviceSession.ServiceInterface
+}
+
+func NewGetSessionsHandler(
+	logger config.LoggerInterface,
+	sessionService serviceSession.ServiceInterface,
+) base.HandlerInterface {
+	return &getSessionsHandler{
+		logger:         logger,
+		sessionService: sessionService,
+	}
+}
+
+func (h *getSessionsHandler) Handle(c *gin.Context) {
+	requestID := requestid.Get(c)
+
+	user := jwt.ExtractUserFromContext(c.Request.Context())
+	if user == nil {
+		h.logger.Warnw("user not found in context", "requestID", requestID)
+		problem.Unauthorized(c)
+		return
+	}
+
+	sessions, err := h.sessionService.List(c.Request.Context(), user.ID, user.SessionID)
+	if err != nil {
+		h.logger.Errorw("Failed to get sessions", "requestID", requestID, "userID", user.ID, "error", err)
+		problem.Internal(c)
+		return
+	}
+
+	if len(sessions) == 0 {
+		c.Status(http.StatusNoContent)
+		return
+	}
+
+	c.JSON(http.StatusOK, sessions)
+}
@@ -0,0 +1,50 @@
+package statement
+
+import (
+	"gophermart-service/internal/base"
+	"gophermart-service/internal/config"
+	"gophermart-service/internal/problem"
+	"gophermart-service/internal/service/jwt"
+	serviceStatement "gophermart-service/internal/service/user/statement"
+	"net/http"
+
+	"github.com/gin-contrib/requestid"
+	"github.com/gin-gonic/gin"
+)
+
+type getUserOrdersStatementHandler struct {
+	logger           config.LoggerInterface
+	statementService serviceStatement.ServiceInterface
+}
+
+func NewGetUserOrdersStatementHandler(
+	logger config.LoggerInterface,
+	statementService serviceStatement.ServiceInterface,
+) base.HandlerInterface {
+	return &getUserOrdersStatementHandler{
+		logger:           logger,
+		statementService: statementService,
+	}
+}
+
+// Handle отдает выписку по заказам пользователя в CSV или PDF
+func (h *getUserOrdersStatementHandler) Handle(c *gin.Context) {
+	requestID := requestid.Get(c)
+
+	user := jwt.ExtractUserFromContext(c.Request.Context())
+	if user == nil {
+		h.logger.Warnw("user not found in context", "requestID", requestID)
+		problem.Unauthorized(c)
+		return
+	}
+
+	var params ExportQueryParams
+	if err := c.ShouldBindQuery(&params); err != nil {
+		h.logger.Warnw("Invalid export parameters", "requestID", requestID, "error", err)
+		problem.Write(c, http.StatusBadRequest, problem.CodeInvalidRequest, "invalid export parameters")
+		return
+	}
+
+	export, err := h.statementService.ExportOrders(c.Request.Context(), user.ID, params.toDTO())
+	streamExport(c, h.logger, export, err)
+}
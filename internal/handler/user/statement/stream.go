@@ -0,0 +1,51 @@
+package statement
+
+import (
+	"fmt"
+	"gophermart-service/internal/base"
+	"gophermart-service/internal/config"
+	"gophermart-service/internal/problem"
+	serviceStatement "gophermart-service/internal/service/user/statement"
+	"net/http"
+
+	"github.com/gin-contrib/requestid"
+	"github.com/gin-gonic/gin"
+)
+
+// ExportQueryParams формат выписки и период
+type ExportQueryParams struct {
+	Format string `form:"format"`
+	From   string `form:"from"`
+	To     string `form:"to"`
+}
+
+func (p *ExportQueryParams) toDTO() *serviceStatement.ExportInDTO {
+	return &serviceStatement.ExportInDTO{Format: p.Format, From: p.From, To: p.To}
+}
+
+// streamExport отдает подготовленную выписку файлом. После начала передачи статус
+// изменить уже нельзя, поэтому ошибка чтения только логируется и обрывает ответ.
+func streamExport(c *gin.Context, logger config.LoggerInterface, export *serviceStatement.Export, err error) {
+	requestID := requestid.Get(c)
+
+	if err != nil {
+		problem.Respond(c, logger, err)
+		return
+	}
+
+	c.Header("Content-Type", export.ContentType)
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, export.Filename))
+	c.Header("Cache-Control", "no-store")
+	c.Status(http.StatusOK)
+
+	if err = base.DisableWriteDeadline(c); err != nil {
+		logger.Warnw("Failed to disable write deadline", "requestID", requestID, "error", err)
+	}
+
+	if err = export.Stream(c.Request.Context(), c.Writer); err != nil {
+		logger.Errorw("Statement export interrupted", "requestID", requestID, "error", err)
+		c.Abort()
+		return
+	}
+	c.Writer.Flush()
+}
@@ -0,0 +1,59 @@
+package twofactor
+
+import (
+	"gophermart-service/internal/base"
+	"gophermart-service/internal/config"
+	"gophermart-service/internal/problem"
+	"gophermart-service/internal/service/jwt"
+	serviceTwoFactor "gophermart-service/internal/service/user/twofactor"
+	"net/http"
+
+	"github.com/gin-contrib/requestid"
+	"github.com/gin-gonic/gin"
+)
+
+type postTwoFactorConfirmHandler struct {
+	logger           config.LoggerInterface
+	twoFactorService serviceTwoFactor.ServiceInterface
+}
+
+// CodeRequestBody представляет запрос с кодом из приложения-аутентификатора
+type CodeRequestBody struct {
+	Code string `json:"code" binding:"required"`
+}
+
+func NewPostTwoFactorConfirmHandler(
+	logger config.LoggerInterface,
+	twoFactorService serviceTwoFactor.ServiceInterface,
+) base.HandlerInterface {
+	return &postTwoFactorConfirmHandler{
+		logger:           logger,
+		twoFactorService: twoFactorService,
+	}
+}
+
+func (h *postTwoFactorConfirmHandler) Handle(c *gin.Context) {
+	requestID := requestid.Get(c)
+
+	user := jwt.ExtractUserFromContext(c.Request.Context())
+	if user == nil {
+		h.logger.Warnw("user not found in context", "requestID", requestID)
+		problem.Unauthorized(c)
+		return
+	}
+
+	var requestBody CodeRequestBody
+	if err := c.ShouldBindJSON(&requestBody); err != nil {
+		h.logger.Warnw("Invalid JSON in request body", "requestID", requestID, "error", err)
+		problem.InvalidRequest(c, err)
+		return
+	}
+
+	response, err := h.twoFactorService.Confirm(c.Request.Context(), user.ID, requestBody.Code)
+	if err != nil {
+		problem.Respond(c, h.logger, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
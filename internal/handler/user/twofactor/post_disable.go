@@ -0,0 +1,53 @@
+package twofactor
+
+import (
+	"gophermart-service/internal/base"
+	"gophermart-service/internal/config"
+	"gophermart-service/internal/problem"
+	"gophermart-service/internal/service/jwt"
+	serviceTwoFactor "gophermart-service/internal/service/user/twofactor"
+	"net/http"
+
+	"github.com/gin-contrib/requestid"
+	"github.com/gin-gonic/gin"
+)
+
+type postTwoFactorDisableHandler struct {
+	logger           config.LoggerInterface
+	twoFactorService serviceTwoFactor.ServiceInterface
+}
+
+func NewPostTwoFactorDisableHandler(
+	logger config.LoggerInterface,
+	twoFactorService serviceTwoFactor.ServiceInterface,
+) base.HandlerInterface {
+	return &postTwoFactorDisableHandler{
+		logger:           logger,
+		twoFactorService: twoFactorService,
+	}
+}
+
+func (h *postTwoFactorDisableHandler) Handle(c *gin.Context) {
+	requestID := requestid.Get(c)
+
+	user := jwt.ExtractUserFromContext(c.Request.Context())
+	if user == nil {
+		h.logger.Warnw("user not found in context", "requestID", requestID)
+		problem.Unauthorized(c)
+		return
+	}
+
+	var requestBody CodeRequestBody
+	if err := c.ShouldBindJSON(&requestBody); err != nil {
+		h.logger.Warnw("Invalid JSON in request body", "requestID", requestID, "error", err)
+		problem.InvalidRequest(c, err)
+		return
+	}
+
+	if err := h.twoFactorService.Disable(c.Request.Context(), user.ID, requestBody.Code); err != nil {
+		problem.Respond(c, h.logger, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Two-factor authentication disabled"})
+}
@@ -0,0 +1,49 @@
+package twofactor
+
+import (
+	"gophermart-service/internal/base"
+	"gophermart-service/internal/config"
+	"gophermart-service/internal/problem"
+	"gophermart-service/internal/service/jwt"
+	serviceTwoFactor "gophermart-service/internal/service/user/twofactor"
+	"net/http"
+
+	"github.com/gin-contrib/requestid"
+	"github.com/gin-gonic/gin"
+)
+
+type postTwoFactorEnrollHandler struct {
+	logger           config.LoggerInterface
+	twoFactorService serviceTwoFactor.ServiceInterface
+}
+
+func NewPostTwoFactorEnrollHandler(
+	logger config.LoggerInterface,
+	twoFactorService serviceTwoFactor.ServiceInterface,
+) base.HandlerInterface {
+	return &postTwoFactorEnrollHandler{
+		logger:           logger,
+		twoFactorService: twoFactorService,
+	}
+}
+
+func (h *postTwoFactorEnrollHandler) Handle(c *gin.Context) {
+	requestID := requestid.Get(c)
+
+	user := jwt.ExtractUserFromContext(c.Request.Context())
+	if user == nil {
+		h.logger.Warnw("user not found in context", "requestID", requestID)
+		problem.Unauthorized(c)
+		return
+	}
+
+	h.logger.Infow("Starting two-factor enrolment", "requestID", requestID, "userID", user.ID)
+
+	response, err := h.twoFactorService.Enroll(c.Request.Context(), user.ID, user.Login)
+	if err != nil {
+		problem.Respond(c, h.logger, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
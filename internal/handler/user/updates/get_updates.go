@@ -0,0 +1,176 @@
+package updates
+
+import (
+	"encoding/json"
+	"fmt"
+	"gophermart-service/internal/base"
+	"gophermart-service/internal/config"
+	"gophermart-service/internal/problem"
+	"gophermart-service/internal/service/jwt"
+	serviceUpdates "gophermart-service/internal/service/user/updates"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-contrib/requestid"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	lastEventIDHeader = "Last-Event-ID"
+	// heartbeatInterval период комментариев-пингов, чтобы прокси не закрывали простаивающее соединение
+	heartbeatInterval = 15 * time.Second
+	// retryInterval рекомендуемая клиенту задержка перед переподключением, мс
+	retryInterval = 3000
+)
+
+type getUserUpdatesHandler struct {
+	logger         config.LoggerInterface
+	updatesService serviceUpdates.ServiceInterface
+}
+
+func NewGetUserUpdatesHandler(
+	logger config.LoggerInterface,
+	updatesService serviceUpdates.ServiceInterface,
+) base.HandlerInterface {
+	return &getUserUpdatesHandler{
+		logger:         logger,
+		updatesService: updatesService,
+	}
+}
+
+// Handle открывает поток Server-Sent Events с изменениями статусов заказов и баланса.
+// Переподключившийся клиент передает Last-Event-ID (заголовок или параметр last_event_id)
+// и получает пропущенные изменения статусов.
+func (h *getUserUpdatesHandler) Handle(c *gin.Context) {
+	requestID := requestid.Get(c)
+	ctx := c.Request.Context()
+
+	user := jwt.ExtractUserFromContext(ctx)
+	if user == nil {
+		h.logger.Warnw("user not found in context", "requestID", requestID)
+		problem.Unauthorized(c)
+		return
+	}
+
+	lastEventID, err := parseLastEventID(c)
+	if err != nil {
+		h.logger.Warnw("invalid Last-Event-ID", "requestID", requestID, "error", err)
+		problem.Write(c, http.StatusBadRequest, problem.CodeInvalidRequest, "invalid Last-Event-ID")
+		return
+	}
+
+	// Подписываемся до загрузки пропущенного, чтобы не потерять события между ними
+	subscription, err := h.updatesService.Subscribe(user.ID)
+	if err != nil {
+		problem.Respond(c, h.logger, err)
+		return
+	}
+	defer subscription.Close()
+
+	var backlog []*serviceUpdates.Notification
+	if lastEventID > 0 {
+		if backlog, err = h.updatesService.Replay(ctx, user.ID, lastEventID); err != nil {
+			problem.Internal(c)
+			return
+		}
+	}
+	balance, err := h.updatesService.Balance(ctx, user.ID)
+	if err != nil {
+		problem.Internal(c)
+		return
+	}
+
+	h.logger.Infow("Updates stream opened",
+		"requestID", requestID,
+		"userID", user.ID,
+		"lastEventID", lastEventID,
+		"backlog", len(backlog))
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+
+	if err = base.DisableWriteDeadline(c); err != nil {
+		h.logger.Warnw("Failed to disable write deadline", "requestID", requestID, "error", err)
+	}
+
+	w := c.Writer
+	if _, err = fmt.Fprintf(w, "retry: %d\n\n", retryInterval); err != nil {
+		return
+	}
+	for _, notification := range append(backlog, balance) {
+		if err = writeNotification(w, notification); err != nil {
+			return
+		}
+		if notification.ID > lastEventID {
+			lastEventID = notification.ID
+		}
+	}
+	w.Flush()
+
+	heartbeat := time.NewTicker(heartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			h.logger.Infow("Updates stream closed by client", "requestID", requestID, "userID", user.ID)
+			return
+		case <-heartbeat.C:
+			if _, err = io.WriteString(w, ": ping\n\n"); err != nil {
+				return
+			}
+		case notification, ok := <-subscription.C:
+			if !ok {
+				// Подписка закрыта сервером: клиент переподключится с последним Last-Event-ID
+				h.logger.Infow("Updates subscription dropped", "requestID", requestID, "userID", user.ID)
+				return
+			}
+			// События, уже отправленные из истории, повторно не отправляем
+			if notification.ID != 0 && notification.ID <= lastEventID {
+				continue
+			}
+			if err = writeNotification(w, notification); err != nil {
+				return
+			}
+			if notification.ID > lastEventID {
+				lastEventID = notification.ID
+			}
+		}
+		w.Flush()
+	}
+}
+
+func parseLastEventID(c *gin.Context) (int64, error) {
+	raw := c.GetHeader(lastEventIDHeader)
+	if raw == "" {
+		raw = c.Query("last_event_id")
+	}
+	if raw == "" {
+		return 0, nil
+	}
+
+	id, err := strconv.ParseInt(raw, 10, 64)
+	if err != nil || id < 0 {
+		return 0, fmt.Errorf("bad event id %q", raw)
+	}
+	return id, nil
+}
+
+func writeNotification(w io.Writer, notification *serviceUpdates.Notification) error {
+	data, err := json.Marshal(notification.Data)
+	if err != nil {
+		return err
+	}
+	if notification.ID != 0 {
+		if _, err = fmt.Fprintf(w, "id: %d\n", notification.ID); err != nil {
+			return err
+		}
+	}
+	_, err = fmt.Fprintf(w, "event: %s\ndata: %s\n\n", notification.Event, data)
+	return err
+}
@@ -0,0 +1,53 @@
+package webhooks
+
+import (
+	"gophermart-service/internal/base"
+	"gophermart-service/internal/config"
+	"gophermart-service/internal/problem"
+	"gophermart-service/internal/service/jwt"
+	serviceWebhook "gophermart-service/internal/service/user/webhook"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-contrib/requestid"
+	"github.com/gin-gonic/gin"
+)
+
+type deleteWebhookHandler struct {
+	logger         config.LoggerInterface
+	webhookService serviceWebhook.ServiceInterface
+}
+
+func NewDeleteWebhookHandler(
+	logger config.LoggerInterface,
+	webhookService serviceWebhook.ServiceInterface,
+) base.HandlerInterface {
+	return &deleteWebhookHandler{
+		logger:         logger,
+		webhookService: webhookService,
+	}
+}
+
+func (h *deleteWebhookHandler) Handle(c *gin.Context) {
+	requestID := requestid.Get(c)
+
+	user := jwt.ExtractUserFromContext(c.Request.Context())
+	if user == nil {
+		h.logger.Warnw("user not found in context", "requestID", requestID)
+		problem.Unauthorized(c)
+		return
+	}
+
+	webhookID, err := strconv.Atoi(c.Param("id"))
+	if err != nil || webhookID <= 0 {
+		problem.Write(c, http.StatusBadRequest, problem.CodeInvalidRequest, "invalid webhook id")
+		return
+	}
+
+	if err = h.webhookService.Delete(c.Request.Context(), user.ID, webhookID); err != nil {
+		problem.Respond(c, h.logger, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
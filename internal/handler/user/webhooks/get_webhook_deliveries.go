@@ -0,0 +1,74 @@
+package webhooks
+
+import (
+	"gophermart-service/internal/base"
+	"gophermart-service/internal/config"
+	"gophermart-service/internal/problem"
+	"gophermart-service/internal/service/jwt"
+	serviceWebhook "gophermart-service/internal/service/user/webhook"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-contrib/requestid"
+	"github.com/gin-gonic/gin"
+)
+
+type getWebhookDeliveriesHandler struct {
+	logger         config.LoggerInterface
+	webhookService serviceWebhook.ServiceInterface
+}
+
+// DeliveriesQueryParams фильтр журнала доставок
+type DeliveriesQueryParams struct {
+	Status string `form:"status"`
+	Limit  int    `form:"limit" binding:"omitempty,min=1,max=200"`
+}
+
+func NewGetWebhookDeliveriesHandler(
+	logger config.LoggerInterface,
+	webhookService serviceWebhook.ServiceInterface,
+) base.HandlerInterface {
+	return &getWebhookDeliveriesHandler{
+		logger:         logger,
+		webhookService: webhookService,
+	}
+}
+
+func (h *getWebhookDeliveriesHandler) Handle(c *gin.Context) {
+	requestID := requestid.Get(c)
+
+	user := jwt.ExtractUserFromContext(c.Request.Context())
+	if user == nil {
+		h.logger.Warnw("user not found in context", "requestID", requestID)
+		problem.Unauthorized(c)
+		return
+	}
+
+	webhookID, err := strconv.Atoi(c.Param("id"))
+	if err != nil || webhookID <= 0 {
+		problem.Write(c, http.StatusBadRequest, problem.CodeInvalidRequest, "invalid webhook id")
+		return
+	}
+
+	var params DeliveriesQueryParams
+	if err = c.ShouldBindQuery(&params); err != nil {
+		problem.InvalidRequest(c, err)
+		return
+	}
+
+	deliveries, err := h.webhookService.ListDeliveries(c.Request.Context(), user.ID, webhookID, &serviceWebhook.DeliveriesInDTO{
+		Status: params.Status,
+		Limit:  params.Limit,
+	})
+	if err != nil {
+		problem.Respond(c, h.logger, err)
+		return
+	}
+
+	if len(deliveries) == 0 {
+		c.Status(http.StatusNoContent)
+		return
+	}
+
+	c.JSON(http.StatusOK, deliveries)
+}
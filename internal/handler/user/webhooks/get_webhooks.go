@@ -0,0 +1,53 @@
+package webhooks
+
+import (
+	"gophermart-service/internal/base"
+	"gophermart-service/internal/config"
+	"gophermart-service/internal/problem"
+	"gophermart-service/internal/service/jwt"
+	serviceWebhook "gophermart-service/internal/service/user/webhook"
+	"net/http"
+
+	"github.com/gin-contrib/requestid"
+	"github.com/gin-gonic/gin"
+)
+
+type getWebhooksHandler struct {
+	logger         config.LoggerInterface
+	webhookService serviceWebhook.ServiceInterface
+}
+
+func NewGetWebhooksHandler(
+	logger config.LoggerInterface,
+	webhookService serviceWebhook.ServiceInterface,
+) base.HandlerInterface {
+	return &getWebhooksHandler{
+		logger:         logger,
+		webhookService: webhookService,
+	}
+}
+
+func (h *getWebhooksHandler) Handle(c *gin.Context) {
+	requestID := requestid.Get(c)
+
+	user := jwt.ExtractUserFromContext(c.Request.Context())
+	if user == nil {
+		h.logger.Warnw("user not found in context", "requestID", requestID)
+		problem.Unauthorized(c)
+		return
+	}
+
+	webhooks, err := h.webhookService.List(c.Request.Context(), user.ID)
+	if err != nil {
+		h.logger.Errorw("Failed to get webhooks", "requestID", requestID, "userID", user.ID, "error", err)
+		problem.Internal(c)
+		return
+	}
+
+	if len(webhooks) == 0 {
+		c.Status(http.StatusNoContent)
+		return
+	}
+
+	c.JSON(http.StatusOK, webhooks)
+}
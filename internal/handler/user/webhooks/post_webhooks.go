@@ -0,0 +1,64 @@
+package webhooks
+
+import (
+	"gophermart-service/internal/base"
+	"gophermart-service/internal/config"
+	"gophermart-service/internal/problem"
+	"gophermart-service/internal/service/jwt"
+	serviceWebhook "gophermart-service/internal/service/user/webhook"
+	"net/http"
+
+	"github.com/gin-contrib/requestid"
+	"github.com/gin-gonic/gin"
+)
+
+type postWebhooksHandler struct {
+	logger         config.LoggerInterface
+	webhookService serviceWebhook.ServiceInterface
+}
+
+type CreateRequestBody struct {
+	URL    string   `json:"url" binding:"required"`
+	Events []string `json:"events" binding:"required"`
+	Secret string   `json:"secret"`
+}
+
+func NewPostWebhooksHandler(
+	logger config.LoggerInterface,
+	webhookService serviceWebhook.ServiceInterface,
+) base.HandlerInterface {
+	return &postWebhooksHandler{
+		logger:         logger,
+		webhookService: webhookService,
+	}
+}
+
+func (h *postWebhooksHandler) Handle(c *gin.Context) {
+	requestID := requestid.Get(c)
+
+	user := jwt.ExtractUserFromContext(c.Request.Context())
+	if user == nil {
+		h.logger.Warnw("user not found in context", "requestID", requestID)
+		problem.Unauthorized(c)
+		return
+	}
+
+	var requestBody CreateRequestBody
+	if err := c.ShouldBindJSON(&requestBody); err != nil {
+		h.logger.Warnw("Invalid JSON in request body", "requestID", requestID, "error", err)
+		problem.InvalidRequest(c, err)
+		return
+	}
+
+	result, err := h.webhookService.Create(c.Request.Context(), user.ID, &serviceWebhook.CreateInDTO{
+		URL:    requestBody.URL,
+		Events: requestBody.Events,
+		Secret: requestBody.Secret,
+	})
+	if err != nil {
+		problem.Respond(c, h.logger, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, result)
+}
@@ -3,6 +3,7 @@ package withdraw
 import (
 	"gophermart-service/internal/base"
 	"gophermart-service/internal/config"
+	"gophermart-service/internal/problem"
 	"gophermart-service/internal/service/jwt"
 	serviceUserWithdraw "gophermart-service/internal/service/user/withdraw"
 	"net/http"
@@ -11,6 +12,15 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
+// ListQueryParams параметры фильтрации, сортировки и пагинации списка списаний
+type ListQueryParams struct {
+	Limit  int    `form:"limit" binding:"omitempty,min=1,max=100"`
+	Cursor string `form:"cursor"`
+	From   string `form:"from"`
+	To     string `form:"to"`
+	Sort   string `form:"sort"`
+}
+
 type getUserWithdrawals struct {
 	logger              config.LoggerInterface
 	userWithdrawService serviceUserWithdraw.ServiceInterface
@@ -32,21 +42,36 @@ func (h *getUserWithdrawals) Handle(c *gin.Context) {
 	user := jwt.ExtractUserFromContext(c.Request.Context())
 	if user == nil {
 		h.logger.Warnw("user not found in context", "requestID", requestID)
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		problem.Unauthorized(c)
+		return
+	}
+
+	// Без limit и cursor список отдается целиком, как до появления пагинации
+	var params ListQueryParams
+	if err := c.ShouldBindQuery(&params); err != nil {
+		h.logger.Warnw("invalid list parameters", "requestID", requestID, "error", err)
+		problem.Write(c, http.StatusBadRequest, problem.CodeInvalidRequest, "invalid pagination parameters")
 		return
 	}
 
-	withdrawals, err := h.userWithdrawService.GetUserWithdrawals(c.Request.Context(), user.ID)
+	page, err := h.userWithdrawService.ListUserWithdrawals(c.Request.Context(), user.ID, &serviceUserWithdraw.ListInDTO{
+		From:   params.From,
+		To:     params.To,
+		Sort:   params.Sort,
+		Cursor: params.Cursor,
+		Limit:  params.Limit,
+	})
 	if err != nil {
-		h.logger.Errorw("failed to get user withdrawals", "requestID", requestID, "error", err)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		h.logger.Warnw("failed to list user withdrawals", "requestID", requestID, "error", err)
+		problem.Respond(c, h.logger, err)
 		return
 	}
 
-	if len(withdrawals) == 0 {
+	if len(page.Withdrawals) == 0 {
 		c.Status(http.StatusNoContent)
 		return
 	}
 
-	c.JSON(http.StatusOK, withdrawals)
+	base.SetNextPageLink(c, page.NextCursor)
+	c.JSON(http.StatusOK, page.Withdrawals)
 }
@@ -3,6 +3,7 @@ package withdraw
 import (
 	"gophermart-service/internal/base"
 	"gophermart-service/internal/config"
+	"gophermart-service/internal/problem"
 	"gophermart-service/internal/service/jwt"
 	serviceUserOrders "gophermart-service/internal/service/user/order"
 	serviceUserWithdraw "gophermart-service/internal/service/user/withdraw"
@@ -41,20 +42,19 @@ func (h *postUserBalanceWithdraw) Handle(c *gin.Context) {
 	user := jwt.ExtractUserFromContext(c.Request.Context())
 	if user == nil {
 		h.logger.Warnw("user not found in context", "requestID", requestID)
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		problem.Unauthorized(c)
 		return
 	}
 
 	var requestBody RequestBody
 	if err := c.ShouldBindBodyWithJSON(&requestBody); err != nil {
 		h.logger.Warnw("failed to bind request body", "requestID", requestID, "error", err)
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+		problem.Write(c, http.StatusBadRequest, problem.CodeInvalidRequest, "invalid request body")
 		return
 	}
 
 	if err := h.userOrdersService.ValidateOrderNumber(c.Request.Context(), requestBody.OrderNumber); err != nil {
-		h.logger.Warnw("invalid order number", "requestID", requestID, "error", err)
-		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": "invalid order number"})
+		problem.Respond(c, h.logger, err)
 		return
 	}
 
@@ -62,14 +62,8 @@ func (h *postUserBalanceWithdraw) Handle(c *gin.Context) {
 		c.Request.Context(),
 		user.ID,
 		requestBody.OrderNumber,
-		requestBody.Sum); err != nil {
-		if serviceUserWithdraw.IsErrNotEnoughBalance(err) {
-			h.logger.Warnw("not enough balance for withdraw", "requestID", requestID, "error", err)
-			c.JSON(http.StatusPaymentRequired, gin.H{"error": "not enough balance"})
-			return
-		}
-		h.logger.Errorw("failed to make new withdraw", "requestID", requestID, "error", err)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		base.NewAmount(requestBody.Sum).Cents()); err != nil {
+		problem.Respond(c, h.logger, err)
 		return
 	}
 
@@ -0,0 +1,56 @@
+package balance
+
+import (
+	"gophermart-service/internal/base"
+	"gophermart-service/internal/config"
+	"gophermart-service/internal/problem"
+	"gophermart-service/internal/service/jwt"
+	userBalance "gophermart-service/internal/service/user/balance"
+	"net/http"
+
+	"github.com/gin-contrib/requestid"
+	"github.com/gin-gonic/gin"
+)
+
+// BalanceOutDTO баланс в представлении v2: обе суммы десятичными строками
+type BalanceOutDTO struct {
+	Current   base.Amount `json:"current"`
+	Withdrawn base.Amount `json:"withdrawn"`
+}
+
+type getUserBalanceHandler struct {
+	logger             config.LoggerInterface
+	userBalanceService userBalance.ServiceInterface
+}
+
+func NewGetUserBalanceHandler(
+	logger config.LoggerInterface,
+	userBalanceService userBalance.ServiceInterface,
+) base.HandlerInterface {
+	return &getUserBalanceHandler{
+		logger:             logger,
+		userBalanceService: userBalanceService,
+	}
+}
+
+func (h *getUserBalanceHandler) Handle(c *gin.Context) {
+	requestID := requestid.Get(c)
+
+	user := jwt.ExtractUserFromContext(c.Request.Context())
+	if user == nil {
+		h.logger.Warnw("user not found in context", "requestID", requestID)
+		problem.Unauthorized(c)
+		return
+	}
+
+	balance, err := h.userBalanceService.GetBalance(c.Request.Context(), user.ID)
+	if err != nil {
+		problem.Respond(c, h.logger, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, &BalanceOutDTO{
+		Current:   base.NewAmount(balance.CurrentBalance),
+		Withdrawn: base.NewAmount(float32(balance.TotalWithdrawn)),
+	})
+}
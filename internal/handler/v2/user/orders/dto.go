@@ -0,0 +1,51 @@
+package orders
+
+import (
+	"gophermart-service/internal/base"
+	"time"
+)
+
+// OrderDTO заказ в представлении v2: начисление передается десятичной строкой
+type OrderDTO struct {
+	Number     string       `json:"number"`
+	Status     string       `json:"status"`
+	Accrual    *base.Amount `json:"accrual,omitempty"`
+	UploadedAt time.Time    `json:"uploaded_at"`
+}
+
+// ListOutDTO страница списка заказов; next_cursor отсутствует на последней странице
+type ListOutDTO struct {
+	Items      []*OrderDTO `json:"items"`
+	NextCursor string      `json:"next_cursor,omitempty"`
+}
+
+// OrderDetailsDTO заказ вместе с историей обработки
+type OrderDetailsDTO struct {
+	OrderDTO
+	History []*OrderEventDTO `json:"history"`
+}
+
+// OrderEventDTO событие истории обработки заказа
+type OrderEventDTO struct {
+	Type          string       `json:"type"`
+	Status        string       `json:"status"`
+	AccrualStatus *string      `json:"accrual_status,omitempty"`
+	Accrual       *base.Amount `json:"accrual,omitempty"`
+	Detail        *string      `json:"detail,omitempty"`
+	CreatedAt     time.Time    `json:"created_at"`
+}
+
+// UploadOutDTO результат загрузки номера заказа
+type UploadOutDTO struct {
+	Number string `json:"number"`
+	Result string `json:"result"`
+}
+
+// accrual возвращает начисление только для заказов, по которым оно есть
+func accrual(value float32) *base.Amount {
+	if value == 0 {
+		return nil
+	}
+	amount := base.NewAmount(value)
+	return &amount
+}
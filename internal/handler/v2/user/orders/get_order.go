@@ -0,0 +1,70 @@
+package orders
+
+import (
+	"gophermart-service/internal/base"
+	"gophermart-service/internal/config"
+	"gophermart-service/internal/problem"
+	"gophermart-service/internal/service/jwt"
+	serviceUserOrders "gophermart-service/internal/service/user/order"
+	"net/http"
+
+	"github.com/gin-contrib/requestid"
+	"github.com/gin-gonic/gin"
+)
+
+type getUserOrderHandler struct {
+	logger            config.LoggerInterface
+	userOrdersService serviceUserOrders.ServiceInterface
+}
+
+func NewGetUserOrderHandler(
+	logger config.LoggerInterface,
+	userOrdersService serviceUserOrders.ServiceInterface,
+) base.HandlerInterface {
+	return &getUserOrderHandler{
+		logger:            logger,
+		userOrdersService: userOrdersService,
+	}
+}
+
+func (h *getUserOrderHandler) Handle(c *gin.Context) {
+	requestID := requestid.Get(c)
+
+	user := jwt.ExtractUserFromContext(c.Request.Context())
+	if user == nil {
+		h.logger.Warnw("user not found in context", "requestID", requestID)
+		problem.Unauthorized(c)
+		return
+	}
+
+	order, err := h.userOrdersService.GetUserOrder(c.Request.Context(), user.ID, c.Param("number"))
+	if err != nil {
+		problem.Respond(c, h.logger, err)
+		return
+	}
+
+	out := &OrderDetailsDTO{
+		OrderDTO: OrderDTO{
+			Number:     order.OrderNumber,
+			Status:     order.Status,
+			Accrual:    accrual(order.Accrual),
+			UploadedAt: order.UploadedAt,
+		},
+		History: make([]*OrderEventDTO, 0, len(order.History)),
+	}
+	for _, event := range order.History {
+		item := &OrderEventDTO{
+			Type:          event.Type,
+			Status:        event.Status,
+			AccrualStatus: event.AccrualStatus,
+			Detail:        event.Detail,
+			CreatedAt:     event.CreatedAt,
+		}
+		if event.Accrual != nil {
+			amount := base.NewAmount(*event.Accrual)
+			item.Accrual = &amount
+		}
+		out.History = append(out.History, item)
+	}
+	c.JSON(http.StatusOK, out)
+}
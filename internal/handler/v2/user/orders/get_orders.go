@@ -0,0 +1,85 @@
+package orders
+
+import (
+	"gophermart-service/internal/base"
+	"gophermart-service/internal/config"
+	"gophermart-service/internal/problem"
+	"gophermart-service/internal/service/jwt"
+	serviceUserOrders "gophermart-service/internal/service/user/order"
+	"net/http"
+	"strings"
+
+	"github.com/gin-contrib/requestid"
+	"github.com/gin-gonic/gin"
+)
+
+// ListQueryParams параметры списка заказов v2; постраничный вывод только по курсору
+type ListQueryParams struct {
+	Limit  int    `form:"limit" binding:"min=1,max=100"`
+	Cursor string `form:"cursor"`
+	Status string `form:"status"`
+	From   string `form:"from"`
+	To     string `form:"to"`
+	Sort   string `form:"sort"`
+}
+
+type getUserOrdersHandler struct {
+	logger            config.LoggerInterface
+	userOrdersService serviceUserOrders.ServiceInterface
+}
+
+func NewGetUserOrdersHandler(
+	logger config.LoggerInterface,
+	userOrdersService serviceUserOrders.ServiceInterface,
+) base.HandlerInterface {
+	return &getUserOrdersHandler{
+		logger:            logger,
+		userOrdersService: userOrdersService,
+	}
+}
+
+// Handle возвращает страницу заказов в конверте {items, next_cursor}; пустой список — это 200
+func (h *getUserOrdersHandler) Handle(c *gin.Context) {
+	requestID := requestid.Get(c)
+
+	user := jwt.ExtractUserFromContext(c.Request.Context())
+	if user == nil {
+		h.logger.Warnw("user not found in context", "requestID", requestID)
+		problem.Unauthorized(c)
+		return
+	}
+
+	params := ListQueryParams{Limit: 100}
+	if err := c.ShouldBindQuery(&params); err != nil {
+		h.logger.Warnw("Invalid list parameters", "requestID", requestID, "error", err)
+		problem.Write(c, http.StatusBadRequest, problem.CodeInvalidRequest, "invalid pagination parameters")
+		return
+	}
+	in := &serviceUserOrders.ListInDTO{
+		From:   params.From,
+		To:     params.To,
+		Sort:   params.Sort,
+		Cursor: params.Cursor,
+		Limit:  params.Limit,
+	}
+	if params.Status != "" {
+		in.Statuses = strings.Split(params.Status, ",")
+	}
+
+	page, err := h.userOrdersService.ListUserOrders(c.Request.Context(), user.ID, in)
+	if err != nil {
+		problem.Respond(c, h.logger, err)
+		return
+	}
+
+	out := &ListOutDTO{Items: make([]*OrderDTO, 0, len(page.Orders)), NextCursor: page.NextCursor}
+	for _, order := range page.Orders {
+		out.Items = append(out.Items, &OrderDTO{
+			Number:     order.OrderNumber,
+			Status:     order.Status,
+			Accrual:    accrual(order.Accrual),
+			UploadedAt: order.UploadedAt,
+		})
+	}
+	c.JSON(http.StatusOK, out)
+}
@@ -0,0 +1,67 @@
+package orders
+
+import (
+	"gophermart-service/internal/base"
+	"gophermart-service/internal/config"
+	"gophermart-service/internal/problem"
+	"gophermart-service/internal/service/jwt"
+	serviceUserOrders "gophermart-service/internal/service/user/order"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/gin-contrib/requestid"
+	"github.com/gin-gonic/gin"
+)
+
+const maxBodySize int64 = 1024
+
+type postUserOrdersHandler struct {
+	logger            config.LoggerInterface
+	userOrdersService serviceUserOrders.ServiceInterface
+}
+
+func NewPostUserOrdersHandler(
+	logger config.LoggerInterface,
+	userOrdersService serviceUserOrders.ServiceInterface,
+) base.HandlerInterface {
+	return &postUserOrdersHandler{
+		logger:            logger,
+		userOrdersService: userOrdersService,
+	}
+}
+
+// Handle принимает номер заказа так же, как v1, но отвечает JSON с результатом вместо текста
+func (h *postUserOrdersHandler) Handle(c *gin.Context) {
+	requestID := requestid.Get(c)
+
+	user := jwt.ExtractUserFromContext(c.Request.Context())
+	if user == nil {
+		h.logger.Warnw("user not found in context", "requestID", requestID)
+		problem.Unauthorized(c)
+		return
+	}
+
+	body, err := io.ReadAll(io.LimitReader(c.Request.Body, maxBodySize))
+	if err != nil {
+		h.logger.Errorw("Failed to read request body", "error", err, "requestID", requestID)
+		problem.Write(c, http.StatusBadRequest, problem.CodeInvalidRequest, "failed to read request body")
+		return
+	}
+	orderNumber := strings.TrimSpace(string(body))
+	if orderNumber == "" {
+		problem.Write(c, http.StatusBadRequest, problem.CodeInvalidRequest, "order number is empty")
+		return
+	}
+
+	if err = h.userOrdersService.LoadNewOrderNumber(c.Request.Context(), user.ID, orderNumber); err != nil {
+		if serviceUserOrders.IsErrOrderAlreadyExistsForUser(err) {
+			c.JSON(http.StatusOK, &UploadOutDTO{Number: orderNumber, Result: serviceUserOrders.BatchItemDuplicate})
+			return
+		}
+		problem.Respond(c, h.logger, err)
+		return
+	}
+
+	c.JSON(http.StatusAccepted, &UploadOutDTO{Number: orderNumber, Result: serviceUserOrders.BatchItemAccepted})
+}
@@ -0,0 +1,92 @@
+package withdraw
+
+import (
+	"gophermart-service/internal/base"
+	"gophermart-service/internal/config"
+	"gophermart-service/internal/problem"
+	"gophermart-service/internal/service/jwt"
+	serviceUserWithdraw "gophermart-service/internal/service/user/withdraw"
+	"net/http"
+	"time"
+
+	"github.com/gin-contrib/requestid"
+	"github.com/gin-gonic/gin"
+)
+
+// ListQueryParams параметры списка списаний v2
+type ListQueryParams struct {
+	Limit  int    `form:"limit" binding:"min=1,max=100"`
+	Cursor string `form:"cursor"`
+	From   string `form:"from"`
+	To     string `form:"to"`
+	Sort   string `form:"sort"`
+}
+
+// WithdrawalDTO списание в представлении v2: сумма десятичной строкой
+type WithdrawalDTO struct {
+	Order       string      `json:"order"`
+	Sum         base.Amount `json:"sum"`
+	ProcessedAt time.Time   `json:"processed_at"`
+}
+
+// ListOutDTO страница списка списаний; next_cursor отсутствует на последней странице
+type ListOutDTO struct {
+	Items      []*WithdrawalDTO `json:"items"`
+	NextCursor string           `json:"next_cursor,omitempty"`
+}
+
+type getUserWithdrawals struct {
+	logger              config.LoggerInterface
+	userWithdrawService serviceUserWithdraw.ServiceInterface
+}
+
+func NewGetUserWithdrawals(
+	logger config.LoggerInterface,
+	userWithdrawService serviceUserWithdraw.ServiceInterface,
+) base.HandlerInterface {
+	return &getUserWithdrawals{
+		logger:              logger,
+		userWithdrawService: userWithdrawService,
+	}
+}
+
+// Handle возвращает страницу списаний в конверте {items, next_cursor}; пустой список — это 200
+func (h *getUserWithdrawals) Handle(c *gin.Context) {
+	requestID := requestid.Get(c)
+
+	user := jwt.ExtractUserFromContext(c.Request.Context())
+	if user == nil {
+		h.logger.Warnw("user not found in context", "requestID", requestID)
+		problem.Unauthorized(c)
+		return
+	}
+
+	params := ListQueryParams{Limit: 100}
+	if err := c.ShouldBindQuery(&params); err != nil {
+		h.logger.Warnw("invalid list parameters", "requestID", requestID, "error", err)
+		problem.Write(c, http.StatusBadRequest, problem.CodeInvalidRequest, "invalid pagination parameters")
+		return
+	}
+
+	page, err := h.userWithdrawService.ListUserWithdrawals(c.Request.Context(), user.ID, &serviceUserWithdraw.ListInDTO{
+		From:   params.From,
+		To:     params.To,
+		Sort:   params.Sort,
+		Cursor: params.Cursor,
+		Limit:  params.Limit,
+	})
+	if err != nil {
+		problem.Respond(c, h.logger, err)
+		return
+	}
+
+	out := &ListOutDTO{Items: make([]*WithdrawalDTO, 0, len(page.Withdrawals)), NextCursor: page.NextCursor}
+	for _, withdrawal := range page.Withdrawals {
+		out.Items = append(out.Items, &WithdrawalDTO{
+			Order:       withdrawal.Order,
+			Sum:         base.NewAmount(withdrawal.Sum),
+			ProcessedAt: withdrawal.ProcessedAt,
+		})
+	}
+	c.JSON(http.StatusOK, out)
+}
@@ -0,0 +1,77 @@
+package withdraw
+
+import (
+	"errors"
+	"gophermart-service/internal/base"
+	"gophermart-service/internal/config"
+	"gophermart-service/internal/problem"
+	"gophermart-service/internal/service/jwt"
+	serviceUserOrders "gophermart-service/internal/service/user/order"
+	serviceUserWithdraw "gophermart-service/internal/service/user/withdraw"
+	"net/http"
+
+	"github.com/gin-contrib/requestid"
+	"github.com/gin-gonic/gin"
+)
+
+// RequestBody запрос на списание; sum принимается строкой ("751.50") или числом
+type RequestBody struct {
+	OrderNumber string      `json:"order" binding:"required"`
+	Sum         base.Amount `json:"sum" binding:"required"`
+}
+
+type postUserBalanceWithdraw struct {
+	logger              config.LoggerInterface
+	userOrdersService   serviceUserOrders.ServiceInterface
+	userWithdrawService serviceUserWithdraw.ServiceInterface
+}
+
+func NewPostUserBalanceWithdraw(
+	logger config.LoggerInterface,
+	userOrdersService serviceUserOrders.ServiceInterface,
+	userWithdrawService serviceUserWithdraw.ServiceInterface,
+) base.HandlerInterface {
+	return &postUserBalanceWithdraw{
+		logger:              logger,
+		userOrdersService:   userOrdersService,
+		userWithdrawService: userWithdrawService,
+	}
+}
+
+func (h *postUserBalanceWithdraw) Handle(c *gin.Context) {
+	requestID := requestid.Get(c)
+
+	user := jwt.ExtractUserFromContext(c.Request.Context())
+	if user == nil {
+		h.logger.Warnw("user not found in context", "requestID", requestID)
+		problem.Unauthorized(c)
+		return
+	}
+
+	var requestBody RequestBody
+	if err := c.ShouldBindJSON(&requestBody); err != nil {
+		h.logger.Warnw("failed to bind request body", "requestID", requestID, "error", err)
+		if errors.Is(err, base.ErrInvalidAmount) {
+			problem.Write(c, http.StatusBadRequest, problem.CodeInvalidAmount, err.Error())
+			return
+		}
+		problem.Write(c, http.StatusBadRequest, problem.CodeInvalidRequest, "invalid request body")
+		return
+	}
+
+	if err := h.userOrdersService.ValidateOrderNumber(c.Request.Context(), requestBody.OrderNumber); err != nil {
+		problem.Respond(c, h.logger, err)
+		return
+	}
+
+	if err := h.userWithdrawService.MakeNewWithdraw(
+		c.Request.Context(),
+		user.ID,
+		requestBody.OrderNumber,
+		requestBody.Sum.Cents()); err != nil {
+		problem.Respond(c, h.logger, err)
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
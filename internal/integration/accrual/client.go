@@ -4,16 +4,21 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"gophermart-service/internal/metrics"
 	"io"
 	"net/http"
 	"strconv"
 	"time"
+
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
 )
 
 // HTTPClient представляет HTTP клиент для работы с системой ACCRUAL
 type HTTPClient struct {
 	baseURL    string
 	httpClient *http.Client
+	// pingClient без трассировки и метрик: проверки доступности идут постоянно и не относятся к заказам
+	pingClient *http.Client
 }
 
 // NewHTTPClient создает новый HTTP клиент для системы ACCRUAL
@@ -22,10 +27,29 @@ func NewHTTPClient(baseURL string, timeout time.Duration) *HTTPClient {
 		baseURL: baseURL,
 		httpClient: &http.Client{
 			Timeout: timeout,
+			// Транспорт открывает клиентский спан и передает traceparent системе начислений
+			Transport: otelhttp.NewTransport(http.DefaultTransport),
+		},
+		pingClient: &http.Client{
+			Timeout: timeout,
 		},
 	}
 }
 
+// Ping отправляет HEAD-запрос на адрес системы: любой HTTP-ответ означает, что она доступна
+func (c *HTTPClient) Ping(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, c.baseURL, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := c.pingClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to execute request: %w", err)
+	}
+	return resp.Body.Close()
+}
+
 // GetOrderInfo получает информацию о расчёте начислений баллов лояльности для заказа
 func (c *HTTPClient) GetOrderInfo(ctx context.Context, orderNumber string) (*OrderInfo, error) {
 	url := fmt.Sprintf("%s/api/orders/%s", c.baseURL, orderNumber)
@@ -35,6 +59,13 @@ func (c *HTTPClient) GetOrderInfo(ctx context.Context, orderNumber string) (*Ord
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
+	start := time.Now()
+	result := metrics.AccrualResultTransportError
+	defer func() {
+		metrics.AccrualRequests.WithLabelValues(result).Inc()
+		metrics.AccrualRequestDuration.WithLabelValues(result).Observe(time.Since(start).Seconds())
+	}()
+
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to execute request: %w", err)
@@ -43,14 +74,19 @@ func (c *HTTPClient) GetOrderInfo(ctx context.Context, orderNumber string) (*Ord
 
 	switch resp.StatusCode {
 	case http.StatusOK:
+		result = metrics.AccrualResultOK
 		return c.parseOrderInfo(resp)
 	case http.StatusNoContent:
+		result = metrics.AccrualResultNotRegistered
 		return nil, nil // заказ не зарегистрирован в системе расчёта
 	case http.StatusTooManyRequests:
+		result = metrics.AccrualResultRateLimited
 		return nil, c.parseRateLimitError(resp)
 	case http.StatusInternalServerError:
+		result = metrics.AccrualResultServerError
 		return nil, fmt.Errorf("internal server error from accrual system")
 	default:
+		result = metrics.AccrualResultUnexpected
 		return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
 	}
 }
@@ -6,4 +6,6 @@ import "context"
 type ClientInterface interface {
 	// GetOrderInfo получает информацию о расчёте начислений баллов лояльности для заказа
 	GetOrderInfo(ctx context.Context, orderNumber string) (*OrderInfo, error)
+	// Ping проверяет, что система ACCRUAL отвечает на HTTP-запросы
+	Ping(ctx context.Context) error
 }
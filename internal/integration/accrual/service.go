@@ -47,3 +47,8 @@ func (s *Service) GetOrderInfo(ctx context.Context, orderNumber string) (*OrderI
 
 	return orderInfo, nil
 }
+
+// Ping проверяет доступность системы ACCRUAL
+func (s *Service) Ping(ctx context.Context) error {
+	return s.client.Ping(ctx)
+}
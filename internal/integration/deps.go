@@ -3,11 +3,17 @@ package integration
 import (
 	"gophermart-service/internal/config"
 	"gophermart-service/internal/integration/accrual"
+	"gophermart-service/internal/integration/notifier"
+	"gophermart-service/internal/integration/oidc"
+	"gophermart-service/internal/integration/webhook"
 	"time"
 )
 
 type Integrations struct {
-	Accrual accrual.ClientInterface
+	Accrual  accrual.ClientInterface
+	Notifier notifier.NotifierInterface
+	OIDC     oidc.ProviderInterface
+	Webhook  webhook.SenderInterface
 }
 
 func NewIntegrations(logger config.LoggerInterface, settings *config.Settings) *Integrations {
@@ -19,7 +25,14 @@ func NewIntegrations(logger config.LoggerInterface, settings *config.Settings) *
 		logger,
 	)
 
+	notifierClient := notifier.NewNotifier(logger, settings.Environment.Notifier)
+	oidcClient := oidc.NewHTTPClient(logger, settings.Environment.OIDC)
+	webhookClient := webhook.NewHTTPClient(settings.Environment.Webhook)
+
 	return &Integrations{
-		Accrual: accrualClient,
+		Accrual:  accrualClient,
+		Notifier: notifierClient,
+		OIDC:     oidcClient,
+		Webhook:  webhookClient,
 	}
 }
@@ -0,0 +1,18 @@
+package notifier
+
+import (
+	"gophermart-service/internal/config"
+)
+
+// NewNotifier создает notifier в соответствии с настройками
+func NewNotifier(logger config.LoggerInterface, settings *config.NotifierSettings) NotifierInterface {
+	switch settings.Type {
+	case config.NotifierTypeFile:
+		return NewFileNotifier(settings.FilePath)
+	case config.NotifierTypeLog:
+		return NewLogNotifier(logger)
+	default:
+		logger.Warnw("Unknown notifier type, falling back to log notifier", "type", settings.Type)
+		return NewLogNotifier(logger)
+	}
+}
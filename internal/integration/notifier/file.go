@@ -0,0 +1,48 @@
+package notifier
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+// FileNotifier дописывает уведомления в файл в формате JSON Lines (для локальной разработки)
+type FileNotifier struct {
+	path string
+	mu   sync.Mutex
+}
+
+// NewFileNotifier создает новый notifier, пишущий уведомления в файл
+func NewFileNotifier(path string) *FileNotifier {
+	return &FileNotifier{path: path}
+}
+
+// Send дописывает уведомление в конец файла
+func (n *FileNotifier) Send(_ context.Context, message *Message) error {
+	if message.SentAt.IsZero() {
+		message.SentAt = time.Now()
+	}
+
+	line, err := json.Marshal(message)
+	if err != nil {
+		return fmt.Errorf("failed to marshal notification: %w", err)
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	file, err := os.OpenFile(n.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to open notifications file: %w", err)
+	}
+	defer file.Close()
+
+	if _, err = file.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("failed to write notification: %w", err)
+	}
+
+	return nil
+}
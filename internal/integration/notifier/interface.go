@@ -0,0 +1,9 @@
+package notifier
+
+import "context"
+
+// NotifierInterface представляет интерфейс доставки уведомлений пользователям
+type NotifierInterface interface {
+	// Send отправляет уведомление получателю
+	Send(ctx context.Context, message *Message) error
+}
@@ -0,0 +1,33 @@
+package notifier
+
+import (
+	"context"
+	"gophermart-service/internal/base"
+	"gophermart-service/internal/config"
+	"time"
+)
+
+// LogNotifier выводит уведомления в лог приложения (для локальной разработки)
+type LogNotifier struct {
+	logger config.LoggerInterface
+}
+
+// NewLogNotifier создает новый notifier, пишущий уведомления в лог
+func NewLogNotifier(logger config.LoggerInterface) *LogNotifier {
+	return &LogNotifier{logger: logger}
+}
+
+// Send записывает уведомление в лог
+func (n *LogNotifier) Send(ctx context.Context, message *Message) error {
+	if message.SentAt.IsZero() {
+		message.SentAt = time.Now()
+	}
+
+	n.logger.Infow("Notification sent",
+		"request_id", base.GetRequestID(ctx),
+		"to", message.To,
+		"subject", message.Subject,
+		"body", message.Body,
+		"sent_at", message.SentAt)
+	return nil
+}
@@ -0,0 +1,11 @@
+package notifier
+
+import "time"
+
+// Message представляет уведомление для пользователя
+type Message struct {
+	To      string    `json:"to"`      // получатель (логин или адрес пользователя)
+	Subject string    `json:"subject"` // тема уведомления
+	Body    string    `json:"body"`    // текст уведомления
+	SentAt  time.Time `json:"sent_at"` // время отправки
+}
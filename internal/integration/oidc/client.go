@@ -0,0 +1,250 @@
+package oidc
+
+import (
+	"context"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"gophermart-service/internal/config"
+	"io"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const maxResponseSize = 1 << 20
+
+// HTTPClient реализует authorization code flow с PKCE (S256) и проверку ID токенов по JWKS провайдера.
+// Документ discovery и ключи загружаются при первом обращении и кешируются.
+type HTTPClient struct {
+	settings   *config.OIDCSettings
+	logger     config.LoggerInterface
+	httpClient *http.Client
+
+	mu        sync.RWMutex
+	discovery *Discovery
+	keys      map[string]*rsa.PublicKey
+}
+
+// NewHTTPClient создает клиента OIDC провайдера
+func NewHTTPClient(logger config.LoggerInterface, settings *config.OIDCSettings) ProviderInterface {
+	return &HTTPClient{
+		settings:   settings,
+		logger:     logger,
+		httpClient: &http.Client{Timeout: settings.HTTPTimeout},
+		keys:       make(map[string]*rsa.PublicKey),
+	}
+}
+
+func (c *HTTPClient) Issuer() string {
+	return strings.TrimSuffix(c.settings.IssuerURL, "/")
+}
+
+func (c *HTTPClient) AuthCodeURL(ctx context.Context, state, nonce, codeChallenge string) (string, error) {
+	discovery, err := c.getDiscovery(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	params := url.Values{}
+	params.Set("response_type", "code")
+	params.Set("client_id", c.settings.ClientID)
+	params.Set("redirect_uri", c.settings.RedirectURL)
+	params.Set("scope", strings.Join(c.settings.Scopes, " "))
+	params.Set("state", state)
+	params.Set("nonce", nonce)
+	params.Set("code_challenge", codeChallenge)
+	params.Set("code_challenge_method", "S256")
+
+	separator := "?"
+	if strings.Contains(discovery.AuthorizationEndpoint, "?") {
+		separator = "&"
+	}
+	return discovery.AuthorizationEndpoint + separator + params.Encode(), nil
+}
+
+func (c *HTTPClient) Exchange(ctx context.Context, code, codeVerifier string) (*Tokens, error) {
+	discovery, err := c.getDiscovery(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	form := url.Values{}
+	form.Set("grant_type", "authorization_code")
+	form.Set("code", code)
+	form.Set("redirect_uri", c.settings.RedirectURL)
+	form.Set("code_verifier", codeVerifier)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, discovery.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	req.SetBasicAuth(url.QueryEscape(c.settings.ClientID), url.QueryEscape(c.settings.ClientSecret))
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrExchangeFailed, err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseSize))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%w: status %d: %s", ErrExchangeFailed, resp.StatusCode, string(body))
+	}
+
+	var tokens Tokens
+	if err = json.Unmarshal(body, &tokens); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+	if tokens.IDToken == "" {
+		return nil, ErrMissingIDToken
+	}
+	return &tokens, nil
+}
+
+func (c *HTTPClient) VerifyIDToken(ctx context.Context, rawIDToken, nonce string) (*IDTokenClaims, error) {
+	discovery, err := c.getDiscovery(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	claims := &IDTokenClaims{}
+	_, err = jwt.ParseWithClaims(rawIDToken, claims,
+		func(token *jwt.Token) (interface{}, error) {
+			kid, _ := token.Header["kid"].(string)
+			return c.getKey(ctx, discovery.JWKSURI, kid)
+		},
+		jwt.WithValidMethods([]string{jwt.SigningMethodRS256.Alg()}),
+		jwt.WithIssuer(discovery.Issuer),
+		jwt.WithAudience(c.settings.ClientID),
+		jwt.WithExpirationRequired(),
+		jwt.WithIssuedAt(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidIDToken, err)
+	}
+	if claims.Subject == "" {
+		return nil, fmt.Errorf("%w: empty subject", ErrInvalidIDToken)
+	}
+	if claims.Nonce != nonce {
+		return nil, ErrNonceMismatch
+	}
+	return claims, nil
+}
+
+func (c *HTTPClient) getDiscovery(ctx context.Context) (*Discovery, error) {
+	c.mu.RLock()
+	discovery := c.discovery
+	c.mu.RUnlock()
+	if discovery != nil {
+		return discovery, nil
+	}
+
+	discoveryURL := c.Issuer() + "/.well-known/openid-configuration"
+	discovery = &Discovery{}
+	if err := c.getJSON(ctx, discoveryURL, discovery); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrDiscoveryFailed, err)
+	}
+	if strings.TrimSuffix(discovery.Issuer, "/") != c.Issuer() {
+		return nil, fmt.Errorf("%w: expected %q, got %q", ErrIssuerMismatch, c.Issuer(), discovery.Issuer)
+	}
+
+	c.mu.Lock()
+	c.discovery = discovery
+	c.mu.Unlock()
+
+	c.logger.Infow("OIDC provider discovered",
+		"issuer", discovery.Issuer,
+		"authorization_endpoint", discovery.AuthorizationEndpoint,
+		"token_endpoint", discovery.TokenEndpoint)
+	return discovery, nil
+}
+
+// getKey возвращает ключ подписи по kid; при неизвестном kid ключи перезагружаются (ротация у провайдера)
+func (c *HTTPClient) getKey(ctx context.Context, jwksURI, kid string) (*rsa.PublicKey, error) {
+	c.mu.RLock()
+	key, ok := c.keys[kid]
+	c.mu.RUnlock()
+	if ok {
+		return key, nil
+	}
+
+	var keySet jsonWebKeySet
+	if err := c.getJSON(ctx, jwksURI, &keySet); err != nil {
+		return nil, fmt.Errorf("failed to fetch jwks: %w", err)
+	}
+
+	keys := make(map[string]*rsa.PublicKey, len(keySet.Keys))
+	for _, jwk := range keySet.Keys {
+		if jwk.Kty != "RSA" || (jwk.Use != "" && jwk.Use != "sig") {
+			continue
+		}
+		publicKey, err := parseRSAPublicKey(jwk)
+		if err != nil {
+			c.logger.Warnw("Skipping invalid JWK", "kid", jwk.Kid, "error", err)
+			continue
+		}
+		keys[jwk.Kid] = publicKey
+	}
+
+	c.mu.Lock()
+	c.keys = keys
+	c.mu.Unlock()
+
+	// Провайдер с единственным ключом может не указывать kid в заголовке токена
+	if kid == "" && len(keys) == 1 {
+		for _, publicKey := range keys {
+			return publicKey, nil
+		}
+	}
+	if key, ok = keys[kid]; ok {
+		return key, nil
+	}
+	return nil, ErrUnknownKey
+}
+
+func (c *HTTPClient) getJSON(ctx context.Context, target string, dst interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, target, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to execute request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+	return json.NewDecoder(io.LimitReader(resp.Body, maxResponseSize)).Decode(dst)
+}
+
+func parseRSAPublicKey(jwk jsonWebKey) (*rsa.PublicKey, error) {
+	n, err := base64.RawURLEncoding.DecodeString(jwk.N)
+	if err != nil {
+		return nil, fmt.Errorf("invalid modulus: %w", err)
+	}
+	e, err := base64.RawURLEncoding.DecodeString(jwk.E)
+	if err != nil {
+		return nil, fmt.Errorf("invalid exponent: %w", err)
+	}
+
+	exponent := new(big.Int).SetBytes(e)
+	if !exponent.IsInt64() || exponent.Int64() < 3 {
+		return nil, fmt.Errorf("invalid exponent")
+	}
+	return &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(exponent.Int64())}, nil
+}
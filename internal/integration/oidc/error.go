@@ -0,0 +1,13 @@
+package oidc
+
+import "errors"
+
+var (
+	ErrDiscoveryFailed = errors.New("oidc discovery failed")
+	ErrIssuerMismatch  = errors.New("oidc issuer mismatch")
+	ErrExchangeFailed  = errors.New("oidc code exchange failed")
+	ErrMissingIDToken  = errors.New("oidc token response has no id_token")
+	ErrInvalidIDToken  = errors.New("invalid oidc id_token")
+	ErrNonceMismatch   = errors.New("oidc nonce mismatch")
+	ErrUnknownKey      = errors.New("oidc signing key not found")
+)
@@ -0,0 +1,15 @@
+package oidc
+
+import "context"
+
+// ProviderInterface представляет клиента OpenID Connect провайдера (authorization code flow с PKCE)
+type ProviderInterface interface {
+	// AuthCodeURL возвращает адрес страницы входа провайдера
+	AuthCodeURL(ctx context.Context, state, nonce, codeChallenge string) (string, error)
+	// Exchange обменивает код авторизации на токены
+	Exchange(ctx context.Context, code, codeVerifier string) (*Tokens, error)
+	// VerifyIDToken проверяет подпись и claims ID токена
+	VerifyIDToken(ctx context.Context, rawIDToken, nonce string) (*IDTokenClaims, error)
+	// Issuer возвращает идентификатор провайдера
+	Issuer() string
+}
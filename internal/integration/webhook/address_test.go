@@ -0,0 +1,82 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"gophermart-service/internal/config"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"testing"
+	"time"
+)
+
+func TestIsPublicAddress(t *testing.T) {
+	tests := []struct {
+		addr string
+		want bool
+	}{
+		{"8.8.8.8", true},
+		{"2606:4700:4700::1111", true},
+		{"127.0.0.1", false},
+		{"::1", false},
+		{"10.1.2.3", false},
+		{"172.16.0.1", false},
+		{"192.168.1.1", false},
+		{"169.254.169.254", false},
+		{"fe80::1", false},
+		{"fc00::1", false},
+		{"0.0.0.0", false},
+		{"::", false},
+		{"0.1.2.3", false},
+		{"100.64.0.1", false},
+		{"224.0.0.1", false},
+		{"::ffff:127.0.0.1", false},
+		{"::ffff:8.8.8.8", true},
+		{"64:ff9b::a00:1", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.addr, func(t *testing.T) {
+			if got := IsPublicAddress(netip.MustParseAddr(tt.addr)); got != tt.want {
+				t.Errorf("IsPublicAddress(%s) = %v, want %v", tt.addr, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHTTPClientPrivateNetworks(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = w.Write([]byte("internal secret"))
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name       string
+		allow      bool
+		wantErr    error
+		wantStatus int
+	}{
+		{name: "loopback is rejected at dial time", allow: false, wantErr: ErrForbiddenAddress},
+		{name: "loopback is allowed when configured", allow: true, wantStatus: http.StatusInternalServerError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := NewHTTPClient(&config.WebhookSettings{HTTPTimeout: time.Second, AllowPrivateNetworks: tt.allow})
+
+			response, err := client.Send(context.Background(), &Request{URL: server.URL, Body: []byte("{}")})
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("Send error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Send: %v", err)
+			}
+			if response.StatusCode != tt.wantStatus {
+				t.Errorf("status = %d, want %d", response.StatusCode, tt.wantStatus)
+			}
+		})
+	}
+}
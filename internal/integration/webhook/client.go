@@ -0,0 +1,69 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"gophermart-service/internal/config"
+	"io"
+	"net"
+	"net/http"
+	"time"
+)
+
+// maxDrainSize сколько байт ответа дочитывается, чтобы соединение можно было переиспользовать.
+// Тело ответа не сохраняется: оно не должно попадать к владельцу подписки.
+const maxDrainSize = 4 << 10
+
+// HTTPClient отправляет вебхуки без следования редиректам: получатель должен отвечать 2xx
+// по адресу из подписки, иначе попытка считается неудачной
+type HTTPClient struct {
+	httpClient *http.Client
+}
+
+// NewHTTPClient создает HTTP-транспорт вебхуков. Соединения с непубличными адресами
+// запрещены, если это не разрешено настройкой WEBHOOK_ALLOW_PRIVATE_NETWORKS.
+func NewHTTPClient(settings *config.WebhookSettings) SenderInterface {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	// Прокси соединялся бы с получателем сам, в обход проверки адреса
+	transport.Proxy = nil
+	if !settings.AllowPrivateNetworks {
+		dialer := &net.Dialer{
+			Timeout:   30 * time.Second,
+			KeepAlive: 30 * time.Second,
+			Control:   controlDial,
+		}
+		transport.DialContext = dialer.DialContext
+	}
+
+	return &HTTPClient{
+		httpClient: &http.Client{
+			Transport: transport,
+			Timeout:   settings.HTTPTimeout,
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+	}
+}
+
+func (c *HTTPClient) Send(ctx context.Context, request *Request) (*Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, request.URL, bytes.NewReader(request.Body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "gophermart-webhooks/1.0")
+	for name, value := range request.Headers {
+		req.Header.Set(name, value)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, maxDrainSize))
+	return &Response{StatusCode: resp.StatusCode}, nil
+}
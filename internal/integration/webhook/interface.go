@@ -0,0 +1,9 @@
+package webhook
+
+import "context"
+
+// SenderInterface представляет HTTP-транспорт доставки вебхуков
+type SenderInterface interface {
+	// Send отправляет POST-запрос и возвращает код ответа получателя
+	Send(ctx context.Context, request *Request) (*Response, error)
+}
@@ -0,0 +1,18 @@
+package webhook
+
+// Request исходящий запрос вебхука
+type Request struct {
+	URL     string
+	Headers map[string]string
+	Body    []byte
+}
+
+// Response ответ получателя; в журнал доставок попадает только код ответа
+type Response struct {
+	StatusCode int
+}
+
+// IsSuccess сообщает, подтвердил ли получатель доставку
+func (r *Response) IsSuccess() bool {
+	return r.StatusCode >= 200 && r.StatusCode < 300
+}
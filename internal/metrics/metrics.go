@@ -0,0 +1,109 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+)
+
+const namespace = "gophermart"
+
+// Результаты обращения к системе расчета начислений
+const (
+	AccrualResultOK             = "ok"
+	AccrualResultNotRegistered  = "not_registered"
+	AccrualResultRateLimited    = "rate_limited"
+	AccrualResultServerError    = "server_error"
+	AccrualResultUnexpected     = "unexpected_status"
+	AccrualResultTransportError = "transport_error"
+)
+
+// Результаты обработки заказа воркером
+const (
+	OrderResultProcessed     = "processed"
+	OrderResultInvalid       = "invalid"
+	OrderResultPending       = "pending"
+	OrderResultNotRegistered = "not_registered"
+	OrderResultRateLimited   = "rate_limited"
+	OrderResultAccrualError  = "accrual_error"
+	OrderResultFailed        = "failed"
+)
+
+// Registry реестр метрик сервиса, который отдает обработчик /metrics.
+// Собственный реестр вместо глобального, чтобы в выдачу не попадали метрики сторонних библиотек.
+var Registry = prometheus.NewRegistry()
+
+var (
+	HTTPRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "http",
+		Name:      "requests_total",
+		Help:      "Количество HTTP-запросов по маршруту и статусу ответа.",
+	}, []string{"method", "route", "status"})
+
+	HTTPRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "http",
+		Name:      "request_duration_seconds",
+		Help:      "Время обработки HTTP-запросов по маршруту и статусу ответа.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "route", "status"})
+
+	AccrualRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "accrual",
+		Name:      "requests_total",
+		Help:      "Количество запросов к системе расчета начислений по результату.",
+	}, []string{"result"})
+
+	AccrualRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "accrual",
+		Name:      "request_duration_seconds",
+		Help:      "Время запросов к системе расчета начислений по результату.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"result"})
+
+	OrdersProcessed = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "order_worker",
+		Name:      "orders_total",
+		Help:      "Количество заказов, обработанных воркерами, по результату обработки.",
+	}, []string{"result"})
+
+	RateLimitSleeps = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "order_worker",
+		Name:      "rate_limit_sleeps_total",
+		Help:      "Сколько раз воркеры засыпали из-за ограничения частоты запросов системы начислений.",
+	})
+
+	RateLimitSleepSeconds = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "order_worker",
+		Name:      "rate_limit_sleep_seconds_total",
+		Help:      "Суммарное время сна воркеров из-за ограничения частоты запросов.",
+	})
+
+	WithdrawalAmount = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "withdrawals",
+		Name:      "amount",
+		Help:      "Суммы успешных списаний баллов.",
+		Buckets:   []float64{1, 10, 50, 100, 250, 500, 1000, 2500, 5000, 10000},
+	})
+)
+
+func init() {
+	Registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		HTTPRequests,
+		HTTPRequestDuration,
+		AccrualRequests,
+		AccrualRequestDuration,
+		OrdersProcessed,
+		RateLimitSleeps,
+		RateLimitSleepSeconds,
+		WithdrawalAmount,
+	)
+}
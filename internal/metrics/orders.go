@@ -0,0 +1,60 @@
+package metrics
+
+import (
+	"context"
+	"gophermart-service/internal/config"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// orderQueueTimeout ограничивает запрос к БД при опросе метрик
+const orderQueueTimeout = 2 * time.Second
+
+// orderStatuses статусы, которые всегда присутствуют в выдаче, даже с нулевым значением
+var orderStatuses = []string{"NEW", "PROCESSING", "INVALID", "PROCESSED"}
+
+// OrderCounterInterface источник количества заказов по статусам
+type OrderCounterInterface interface {
+	CountOrdersByStatus(ctx context.Context) (map[string]int, error)
+}
+
+// orderQueueCollector отдает глубину очереди заказов по статусам, запрашивая ее при опросе
+type orderQueueCollector struct {
+	logger  config.LoggerInterface
+	counter OrderCounterInterface
+	orders  *prometheus.Desc
+}
+
+// RegisterOrderQueue добавляет в реестр метрику количества заказов по статусам
+func RegisterOrderQueue(logger config.LoggerInterface, counter OrderCounterInterface) error {
+	return Registry.Register(&orderQueueCollector{
+		logger:  logger,
+		counter: counter,
+		orders: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "orders", "by_status"),
+			"Количество заказов в каждом статусе.",
+			[]string{"status"}, nil,
+		),
+	})
+}
+
+func (c *orderQueueCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.orders
+}
+
+func (c *orderQueueCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), orderQueueTimeout)
+	defer cancel()
+
+	counts, err := c.counter.CountOrdersByStatus(ctx)
+	if err != nil {
+		// Без данных метрика пропускается, остальные метрики отдаются как обычно
+		c.logger.Warnw("Failed to count orders by status for metrics", "error", err)
+		return
+	}
+
+	for _, status := range orderStatuses {
+		ch <- prometheus.MustNewConstMetric(c.orders, prometheus.GaugeValue, float64(counts[status]), status)
+	}
+}
@@ -0,0 +1,79 @@
+package metrics
+
+import (
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// poolCollector снимает статистику пула соединений с БД в момент опроса
+type poolCollector struct {
+	pool *pgxpool.Pool
+
+	acquiredConns     *prometheus.Desc
+	idleConns         *prometheus.Desc
+	constructingConns *prometheus.Desc
+	totalConns        *prometheus.Desc
+	maxConns          *prometheus.Desc
+	acquires          *prometheus.Desc
+	acquireDuration   *prometheus.Desc
+	emptyAcquires     *prometheus.Desc
+	canceledAcquires  *prometheus.Desc
+	newConns          *prometheus.Desc
+	lifetimeDestroys  *prometheus.Desc
+	idleDestroys      *prometheus.Desc
+}
+
+// RegisterPool добавляет в реестр метрики пула соединений pgxpool
+func RegisterPool(pool *pgxpool.Pool) error {
+	return Registry.Register(newPoolCollector(pool))
+}
+
+func newPoolCollector(pool *pgxpool.Pool) *poolCollector {
+	desc := func(name, help string) *prometheus.Desc {
+		return prometheus.NewDesc(prometheus.BuildFQName(namespace, "db_pool", name), help, nil, nil)
+	}
+
+	return &poolCollector{
+		pool:              pool,
+		acquiredConns:     desc("acquired_conns", "Соединения, занятые в данный момент."),
+		idleConns:         desc("idle_conns", "Свободные соединения."),
+		constructingConns: desc("constructing_conns", "Соединения в процессе установки."),
+		totalConns:        desc("total_conns", "Все соединения пула."),
+		maxConns:          desc("max_conns", "Максимальный размер пула."),
+		acquires:          desc("acquires_total", "Успешные получения соединения из пула."),
+		acquireDuration:   desc("acquire_duration_seconds_total", "Суммарное время ожидания соединения."),
+		emptyAcquires:     desc("empty_acquires_total", "Получения соединения, которым пришлось ждать освобождения."),
+		canceledAcquires:  desc("canceled_acquires_total", "Получения соединения, отмененные контекстом."),
+		newConns:          desc("new_conns_total", "Открытые пулом соединения."),
+		lifetimeDestroys:  desc("max_lifetime_destroys_total", "Соединения, закрытые по MaxConnLifetime."),
+		idleDestroys:      desc("max_idle_destroys_total", "Соединения, закрытые по MaxConnIdleTime."),
+	}
+}
+
+func (c *poolCollector) Describe(ch chan<- *prometheus.Desc) {
+	prometheus.DescribeByCollect(c, ch)
+}
+
+func (c *poolCollector) Collect(ch chan<- prometheus.Metric) {
+	stat := c.pool.Stat()
+
+	gauge := func(desc *prometheus.Desc, value float64) {
+		ch <- prometheus.MustNewConstMetric(desc, prometheus.GaugeValue, value)
+	}
+	counter := func(desc *prometheus.Desc, value float64) {
+		ch <- prometheus.MustNewConstMetric(desc, prometheus.CounterValue, value)
+	}
+
+	gauge(c.acquiredConns, float64(stat.AcquiredConns()))
+	gauge(c.idleConns, float64(stat.IdleConns()))
+	gauge(c.constructingConns, float64(stat.ConstructingConns()))
+	gauge(c.totalConns, float64(stat.TotalConns()))
+	gauge(c.maxConns, float64(stat.MaxConns()))
+	counter(c.acquires, float64(stat.AcquireCount()))
+	counter(c.acquireDuration, stat.AcquireDuration().Seconds())
+	counter(c.emptyAcquires, float64(stat.EmptyAcquireCount()))
+	counter(c.canceledAcquires, float64(stat.CanceledAcquireCount()))
+	counter(c.newConns, float64(stat.NewConnsCount()))
+	counter(c.lifetimeDestroys, float64(stat.MaxLifetimeDestroyCount()))
+	counter(c.idleDestroys, float64(stat.MaxIdleDestroyCount()))
+}
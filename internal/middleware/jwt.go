@@ -3,18 +3,37 @@ package middleware
 import (
 	"gophermart-service/internal/base"
 	"gophermart-service/internal/config"
+	"gophermart-service/internal/problem"
+	"gophermart-service/internal/service/apikey"
 	"gophermart-service/internal/service/jwt"
+	userAuth "gophermart-service/internal/service/user/auth"
+	userSession "gophermart-service/internal/service/user/session"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/gin-contrib/requestid"
 	"github.com/gin-gonic/gin"
 )
 
+// RouteScopes сопоставляет маршрут вида "METHOD /path" с правом доступа, необходимым API-ключу.
+// Маршруты, отсутствующие в списке, для API-ключей закрыты.
+type RouteScopes map[string]string
+
+// PublicRoutes перечисляет маршруты вида "METHOD /path", доступные без аутентификации.
+// Устаревший токен на них не отклоняется, иначе после смены пароля или завершения сессии
+// браузер со старой кукой не смог бы войти заново.
+type PublicRoutes map[string]bool
+
 func JWTMiddleware(
 	logger config.LoggerInterface,
 	jwtSettings *config.JWTSettings,
 	jwtService jwt.ServiceInterface,
+	userAuthService userAuth.ServiceInterface,
+	sessionService userSession.ServiceInterface,
+	apiKeyService apikey.ServiceInterface,
+	routeScopes RouteScopes,
+	publicRoutes PublicRoutes,
 ) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		requestID := requestid.Get(c)
@@ -26,23 +45,100 @@ func JWTMiddleware(
 			return
 		}
 
+		if apikey.IsAPIKey(token) || ExtractAPIKeyFromHeader(c) != "" {
+			authenticateAPIKey(c, logger, apiKeyService, routeScopes, token)
+			return
+		}
+
+		// reject прерывает цепочку обработчиков; на публичных маршрутах запрос продолжается
+		// без пользователя, а устаревшая кука удаляется
+		reject := func(code problem.Code, detail string) {
+			if publicRoutes[c.Request.Method+" "+c.FullPath()] {
+				if ExtractTokenFromCookie(c, jwtSettings.CookieName) != "" {
+					base.SetTokenToCookie(c, "", jwtSettings, -time.Second)
+				}
+				return
+			}
+			problem.Abort(c, http.StatusUnauthorized, code, detail)
+		}
+
 		user, err := jwtService.ValidateToken(requestCtx, token)
 		if err != nil {
 			logger.Warnw("Invalid JWT token", "error", err, "request_id", requestID)
-			c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+			reject(problem.CodeTokenInvalid, err.Error())
 			return
 		}
 
+		// Токены, выданные до смены пароля, считаются отозванными
+		tokenVersion, err := userAuthService.GetTokenVersion(requestCtx, user.ID)
+		if err != nil || tokenVersion != user.TokenVersion {
+			logger.Warnw("Revoked JWT token", "error", err, "user_id", user.ID, "request_id", requestID)
+			reject(problem.CodeTokenRevoked, jwt.ErrTokenRevoked.Error())
+			return
+		}
+
+		// Токены без sid выданы до появления сессий и принимаются до истечения срока действия
+		if user.SessionID != 0 {
+			if err = sessionService.Validate(requestCtx, user.ID, user.SessionID); err != nil {
+				logger.Warnw("Terminated session", "error", err, "session_id", user.SessionID, "request_id", requestID)
+				reject(problem.CodeSessionTerminated, userSession.ErrSessionTerminated.Error())
+				return
+			}
+		}
+
 		// Добавляем информацию о пользователе в контекст запроса
 		requestCtx = jwt.SetUserInContext(requestCtx, user)
 		c.Request = c.Request.WithContext(requestCtx)
 	}
 }
 
+// authenticateAPIKey аутентифицирует запрос по API-ключу и проверяет право доступа к маршруту
+func authenticateAPIKey(
+	c *gin.Context,
+	logger config.LoggerInterface,
+	apiKeyService apikey.ServiceInterface,
+	routeScopes RouteScopes,
+	rawKey string,
+) {
+	requestID := requestid.Get(c)
+	requestCtx := c.Request.Context()
+
+	user, err := apiKeyService.Authenticate(requestCtx, rawKey)
+	if err != nil {
+		logger.Warnw("Invalid API key", "error", err, "request_id", requestID)
+		problem.Abort(c, http.StatusUnauthorized, problem.CodeAPIKeyInvalid, apikey.ErrInvalidAPIKey.Error())
+		return
+	}
+
+	route := c.Request.Method + " " + c.FullPath()
+	scope, ok := routeScopes[route]
+	if !ok || !user.HasScope(scope) {
+		logger.Warnw("API key scope denied",
+			"route", route,
+			"required_scope", scope,
+			"api_key_id", user.APIKeyID,
+			"request_id", requestID)
+		p := problem.New(c, http.StatusForbidden, problem.CodeInsufficientScope, "insufficient scope")
+		p.Details = gin.H{"required_scope": scope}
+		p.Abort(c)
+		return
+	}
+
+	requestCtx = jwt.SetUserInContext(requestCtx, user)
+	c.Request = c.Request.WithContext(requestCtx)
+}
+
+// ExtractToken извлекает токен доступа: JWT из Cookie или заголовка Authorization,
+// либо API-ключ из заголовка X-API-Key или Authorization: Bearer gm_...
 func ExtractToken(c *gin.Context, logger config.LoggerInterface, cookieName string) string {
 	requestID := requestid.Get(c)
 
-	token := ExtractTokenFromCookie(c, cookieName)
+	token := ExtractAPIKeyFromHeader(c)
+	if token != "" {
+		logger.Debugw("API key found in request Header", "request_id", requestID)
+		return token
+	}
+	token = ExtractTokenFromCookie(c, cookieName)
 	if token != "" {
 		logger.Debugw("JWT token found in request Cookie", "request_id", requestID)
 		return token
@@ -88,3 +184,8 @@ func ExtractTokenFromHeader(c *gin.Context) string {
 
 	return token
 }
+
+// ExtractAPIKeyFromHeader извлекает API-ключ из заголовка X-API-Key
+func ExtractAPIKeyFromHeader(c *gin.Context) string {
+	return strings.TrimSpace(c.GetHeader(base.APIKeyHeader))
+}
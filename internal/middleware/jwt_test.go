@@ -0,0 +1,189 @@
+package middleware
+
+import (
+	"context"
+	"gophermart-service/internal/config"
+	"gophermart-service/internal/service/jwt"
+	userAuth "gophermart-service/internal/service/user/auth"
+	userSession "gophermart-service/internal/service/user/session"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+const testCookieName = "token"
+
+type fakeAuthService struct {
+	userAuth.ServiceInterface
+	tokenVersion int
+}
+
+func (f *fakeAuthService) GetTokenVersion(context.Context, int) (int, error) {
+	return f.tokenVersion, nil
+}
+
+type fakeSessionService struct {
+	userSession.ServiceInterface
+	err error
+}
+
+func (f *fakeSessionService) Validate(context.Context, int, int) error {
+	return f.err
+}
+
+type testServer struct {
+	router     *gin.Engine
+	jwtService jwt.ServiceInterface
+	auth       *fakeAuthService
+	session    *fakeSessionService
+}
+
+// newTestServer собирает роутер с JWTMiddleware, публичным маршрутом входа и закрытым маршрутом баланса
+func newTestServer(t *testing.T) *testServer {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	logger := zap.NewNop().Sugar()
+	jwtSettings := &config.JWTSettings{
+		SecretKey:     "test-secret",
+		TokenDuration: time.Hour,
+		Issuer:        "test",
+		Algorithm:     "HS256",
+		CookieName:    testCookieName,
+		CookiePath:    "/",
+	}
+	s := &testServer{
+		router:     gin.New(),
+		jwtService: jwt.NewJWTService(jwtSettings, logger),
+		auth:       &fakeAuthService{},
+		session:    &fakeSessionService{},
+	}
+
+	s.router.Use(JWTMiddleware(
+		logger,
+		jwtSettings,
+		s.jwtService,
+		s.auth,
+		s.session,
+		nil,
+		nil,
+		PublicRoutes{"POST /api/user/login": true},
+	))
+	s.router.POST("/api/user/login", func(c *gin.Context) {
+		c.SetCookie(testCookieName, "fresh", 3600, "/", "", false, true)
+		c.String(http.StatusOK, "logged in")
+	})
+	s.router.GET("/api/user/balance", func(c *gin.Context) {
+		c.String(http.StatusOK, "handler ran")
+	})
+	return s
+}
+
+func (s *testServer) token(t *testing.T, user *jwt.InDTO) string {
+	t.Helper()
+	token, err := s.jwtService.GenerateToken(context.Background(), user)
+	if err != nil {
+		t.Fatalf("GenerateToken: %v", err)
+	}
+	return token
+}
+
+func (s *testServer) do(method, path, token string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, nil)
+	req.AddCookie(&http.Cookie{Name: testCookieName, Value: token})
+	rec := httptest.NewRecorder()
+	s.router.ServeHTTP(rec, req)
+	return rec
+}
+
+// lastCookie возвращает значение куки, которое браузер сохранит последним
+func lastCookie(rec *httptest.ResponseRecorder) string {
+	value := ""
+	for _, cookie := range rec.Result().Cookies() {
+		if cookie.Name == testCookieName {
+			value = cookie.Value
+		}
+	}
+	return value
+}
+
+func TestJWTMiddlewareRevokedToken(t *testing.T) {
+	s := newTestServer(t)
+	token := s.token(t, &jwt.InDTO{ID: 1, Login: "user", TokenVersion: 1})
+	// Пароль сменили: версия токенов пользователя увеличилась
+	s.auth.tokenVersion = 2
+
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		wantStatus int
+		wantBody   string
+		wantCookie string
+	}{
+		{
+			name:       "protected route is rejected without running the handler",
+			method:     http.MethodGet,
+			path:       "/api/user/balance",
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "public route runs and sets a new cookie",
+			method:     http.MethodPost,
+			path:       "/api/user/login",
+			wantStatus: http.StatusOK,
+			wantBody:   "logged in",
+			wantCookie: "fresh",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := s.do(tt.method, tt.path, token)
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d", rec.Code, tt.wantStatus)
+			}
+			body := rec.Body.String()
+			if tt.wantBody != "" && body != tt.wantBody {
+				t.Errorf("body = %q, want %q", body, tt.wantBody)
+			}
+			if tt.wantBody == "" && strings.Contains(body, "handler ran") {
+				t.Errorf("handler ran after rejection, body = %q", body)
+			}
+			if got := lastCookie(rec); got != tt.wantCookie {
+				t.Errorf("cookie = %q, want %q", got, tt.wantCookie)
+			}
+		})
+	}
+}
+
+func TestJWTMiddlewareTerminatedSession(t *testing.T) {
+	s := newTestServer(t)
+	token := s.token(t, &jwt.InDTO{ID: 1, Login: "user", SessionID: 7})
+	s.session.err = userSession.ErrSessionTerminated
+
+	rec := s.do(http.MethodGet, "/api/user/balance", token)
+	if rec.Code != http.StatusUnauthorized {
+		t.Fatalf("protected route status = %d, want %d", rec.Code, http.StatusUnauthorized)
+	}
+	if body := rec.Body.String(); strings.Contains(body, "handler ran") {
+		t.Fatalf("handler ran after rejection, body = %q", body)
+	}
+
+	// Браузер с кукой завершенной сессии должен иметь возможность войти заново
+	rec = s.do(http.MethodPost, "/api/user/login", token)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("login status = %d, want %d, body = %q", rec.Code, http.StatusOK, rec.Body.String())
+	}
+	if body := rec.Body.String(); body != "logged in" {
+		t.Errorf("login body = %q, want %q", body, "logged in")
+	}
+	if got := lastCookie(rec); got != "fresh" {
+		t.Errorf("cookie after login = %q, want %q", got, "fresh")
+	}
+}
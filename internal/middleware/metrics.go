@@ -0,0 +1,30 @@
+package middleware
+
+import (
+	"gophermart-service/internal/metrics"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// unmatchedRoute метка для запросов к незарегистрированным путям, чтобы произвольные URL
+// не раздували число временных рядов
+const unmatchedRoute = "unmatched"
+
+// Metrics считает HTTP-запросы и время их обработки по шаблону маршрута и статусу ответа
+func Metrics() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+
+		route := c.FullPath()
+		if route == "" {
+			route = unmatchedRoute
+		}
+		status := strconv.Itoa(c.Writer.Status())
+
+		metrics.HTTPRequests.WithLabelValues(c.Request.Method, route, status).Inc()
+		metrics.HTTPRequestDuration.WithLabelValues(c.Request.Method, route, status).Observe(time.Since(start).Seconds())
+	}
+}
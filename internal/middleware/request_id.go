@@ -5,12 +5,16 @@ import (
 
 	"github.com/gin-contrib/requestid"
 	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
-// RequestIDMiddleware Middleware для добавления requestID в context
+// RequestIDMiddleware Middleware для добавления requestID в context и в спан запроса,
+// чтобы по идентификатору из логов можно было найти трассу
 func RequestIDMiddleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		requestID := requestid.Get(c)
+		trace.SpanFromContext(c.Request.Context()).SetAttributes(attribute.String("request.id", requestID))
 		ctx := base.SetRequestID(c.Request.Context(), requestID)
 		c.Request = c.Request.WithContext(ctx)
 		c.Next()
@@ -0,0 +1,38 @@
+package middleware
+
+import (
+	"gophermart-service/internal/config"
+	"gophermart-service/internal/problem"
+	"gophermart-service/internal/service/jwt"
+	"net/http"
+
+	"github.com/gin-contrib/requestid"
+	"github.com/gin-gonic/gin"
+)
+
+// RequireRole ограничивает доступ к группе маршрутов пользователями с одной из указанных ролей.
+// Должен подключаться после JWTMiddleware; API-ключи роли не имеют и доступа не получают.
+func RequireRole(logger config.LoggerInterface, roles ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		requestID := requestid.Get(c)
+
+		user := jwt.ExtractUserFromContext(c.Request.Context())
+		if user == nil {
+			logger.Warnw("User not found in context", "request_id", requestID)
+			problem.Abort(c, http.StatusUnauthorized, problem.CodeUnauthorized, "authentication is required")
+			return
+		}
+
+		if user.IsAPIKey() || !user.HasRole(roles...) {
+			logger.Warnw("Access denied by role",
+				"user_id", user.ID,
+				"role", user.Role,
+				"required_roles", roles,
+				"request_id", requestID)
+			problem.Abort(c, http.StatusForbidden, problem.CodeForbidden, "access denied for the current role")
+			return
+		}
+
+		c.Next()
+	}
+}
@@ -0,0 +1,108 @@
+package middleware
+
+import (
+	"errors"
+	"fmt"
+	"gophermart-service/internal/config"
+	"gophermart-service/internal/problem"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/gin-contrib/requestid"
+	"github.com/gin-gonic/gin"
+)
+
+// Версии HTTP API
+const (
+	APIVersion1 = 1
+	APIVersion2 = 2
+)
+
+const (
+	// APIVersionHeader заголовок запроса и ответа с номером версии API
+	APIVersionHeader = "API-Version"
+	// V2PathPrefix префикс маршрутов второй версии; маршруты без версии в пути относятся к v1
+	V2PathPrefix = "/api/v2"
+)
+
+var (
+	errUnsupportedAPIVersion = errors.New("unsupported api version")
+
+	// vendorMediaType версия в заголовке Accept: application/vnd.gophermart.v2+json
+	vendorMediaType = regexp.MustCompile(`application/vnd\.gophermart\.v(\d+)\+json`)
+)
+
+// APIVersion помечает ответы группы маршрутов номером версии API
+func APIVersion(version int) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Header(APIVersionHeader, strconv.Itoa(version))
+		c.Next()
+	}
+}
+
+// NegotiateAPIVersion обслуживает маршрут без версии в пути. По умолчанию отвечает v1 с заголовками
+// Deprecation, Sunset и ссылкой на маршрут-преемник; v2 выбирается заголовком API-Version: 2
+// или Accept: application/vnd.gophermart.v2+json.
+func NegotiateAPIVersion(
+	logger config.LoggerInterface,
+	settings *config.APISettings,
+	v1, v2 gin.HandlerFunc,
+) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Writer.Header().Add("Vary", "Accept, "+APIVersionHeader)
+
+		version, err := requestedAPIVersion(c.Request)
+		if err != nil {
+			logger.Warnw("Unsupported API version requested",
+				"request_id", requestid.Get(c),
+				"error", err)
+			problem.Abort(c, http.StatusNotAcceptable, problem.CodeUnsupportedAPIVersion, err.Error())
+			return
+		}
+
+		if version == APIVersion2 {
+			c.Header(APIVersionHeader, strconv.Itoa(APIVersion2))
+			v2(c)
+			return
+		}
+
+		c.Header(APIVersionHeader, strconv.Itoa(APIVersion1))
+		setDeprecationHeaders(c, settings)
+		v1(c)
+	}
+}
+
+// setDeprecationHeaders сообщает клиентам v1 о сроках поддержки (RFC 9745, RFC 8594)
+// и о маршруте второй версии, который заменяет текущий
+func setDeprecationHeaders(c *gin.Context, settings *config.APISettings) {
+	if !settings.V1DeprecatedAt.IsZero() {
+		c.Header("Deprecation", fmt.Sprintf("@%d", settings.V1DeprecatedAt.Unix()))
+	}
+	if !settings.V1Sunset.IsZero() {
+		c.Header("Sunset", settings.V1Sunset.UTC().Format(http.TimeFormat))
+	}
+
+	successor := V2PathPrefix + strings.TrimPrefix(c.Request.URL.Path, "/api")
+	c.Writer.Header().Add("Link", "<"+successor+`>; rel="successor-version"`)
+}
+
+// requestedAPIVersion возвращает версию, запрошенную клиентом; без явного запроса это v1
+func requestedAPIVersion(r *http.Request) (int, error) {
+	if raw := strings.TrimSpace(r.Header.Get(APIVersionHeader)); raw != "" {
+		return parseAPIVersion(raw)
+	}
+	if match := vendorMediaType.FindStringSubmatch(r.Header.Get("Accept")); match != nil {
+		return parseAPIVersion(match[1])
+	}
+	return APIVersion1, nil
+}
+
+func parseAPIVersion(raw string) (int, error) {
+	version, err := strconv.Atoi(strings.TrimPrefix(strings.ToLower(raw), "v"))
+	if err != nil || (version != APIVersion1 && version != APIVersion2) {
+		return 0, fmt.Errorf("%w: %s, supported versions are 1 and 2", errUnsupportedAPIVersion, raw)
+	}
+	return version, nil
+}
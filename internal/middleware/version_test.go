@@ -0,0 +1,58 @@
+package middleware
+
+import (
+	"gophermart-service/internal/config"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+func TestNegotiateAPIVersionDeprecationHeaders(t *testing.T) {
+	deprecatedAt := time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)
+	sunset := time.Date(2027, 4, 19, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name            string
+		settings        *config.APISettings
+		wantDeprecation string
+		wantSunset      string
+	}{
+		{
+			name:     "not configured",
+			settings: &config.APISettings{},
+		},
+		{
+			name:            "deprecation date only",
+			settings:        &config.APISettings{V1DeprecatedAt: deprecatedAt},
+			wantDeprecation: "@1792368000",
+		},
+		{
+			name:            "deprecation and sunset dates",
+			settings:        &config.APISettings{V1DeprecatedAt: deprecatedAt, V1Sunset: sunset},
+			wantDeprecation: "@1792368000",
+			wantSunset:      "Mon, 19 Apr 2027 00:00:00 GMT",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+			router := gin.New()
+			ok := func(c *gin.Context) { c.Status(http.StatusOK) }
+			router.GET("/api/user/balance", NegotiateAPIVersion(zap.NewNop().Sugar(), tt.settings, ok, ok))
+
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/user/balance", nil))
+
+			if got := rec.Header().Get("Deprecation"); got != tt.wantDeprecation {
+				t.Errorf("Deprecation = %q, want %q", got, tt.wantDeprecation)
+			}
+			if got := rec.Header().Get("Sunset"); got != tt.wantSunset {
+				t.Errorf("Sunset = %q, want %q", got, tt.wantSunset)
+			}
+		})
+	}
+}
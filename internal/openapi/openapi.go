@@ -0,0 +1,81 @@
+package openapi
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+//go:embed openapi.json
+var spec []byte
+
+//go:embed swagger.html
+var swaggerUI []byte
+
+// Spec возвращает документ OpenAPI 3 в формате JSON
+func Spec() []byte {
+	return spec
+}
+
+// SwaggerUI возвращает HTML-страницу Swagger UI, загружающую /openapi.json
+func SwaggerUI() []byte {
+	return swaggerUI
+}
+
+// Operations возвращает набор описанных в спецификации операций в виде "METHOD /path"
+func Operations() (map[string]struct{}, error) {
+	var document struct {
+		Paths map[string]map[string]json.RawMessage `json:"paths"`
+	}
+	if err := json.Unmarshal(spec, &document); err != nil {
+		return nil, fmt.Errorf("failed to parse openapi spec: %w", err)
+	}
+
+	operations := make(map[string]struct{})
+	for path, item := range document.Paths {
+		for method := range item {
+			operations[strings.ToUpper(method)+" "+path] = struct{}{}
+		}
+	}
+
+	return operations, nil
+}
+
+// CheckRoutes сверяет зарегистрированные маршруты gin со спецификацией
+// и возвращает ошибку со списком маршрутов, которые в ней не описаны. Используется в тестах роутера.
+func CheckRoutes(routes gin.RoutesInfo) error {
+	operations, err := Operations()
+	if err != nil {
+		return err
+	}
+
+	var missing []string
+	for _, route := range routes {
+		operation := route.Method + " " + toOpenAPIPath(route.Path)
+		if _, ok := operations[operation]; !ok {
+			missing = append(missing, operation)
+		}
+	}
+	if len(missing) > 0 {
+		sort.Strings(missing)
+		return fmt.Errorf("routes missing from openapi spec: %s", strings.Join(missing, ", "))
+	}
+
+	return nil
+}
+
+// toOpenAPIPath переводит параметры пути gin (:id) в формат OpenAPI ({id})
+func toOpenAPIPath(path string) string {
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		if strings.HasPrefix(segment, ":") || strings.HasPrefix(segment, "*") {
+			segments[i] = "{" + segment[1:] + "}"
+		}
+	}
+
+	return strings.Join(segments, "/")
+}
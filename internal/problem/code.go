@@ -0,0 +1,103 @@
+package problem
+
+// Code стабильный машиночитаемый код ошибки, на который могут опираться клиенты.
+// Коды не переименовываются: при изменении смысла заводится новый код.
+type Code string
+
+const (
+	CodeInvalidRequest Code = "invalid_request"
+	CodeUnauthorized   Code = "unauthorized"
+	CodeForbidden      Code = "forbidden"
+	CodeNotFound       Code = "not_found"
+	CodeInternal       Code = "internal_error"
+
+	CodeTokenInvalid       Code = "token_invalid"
+	CodeTokenRevoked       Code = "token_revoked"
+	CodeSessionTerminated  Code = "session_terminated"
+	CodeAPIKeyInvalid      Code = "api_key_invalid"
+	CodeInsufficientScope  Code = "insufficient_scope"
+	CodeInvalidCredentials Code = "invalid_credentials"
+
+	CodeLoginRequired       Code = "login_required"
+	CodeLoginTooShort       Code = "login_too_short"
+	CodeLoginTooLong        Code = "login_too_long"
+	CodeLoginReserved       Code = "login_reserved"
+	CodeLoginAlreadyExists  Code = "login_already_exists"
+	CodePasswordRequired    Code = "password_required"
+	CodePasswordTooShort    Code = "password_too_short"
+	CodePasswordTooLong     Code = "password_too_long"
+	CodePasswordPolicy      Code = "password_policy_violation"
+	CodeOldPasswordRequired Code = "old_password_required"
+	CodeWrongOldPassword    Code = "wrong_old_password"
+	CodeSamePassword        Code = "same_password"
+	CodeResetTokenRequired  Code = "reset_token_required"
+	CodeResetTokenInvalid   Code = "reset_token_invalid"
+
+	CodeTwoFactorRequired         Code = "two_factor_required"
+	CodeTwoFactorCodeRequired     Code = "two_factor_code_required"
+	CodeTwoFactorCodeInvalid      Code = "two_factor_code_invalid"
+	CodeTwoFactorNotEnrolled      Code = "two_factor_not_enrolled"
+	CodeTwoFactorNotEnabled       Code = "two_factor_not_enabled"
+	CodeTwoFactorAlreadyEnabled   Code = "two_factor_already_enabled"
+	CodeTwoFactorChallengeInvalid Code = "two_factor_challenge_invalid"
+	CodeTwoFactorLocked           Code = "two_factor_locked"
+
+	CodeOIDCDisabled            Code = "oidc_disabled"
+	CodeOIDCInvalidState        Code = "oidc_invalid_state"
+	CodeOIDCCodeRequired        Code = "oidc_code_required"
+	CodeOIDCAuthFailed          Code = "oidc_authentication_failed"
+	CodeIdentityProviderOffline Code = "identity_provider_unavailable"
+
+	CodeOrderNumberInvalid    Code = "order_number_invalid"
+	CodeOrderNotFound         Code = "order_not_found"
+	CodeOrderOwnedByOtherUser Code = "order_owned_by_another_user"
+	CodeInsufficientBalance   Code = "insufficient_balance"
+	CodeOrderBatchEmpty       Code = "order_batch_empty"
+	CodeOrderBatchTooLarge    Code = "order_batch_too_large"
+
+	CodeInvalidCursor       Code = "invalid_cursor"
+	CodeInvalidSort         Code = "invalid_sort"
+	CodeInvalidDateRange    Code = "invalid_date_range"
+	CodeInvalidStatusFilter Code = "invalid_status_filter"
+	CodeInvalidAmount       Code = "invalid_amount"
+
+	CodeExportFormatUnsupported Code = "export_format_unsupported"
+
+	CodeAPIKeyNameRequired   Code = "api_key_name_required"
+	CodeAPIKeyNameTooLong    Code = "api_key_name_too_long"
+	CodeAPIKeyScopesRequired Code = "api_key_scopes_required"
+	CodeAPIKeyUnknownScope   Code = "api_key_unknown_scope"
+	CodeAPIKeyNotFound       Code = "api_key_not_found"
+
+	CodeSessionNotFound Code = "session_not_found"
+
+	CodeTooManyStreams Code = "too_many_streams"
+	CodeStreamsClosed  Code = "streams_closed"
+
+	CodeUnsupportedAPIVersion Code = "unsupported_api_version"
+
+	CodeWebhookURLRequired    Code = "webhook_url_required"
+	CodeWebhookURLInvalid     Code = "webhook_url_invalid"
+	CodeWebhookURLInsecure    Code = "webhook_url_insecure"
+	CodeWebhookURLPrivate     Code = "webhook_url_private"
+	CodeWebhookEventsRequired Code = "webhook_events_required"
+	CodeWebhookUnknownEvent   Code = "webhook_unknown_event"
+	CodeWebhookSecretTooShort Code = "webhook_secret_too_short"
+	CodeWebhookSecretTooLong  Code = "webhook_secret_too_long"
+	CodeWebhookLimitReached   Code = "webhook_limit_reached"
+	CodeWebhookNotFound       Code = "webhook_not_found"
+	CodeInvalidDeliveryStatus Code = "invalid_delivery_status"
+
+	CodeUserNotFound         Code = "user_not_found"
+	CodeUnknownRole          Code = "unknown_role"
+	CodeCannotChangeOwnRole  Code = "cannot_change_own_role"
+	CodeConfirmationRequired Code = "confirmation_required"
+	CodeWrongConfirmation    Code = "wrong_confirmation"
+
+	CodeDisplayNameTooLong        Code = "display_name_too_long"
+	CodeEmailInvalid              Code = "email_invalid"
+	CodePhoneInvalid              Code = "phone_invalid"
+	CodeEmailTaken                Code = "email_taken"
+	CodeVerificationTokenRequired Code = "verification_token_required"
+	CodeVerificationTokenInvalid  Code = "verification_token_invalid"
+)
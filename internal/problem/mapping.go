@@ -0,0 +1,115 @@
+package problem
+
+import (
+	serviceAdmin "gophermart-service/internal/service/admin"
+	serviceAPIKey "gophermart-service/internal/service/apikey"
+	servicePagination "gophermart-service/internal/service/pagination"
+	serviceAccount "gophermart-service/internal/service/user/account"
+	serviceAuth "gophermart-service/internal/service/user/auth"
+	serviceOIDC "gophermart-service/internal/service/user/oidc"
+	serviceOrder "gophermart-service/internal/service/user/order"
+	servicePassword "gophermart-service/internal/service/user/password"
+	serviceProfile "gophermart-service/internal/service/user/profile"
+	serviceSession "gophermart-service/internal/service/user/session"
+	serviceStatement "gophermart-service/internal/service/user/statement"
+	serviceTwoFactor "gophermart-service/internal/service/user/twofactor"
+	serviceUpdates "gophermart-service/internal/service/user/updates"
+	serviceWebhook "gophermart-service/internal/service/user/webhook"
+	serviceWithdraw "gophermart-service/internal/service/user/withdraw"
+	"net/http"
+)
+
+// Mapping сопоставляет ошибку сервисного слоя с HTTP-статусом и кодом ошибки
+type Mapping struct {
+	Err    error
+	Status int
+	Code   Code
+}
+
+// mappings единая таблица соответствия ошибок сервисов ответам API.
+// Ошибки сравниваются через errors.Is, поэтому обернутые ошибки тоже распознаются.
+var mappings = []Mapping{
+	{serviceAuth.ErrLoginIsRequired, http.StatusBadRequest, CodeLoginRequired},
+	{serviceAuth.ErrLoginTooShort, http.StatusBadRequest, CodeLoginTooShort},
+	{serviceAuth.ErrLoginTooLong, http.StatusBadRequest, CodeLoginTooLong},
+	{serviceAuth.ErrLoginReserved, http.StatusBadRequest, CodeLoginReserved},
+	{serviceAuth.ErrPasswordIsRequired, http.StatusBadRequest, CodePasswordRequired},
+	{serviceAuth.ErrPasswordTooShort, http.StatusBadRequest, CodePasswordTooShort},
+	{serviceAuth.ErrPasswordTooLong, http.StatusBadRequest, CodePasswordTooLong},
+	{serviceAuth.ErrUserLoginAlreadyExists, http.StatusConflict, CodeLoginAlreadyExists},
+	{serviceAuth.ErrBadPassword, http.StatusUnauthorized, CodeInvalidCredentials},
+	{serviceAuth.ErrUserNotFound, http.StatusUnauthorized, CodeInvalidCredentials},
+
+	{servicePassword.ErrOldPasswordIsRequired, http.StatusBadRequest, CodeOldPasswordRequired},
+	{servicePassword.ErrSamePassword, http.StatusBadRequest, CodeSamePassword},
+	{servicePassword.ErrWrongOldPassword, http.StatusUnauthorized, CodeWrongOldPassword},
+	{servicePassword.ErrResetTokenIsRequired, http.StatusBadRequest, CodeResetTokenRequired},
+	{servicePassword.ErrInvalidResetToken, http.StatusUnauthorized, CodeResetTokenInvalid},
+
+	{serviceTwoFactor.ErrCodeIsRequired, http.StatusBadRequest, CodeTwoFactorCodeRequired},
+	{serviceTwoFactor.ErrNotEnrolled, http.StatusBadRequest, CodeTwoFactorNotEnrolled},
+	{serviceTwoFactor.ErrNotEnabled, http.StatusBadRequest, CodeTwoFactorNotEnabled},
+	{serviceTwoFactor.ErrAlreadyEnabled, http.StatusConflict, CodeTwoFactorAlreadyEnabled},
+	{serviceTwoFactor.ErrInvalidCode, http.StatusUnprocessableEntity, CodeTwoFactorCodeInvalid},
+	{serviceTwoFactor.ErrChallengeIsInvalid, http.StatusUnauthorized, CodeTwoFactorChallengeInvalid},
+	{serviceTwoFactor.ErrTooManyAttempts, http.StatusTooManyRequests, CodeTwoFactorLocked},
+
+	{serviceOIDC.ErrDisabled, http.StatusNotFound, CodeOIDCDisabled},
+	{serviceOIDC.ErrInvalidState, http.StatusBadRequest, CodeOIDCInvalidState},
+	{serviceOIDC.ErrCodeIsRequired, http.StatusBadRequest, CodeOIDCCodeRequired},
+	{serviceOIDC.ErrAuthenticationFailed, http.StatusUnauthorized, CodeOIDCAuthFailed},
+
+	{serviceOrder.ErrBadOrderNumber, http.StatusUnprocessableEntity, CodeOrderNumberInvalid},
+	{serviceOrder.ErrOrderAlreadyProcessed, http.StatusConflict, CodeOrderOwnedByOtherUser},
+	{serviceOrder.ErrOrderNotFound, http.StatusNotFound, CodeOrderNotFound},
+	{serviceOrder.ErrEmptyBatch, http.StatusBadRequest, CodeOrderBatchEmpty},
+	{serviceOrder.ErrBatchTooLarge, http.StatusRequestEntityTooLarge, CodeOrderBatchTooLarge},
+	{serviceWithdraw.ErrNotEnoughBalance, http.StatusPaymentRequired, CodeInsufficientBalance},
+
+	{serviceUpdates.ErrTooManySubscriptions, http.StatusTooManyRequests, CodeTooManyStreams},
+	{serviceUpdates.ErrClosed, http.StatusServiceUnavailable, CodeStreamsClosed},
+
+	{serviceWebhook.ErrURLIsRequired, http.StatusBadRequest, CodeWebhookURLRequired},
+	{serviceWebhook.ErrInvalidURL, http.StatusBadRequest, CodeWebhookURLInvalid},
+	{serviceWebhook.ErrInsecureURL, http.StatusBadRequest, CodeWebhookURLInsecure},
+	{serviceWebhook.ErrPrivateURL, http.StatusBadRequest, CodeWebhookURLPrivate},
+	{serviceWebhook.ErrEventsAreRequired, http.StatusBadRequest, CodeWebhookEventsRequired},
+	{serviceWebhook.ErrUnknownEvent, http.StatusBadRequest, CodeWebhookUnknownEvent},
+	{serviceWebhook.ErrSecretTooShort, http.StatusBadRequest, CodeWebhookSecretTooShort},
+	{serviceWebhook.ErrSecretTooLong, http.StatusBadRequest, CodeWebhookSecretTooLong},
+	{serviceWebhook.ErrTooManyWebhooks, http.StatusConflict, CodeWebhookLimitReached},
+	{serviceWebhook.ErrWebhookNotFound, http.StatusNotFound, CodeWebhookNotFound},
+	{serviceWebhook.ErrInvalidDeliveryStatus, http.StatusBadRequest, CodeInvalidDeliveryStatus},
+
+	{servicePagination.ErrInvalidCursor, http.StatusBadRequest, CodeInvalidCursor},
+	{servicePagination.ErrInvalidSort, http.StatusBadRequest, CodeInvalidSort},
+	{servicePagination.ErrInvalidDateRange, http.StatusBadRequest, CodeInvalidDateRange},
+	{serviceOrder.ErrInvalidStatusFilter, http.StatusBadRequest, CodeInvalidStatusFilter},
+	{serviceStatement.ErrUnsupportedFormat, http.StatusBadRequest, CodeExportFormatUnsupported},
+
+	{serviceAPIKey.ErrNameIsRequired, http.StatusBadRequest, CodeAPIKeyNameRequired},
+	{serviceAPIKey.ErrNameTooLong, http.StatusBadRequest, CodeAPIKeyNameTooLong},
+	{serviceAPIKey.ErrScopesAreRequired, http.StatusBadRequest, CodeAPIKeyScopesRequired},
+	{serviceAPIKey.ErrUnknownScope, http.StatusBadRequest, CodeAPIKeyUnknownScope},
+	{serviceAPIKey.ErrInvalidAPIKey, http.StatusUnauthorized, CodeAPIKeyInvalid},
+	{serviceAPIKey.ErrAPIKeyNotFound, http.StatusNotFound, CodeAPIKeyNotFound},
+
+	{serviceSession.ErrSessionNotFound, http.StatusNotFound, CodeSessionNotFound},
+	{serviceSession.ErrSessionTerminated, http.StatusUnauthorized, CodeSessionTerminated},
+
+	{serviceAdmin.ErrUnknownRole, http.StatusBadRequest, CodeUnknownRole},
+	{serviceAdmin.ErrCannotChangeSelf, http.StatusBadRequest, CodeCannotChangeOwnRole},
+	{serviceAdmin.ErrUserNotFound, http.StatusNotFound, CodeUserNotFound},
+
+	{serviceAccount.ErrConfirmationRequired, http.StatusBadRequest, CodeConfirmationRequired},
+	{serviceAccount.ErrWrongConfirmation, http.StatusUnauthorized, CodeWrongConfirmation},
+	{serviceAccount.ErrUserNotFound, http.StatusNotFound, CodeUserNotFound},
+
+	{serviceProfile.ErrDisplayNameTooLong, http.StatusBadRequest, CodeDisplayNameTooLong},
+	{serviceProfile.ErrInvalidEmail, http.StatusBadRequest, CodeEmailInvalid},
+	{serviceProfile.ErrInvalidPhone, http.StatusBadRequest, CodePhoneInvalid},
+	{serviceProfile.ErrEmailAlreadyVerified, http.StatusConflict, CodeEmailTaken},
+	{serviceProfile.ErrVerificationTokenMissing, http.StatusBadRequest, CodeVerificationTokenRequired},
+	{serviceProfile.ErrVerificationTokenInvalid, http.StatusBadRequest, CodeVerificationTokenInvalid},
+	{serviceProfile.ErrUserNotFound, http.StatusNotFound, CodeUserNotFound},
+}
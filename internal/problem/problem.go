@@ -0,0 +1,140 @@
+package problem
+
+import (
+	"errors"
+	"gophermart-service/internal/config"
+	serviceAuth "gophermart-service/internal/service/user/auth"
+	"net/http"
+
+	"github.com/gin-contrib/requestid"
+	"github.com/gin-gonic/gin"
+)
+
+// ContentType тип содержимого ответов об ошибках (RFC 7807)
+const ContentType = "application/problem+json"
+
+// typePrefix префикс URI типа проблемы; тип однозначно определяется кодом ошибки
+const typePrefix = "urn:gophermart:problem:"
+
+// Problem тело ответа об ошибке в формате RFC 7807 с расширениями code, request_id и details
+type Problem struct {
+	Type      string `json:"type"`
+	Title     string `json:"title"`
+	Status    int    `json:"status"`
+	Detail    string `json:"detail,omitempty"`
+	Instance  string `json:"instance,omitempty"`
+	Code      Code   `json:"code"`
+	RequestID string `json:"request_id,omitempty"`
+	Details   any    `json:"details,omitempty"`
+}
+
+// New создает описание проблемы для текущего запроса
+func New(c *gin.Context, status int, code Code, detail string) *Problem {
+	return &Problem{
+		Type:      typePrefix + string(code),
+		Title:     http.StatusText(status),
+		Status:    status,
+		Detail:    detail,
+		Instance:  c.Request.URL.Path,
+		Code:      code,
+		RequestID: requestid.Get(c),
+	}
+}
+
+// Send отправляет описание проблемы клиенту
+func (p *Problem) Send(c *gin.Context) {
+	c.Header("Content-Type", ContentType)
+	c.JSON(p.Status, p)
+}
+
+// Abort отправляет описание проблемы и прерывает цепочку обработчиков
+func (p *Problem) Abort(c *gin.Context) {
+	c.Header("Content-Type", ContentType)
+	c.AbortWithStatusJSON(p.Status, p)
+}
+
+// Write отправляет ошибку с явно заданными статусом и кодом
+func Write(c *gin.Context, status int, code Code, detail string) {
+	New(c, status, code, detail).Send(c)
+}
+
+// Abort отправляет ошибку с явно заданными статусом и кодом и прерывает цепочку обработчиков
+func Abort(c *gin.Context, status int, code Code, detail string) {
+	New(c, status, code, detail).Abort(c)
+}
+
+// InvalidRequest отвечает на запрос, который не удалось разобрать
+func InvalidRequest(c *gin.Context, err error) {
+	Write(c, http.StatusBadRequest, CodeInvalidRequest, err.Error())
+}
+
+// Unauthorized отвечает на запрос без аутентифицированного пользователя
+func Unauthorized(c *gin.Context) {
+	Write(c, http.StatusUnauthorized, CodeUnauthorized, "authentication is required")
+}
+
+// Internal отвечает на непредвиденную ошибку, не раскрывая ее текст клиенту
+func Internal(c *gin.Context) {
+	Write(c, http.StatusInternalServerError, CodeInternal, "internal server error")
+}
+
+// Respond преобразует ошибку сервисного слоя в ответ по единой таблице соответствия.
+// overrides позволяют обработчику переопределить статус для отдельных ошибок,
+// если в его контексте они означают другое (например, неверный код 2FA при входе).
+// Неизвестные ошибки логируются и возвращаются клиенту как внутренняя ошибка.
+func Respond(c *gin.Context, logger config.LoggerInterface, err error, overrides ...Mapping) {
+	requestID := requestid.Get(c)
+
+	mapping, ok := lookup(err, overrides)
+	if !ok {
+		logger.Errorw("Unhandled error",
+			"request_id", requestID,
+			"method", c.Request.Method,
+			"path", c.FullPath(),
+			"error", err)
+		Internal(c)
+		return
+	}
+
+	logger.Warnw("Request failed",
+		"request_id", requestID,
+		"method", c.Request.Method,
+		"path", c.FullPath(),
+		"status", mapping.Status,
+		"code", mapping.Code,
+		"error", err)
+
+	// В ответ попадает текст исходной ошибки сервиса без обертки с внутренним контекстом
+	p := New(c, mapping.Status, mapping.Code, err.Error())
+	if mapping.Err != nil {
+		p.Detail = mapping.Err.Error()
+	}
+	if serviceAuth.IsErrPasswordPolicy(err) {
+		p.Detail = "password does not meet the policy"
+		p.Details = map[string]any{"violations": serviceAuth.PasswordPolicyViolations(err)}
+	}
+	p.Send(c)
+}
+
+// Lookup находит соответствие ошибки в единой таблице; используется транспортами помимо HTTP
+func Lookup(err error, overrides ...Mapping) (Mapping, bool) {
+	return lookup(err, overrides)
+}
+
+func lookup(err error, overrides []Mapping) (Mapping, bool) {
+	if serviceAuth.IsErrPasswordPolicy(err) {
+		return Mapping{Status: http.StatusBadRequest, Code: CodePasswordPolicy}, true
+	}
+	for _, mapping := range overrides {
+		if errors.Is(err, mapping.Err) {
+			return mapping, true
+		}
+	}
+	for _, mapping := range mappings {
+		if errors.Is(err, mapping.Err) {
+			return mapping, true
+		}
+	}
+
+	return Mapping{}, false
+}
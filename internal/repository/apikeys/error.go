@@ -0,0 +1,11 @@
+package apikeys
+
+import "errors"
+
+var (
+	ErrAPIKeyNotFound = errors.New("api key not found")
+)
+
+func IsErrAPIKeyNotFound(err error) bool {
+	return errors.Is(err, ErrAPIKeyNotFound)
+}
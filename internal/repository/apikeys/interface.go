@@ -0,0 +1,19 @@
+package apikeys
+
+import "context"
+
+type RepositoryInterface interface {
+	RepositoryReaderInterface
+	RepositoryWriterInterface
+}
+
+type RepositoryReaderInterface interface {
+	GetActiveByPrefix(ctx context.Context, prefix string) (*APIKey, error)
+	GetUserAPIKeys(ctx context.Context, userID int) ([]*APIKey, error)
+}
+
+type RepositoryWriterInterface interface {
+	Add(ctx context.Context, apiKey *APIKey) (*APIKey, error)
+	Revoke(ctx context.Context, userID, apiKeyID int) error
+	TouchLastUsed(ctx context.Context, apiKeyID int) error
+}
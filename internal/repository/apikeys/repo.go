@@ -0,0 +1,127 @@
+package apikeys
+
+import (
+	"context"
+	"errors"
+	"gophermart-service/internal/config"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+func NewAPIKeysRepository(logger config.LoggerInterface, pool *pgxpool.Pool) RepositoryInterface {
+	return &Repository{
+		logger: logger,
+		pool:   pool,
+	}
+}
+
+type Repository struct {
+	logger config.LoggerInterface
+	pool   *pgxpool.Pool
+}
+
+func (r *Repository) Add(ctx context.Context, apiKey *APIKey) (*APIKey, error) {
+	query := `INSERT INTO api_keys (user_id, name, prefix, key_hash, scopes)
+			  VALUES ($1, $2, $3, $4, $5)
+			  RETURNING id, created_at`
+
+	created := *apiKey
+	err := r.pool.QueryRow(ctx, query,
+		apiKey.UserID,
+		apiKey.Name,
+		apiKey.Prefix,
+		apiKey.KeyHash,
+		apiKey.Scopes,
+	).Scan(&created.ID, &created.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &created, nil
+}
+
+// GetActiveByPrefix возвращает неотозванный ключ вместе с логином владельца
+func (r *Repository) GetActiveByPrefix(ctx context.Context, prefix string) (*APIKey, error) {
+	query := `SELECT k.id, k.user_id, u.login, k.name, k.prefix, k.key_hash, k.scopes,
+			         k.last_used_at, k.revoked_at, k.created_at
+			  FROM api_keys k
+			  JOIN users u ON u.id = k.user_id
+			  WHERE k.prefix = $1 AND k.revoked_at IS NULL`
+
+	var apiKey APIKey
+	err := r.pool.QueryRow(ctx, query, prefix).Scan(
+		&apiKey.ID,
+		&apiKey.UserID,
+		&apiKey.UserLogin,
+		&apiKey.Name,
+		&apiKey.Prefix,
+		&apiKey.KeyHash,
+		&apiKey.Scopes,
+		&apiKey.LastUsedAt,
+		&apiKey.RevokedAt,
+		&apiKey.CreatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrAPIKeyNotFound
+		}
+		return nil, err
+	}
+	return &apiKey, nil
+}
+
+func (r *Repository) GetUserAPIKeys(ctx context.Context, userID int) ([]*APIKey, error) {
+	query := `SELECT id, user_id, name, prefix, scopes, last_used_at, revoked_at, created_at
+			  FROM api_keys
+			  WHERE user_id = $1
+			  ORDER BY created_at DESC`
+
+	rows, err := r.pool.Query(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var apiKeys []*APIKey
+	for rows.Next() {
+		var apiKey APIKey
+		if err := rows.Scan(
+			&apiKey.ID,
+			&apiKey.UserID,
+			&apiKey.Name,
+			&apiKey.Prefix,
+			&apiKey.Scopes,
+			&apiKey.LastUsedAt,
+			&apiKey.RevokedAt,
+			&apiKey.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		apiKeys = append(apiKeys, &apiKey)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return apiKeys, nil
+}
+
+func (r *Repository) Revoke(ctx context.Context, userID, apiKeyID int) error {
+	query := `UPDATE api_keys SET revoked_at = NOW()
+			  WHERE id = $1 AND user_id = $2 AND revoked_at IS NULL`
+
+	tag, err := r.pool.Exec(ctx, query, apiKeyID, userID)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrAPIKeyNotFound
+	}
+	return nil
+}
+
+func (r *Repository) TouchLastUsed(ctx context.Context, apiKeyID int) error {
+	query := `UPDATE api_keys SET last_used_at = NOW() WHERE id = $1`
+
+	_, err := r.pool.Exec(ctx, query, apiKeyID)
+	return err
+}
@@ -2,21 +2,35 @@ package repository
 
 import (
 	"gophermart-service/internal/config"
+	"gophermart-service/internal/repository/apikeys"
+	"gophermart-service/internal/repository/emailverification"
 	"gophermart-service/internal/repository/health"
+	"gophermart-service/internal/repository/identities"
 	"gophermart-service/internal/repository/orders"
+	"gophermart-service/internal/repository/passwordreset"
+	"gophermart-service/internal/repository/sessions"
+	"gophermart-service/internal/repository/twofactor"
 	"gophermart-service/internal/repository/users"
 	"gophermart-service/internal/repository/views"
+	"gophermart-service/internal/repository/webhooks"
 	"gophermart-service/internal/repository/withdraw"
 
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
 type Repositories struct {
-	Health   health.RepositoryInterface
-	Users    users.RepositoryInterface
-	Orders   orders.RepositoryInterface
-	Views    views.RepositoryInterface
-	Withdraw withdraw.RepositoryInterface
+	Health        health.RepositoryInterface
+	Users         users.RepositoryInterface
+	Orders        orders.RepositoryInterface
+	Views         views.RepositoryInterface
+	Withdraw      withdraw.RepositoryInterface
+	PasswordReset passwordreset.RepositoryInterface
+	TwoFactor     twofactor.RepositoryInterface
+	APIKeys       apikeys.RepositoryInterface
+	Identities    identities.RepositoryInterface
+	Sessions      sessions.RepositoryInterface
+	EmailVerify   emailverification.RepositoryInterface
+	Webhooks      webhooks.RepositoryInterface
 }
 
 func NewRepositories(logger config.LoggerInterface, pool *pgxpool.Pool) *Repositories {
@@ -26,12 +40,26 @@ func NewRepositories(logger config.LoggerInterface, pool *pgxpool.Pool) *Reposit
 	ordersRepo := orders.NewOrdersRepository(logger, pool)
 	viewsRepo := views.NewViewsRepository(logger, pool)
 	withdrawRepo := withdraw.NewWithdrawRepository(logger, pool)
+	passwordResetRepo := passwordreset.NewPasswordResetRepository(logger, pool)
+	twoFactorRepo := twofactor.NewTwoFactorRepository(logger, pool)
+	apiKeysRepo := apikeys.NewAPIKeysRepository(logger, pool)
+	identitiesRepo := identities.NewIdentitiesRepository(logger, pool)
+	sessionsRepo := sessions.NewSessionsRepository(logger, pool)
+	emailVerifyRepo := emailverification.NewEmailVerificationRepository(logger, pool)
+	webhooksRepo := webhooks.NewWebhooksRepository(logger, pool)
 
 	return &Repositories{
-		Health:   healthRepo,
-		Users:    usersRepo,
-		Orders:   ordersRepo,
-		Views:    viewsRepo,
-		Withdraw: withdrawRepo,
+		Health:        healthRepo,
+		Users:         usersRepo,
+		Orders:        ordersRepo,
+		Views:         viewsRepo,
+		Withdraw:      withdrawRepo,
+		PasswordReset: passwordResetRepo,
+		TwoFactor:     twoFactorRepo,
+		APIKeys:       apiKeysRepo,
+		Identities:    identitiesRepo,
+		Sessions:      sessionsRepo,
+		EmailVerify:   emailVerifyRepo,
+		Webhooks:      webhooksRepo,
 	}
 }
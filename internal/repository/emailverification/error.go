@@ -0,0 +1,9 @@
+package emailverification
+
+import "errors"
+
+var ErrTokenNotFound = errors.New("email verification token not found")
+
+func IsErrTokenNotFound(err error) bool {
+	return errors.Is(err, ErrTokenNotFound)
+}
@@ -0,0 +1,12 @@
+package emailverification
+
+import (
+	"context"
+	"time"
+)
+
+type RepositoryInterface interface {
+	Add(ctx context.Context, userID int, email, tokenHash string, expiresAt time.Time) error
+	Consume(ctx context.Context, tokenHash string) (int, string, error)
+	DeleteUserTokens(ctx context.Context, userID int) error
+}
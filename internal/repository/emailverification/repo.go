@@ -0,0 +1,57 @@
+package emailverification
+
+import (
+	"context"
+	"errors"
+	"gophermart-service/internal/config"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+func NewEmailVerificationRepository(logger config.LoggerInterface, pool *pgxpool.Pool) RepositoryInterface {
+	return &Repository{
+		logger: logger,
+		pool:   pool,
+	}
+}
+
+type Repository struct {
+	logger config.LoggerInterface
+	pool   *pgxpool.Pool
+}
+
+func (r *Repository) Add(ctx context.Context, userID int, email, tokenHash string, expiresAt time.Time) error {
+	query := `INSERT INTO email_verification_tokens (user_id, email, token_hash, expires_at) VALUES ($1, $2, $3, $4)`
+
+	_, err := r.pool.Exec(ctx, query, userID, email, tokenHash, expiresAt)
+	return err
+}
+
+// Consume удаляет действующий токен и возвращает ID пользователя и подтверждаемый email
+func (r *Repository) Consume(ctx context.Context, tokenHash string) (int, string, error) {
+	query := `DELETE FROM email_verification_tokens
+			  WHERE token_hash = $1 AND expires_at > NOW()
+			  RETURNING user_id, email`
+
+	var (
+		userID int
+		email  string
+	)
+	err := r.pool.QueryRow(ctx, query, tokenHash).Scan(&userID, &email)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, "", ErrTokenNotFound
+		}
+		return 0, "", err
+	}
+	return userID, email, nil
+}
+
+func (r *Repository) DeleteUserTokens(ctx context.Context, userID int) error {
+	query := `DELETE FROM email_verification_tokens WHERE user_id = $1`
+
+	_, err := r.pool.Exec(ctx, query, userID)
+	return err
+}
@@ -4,4 +4,5 @@ import "context"
 
 type RepositoryInterface interface {
 	Ping(ctx context.Context) error
+	MigrationVersion(ctx context.Context) (version uint, dirty bool, err error)
 }
@@ -2,8 +2,10 @@ package health
 
 import (
 	"context"
+	"errors"
 	"gophermart-service/internal/config"
 
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
@@ -27,3 +29,21 @@ func (r *Repository) Ping(ctx context.Context) error {
 	}
 	return nil
 }
+
+// MigrationVersion возвращает версию схемы из таблицы golang-migrate; без примененных миграций версия равна нулю
+func (r *Repository) MigrationVersion(ctx context.Context) (uint, bool, error) {
+	query := `SELECT version, dirty FROM schema_migrations LIMIT 1`
+
+	var (
+		version int64
+		dirty   bool
+	)
+	err := r.pool.QueryRow(ctx, query).Scan(&version, &dirty)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, err
+	}
+	return uint(version), dirty, nil
+}
@@ -0,0 +1,13 @@
+package identities
+
+import "errors"
+
+var (
+	ErrAuthRequestNotFound = errors.New("oidc auth request not found")
+	ErrIdentityNotFound    = errors.New("identity not found")
+	ErrLoginAlreadyExists  = errors.New("user login already exists")
+)
+
+func IsErrAuthRequestNotFound(err error) bool { return errors.Is(err, ErrAuthRequestNotFound) }
+func IsErrIdentityNotFound(err error) bool    { return errors.Is(err, ErrIdentityNotFound) }
+func IsErrLoginAlreadyExists(err error) bool  { return errors.Is(err, ErrLoginAlreadyExists) }
@@ -0,0 +1,12 @@
+package identities
+
+import "context"
+
+type RepositoryInterface interface {
+	SaveAuthRequest(ctx context.Context, authRequest *AuthRequest) error
+	ConsumeAuthRequest(ctx context.Context, state string) (*AuthRequest, error)
+	GetUserID(ctx context.Context, issuer, subject string) (int, error)
+	GetUserIdentities(ctx context.Context, userID int) ([]*Identity, error)
+	TouchLastLogin(ctx context.Context, issuer, subject string) error
+	CreateUserWithIdentity(ctx context.Context, login, passwordHash string, identity *Identity) (int, error)
+}
@@ -0,0 +1,20 @@
+package identities
+
+import "time"
+
+// AuthRequest представляет незавершенный запрос авторизации у OIDC провайдера
+type AuthRequest struct {
+	State        string
+	Nonce        string
+	CodeVerifier string
+	ExpiresAt    time.Time
+}
+
+// Identity представляет внешнюю учетную запись пользователя
+type Identity struct {
+	Issuer      string
+	Subject     string
+	Email       string
+	LastLoginAt *time.Time
+	CreatedAt   time.Time
+}
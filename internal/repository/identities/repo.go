@@ -0,0 +1,151 @@
+package identities
+
+import (
+	"context"
+	"errors"
+	"gophermart-service/internal/config"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+func NewIdentitiesRepository(logger config.LoggerInterface, pool *pgxpool.Pool) RepositoryInterface {
+	return &Repository{
+		logger: logger,
+		pool:   pool,
+	}
+}
+
+type Repository struct {
+	logger config.LoggerInterface
+	pool   *pgxpool.Pool
+}
+
+func (r *Repository) SaveAuthRequest(ctx context.Context, authRequest *AuthRequest) error {
+	query := `INSERT INTO oidc_auth_requests (state, nonce, code_verifier, expires_at) VALUES ($1, $2, $3, $4)`
+
+	_, err := r.pool.Exec(ctx, query,
+		authRequest.State,
+		authRequest.Nonce,
+		authRequest.CodeVerifier,
+		authRequest.ExpiresAt)
+	return err
+}
+
+// ConsumeAuthRequest удаляет запрос авторизации и возвращает его, если он не истек.
+// Заодно удаляются все истекшие запросы.
+func (r *Repository) ConsumeAuthRequest(ctx context.Context, state string) (*AuthRequest, error) {
+	query := `DELETE FROM oidc_auth_requests
+			  WHERE state = $1 AND expires_at > NOW()
+			  RETURNING state, nonce, code_verifier, expires_at`
+
+	authRequest := &AuthRequest{}
+	err := r.pool.QueryRow(ctx, query, state).Scan(
+		&authRequest.State,
+		&authRequest.Nonce,
+		&authRequest.CodeVerifier,
+		&authRequest.ExpiresAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrAuthRequestNotFound
+		}
+		return nil, err
+	}
+
+	if _, err = r.pool.Exec(ctx, `DELETE FROM oidc_auth_requests WHERE expires_at <= NOW()`); err != nil {
+		r.logger.Warnw("Failed to delete expired oidc auth requests", "error", err)
+	}
+
+	return authRequest, nil
+}
+
+func (r *Repository) GetUserID(ctx context.Context, issuer, subject string) (int, error) {
+	query := `SELECT user_id FROM user_identities WHERE issuer = $1 AND subject = $2`
+
+	var userID int
+	err := r.pool.QueryRow(ctx, query, issuer, subject).Scan(&userID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, ErrIdentityNotFound
+		}
+		return 0, err
+	}
+	return userID, nil
+}
+
+func (r *Repository) GetUserIdentities(ctx context.Context, userID int) ([]*Identity, error) {
+	query := `SELECT issuer, subject, COALESCE(email, ''), last_login_at, created_at
+			  FROM user_identities
+			  WHERE user_id = $1
+			  ORDER BY created_at`
+
+	rows, err := r.pool.Query(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []*Identity
+	for rows.Next() {
+		identity := &Identity{}
+		if err = rows.Scan(
+			&identity.Issuer,
+			&identity.Subject,
+			&identity.Email,
+			&identity.LastLoginAt,
+			&identity.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		result = append(result, identity)
+	}
+	return result, rows.Err()
+}
+
+func (r *Repository) TouchLastLogin(ctx context.Context, issuer, subject string) error {
+	query := `UPDATE user_identities SET last_login_at = NOW() WHERE issuer = $1 AND subject = $2`
+
+	_, err := r.pool.Exec(ctx, query, issuer, subject)
+	return err
+}
+
+// CreateUserWithIdentity создает пользователя и привязывает к нему внешнюю учетную запись в одной транзакции
+func (r *Repository) CreateUserWithIdentity(
+	ctx context.Context,
+	login, passwordHash string,
+	identity *Identity,
+) (int, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback(ctx)
+
+	var userID int
+	err = tx.QueryRow(ctx,
+		`INSERT INTO users (login, password_hash) VALUES ($1, $2) RETURNING id`,
+		login, passwordHash).Scan(&userID)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return 0, ErrLoginAlreadyExists
+		}
+		return 0, err
+	}
+
+	var email *string
+	if identity.Email != "" {
+		email = &identity.Email
+	}
+	if _, err = tx.Exec(ctx,
+		`INSERT INTO user_identities (user_id, issuer, subject, email, last_login_at) VALUES ($1, $2, $3, $4, NOW())`,
+		userID, identity.Issuer, identity.Subject, email); err != nil {
+		return 0, err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return 0, err
+	}
+	return userID, nil
+}
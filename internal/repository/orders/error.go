@@ -1 +1,5 @@
 package orders
+
+import "errors"
+
+var ErrOrderNotFound = errors.New("order not found")
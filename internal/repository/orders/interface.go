@@ -14,11 +14,17 @@ type ReaderRepositoryInterface interface {
 	CheckOrderAlreadyProcessed(ctx context.Context, userID int, orderNumber string) (bool, error)
 	GetUserOrders(ctx context.Context, userID int, limit, offset int) ([]*Order, error)
 	GetOrdersByStatus(ctx context.Context, status string, limit int) ([]*Order, error)
+	CountOrdersByStatus(ctx context.Context) (map[string]int, error)
+	GetUserOrder(ctx context.Context, userID int, orderNumber string) (*Order, error)
+	ListUserOrders(ctx context.Context, filter *ListFilter) ([]*Order, error)
+	GetOrderEvents(ctx context.Context, orderID int) ([]*OrderEvent, error)
+	GetUserEventsAfter(ctx context.Context, userID int, eventType string, afterID int64, limit int) ([]*UserOrderEvent, error)
 }
 
 type WriterRepositoryInterface interface {
 	AddNewOrder(ctx context.Context, userID int, orderNumber string) (int, error)
 	AddNewOrderWithCheck(ctx context.Context, userID int, orderNumber string) (int, error)
-	UpdateOrder(ctx context.Context, userID int, orderNumber, status string, accrual float32) error
+	UpdateOrder(ctx context.Context, userID int, orderNumber, status string, accrual float32, event *OrderEvent) error
 	UpdateOrderStatus(ctx context.Context, orderID int, status string) error
+	AddPollEvent(ctx context.Context, event *OrderEvent) (bool, error)
 }
@@ -10,3 +10,49 @@ type Order struct {
 	Accrual     float32   `json:"accrual"`
 	UploadedAt  time.Time `json:"uploaded_at"`
 }
+
+// Типы событий истории обработки заказа
+const (
+	EventUploaded      = "uploaded"
+	EventAccrualPoll   = "accrual_poll"
+	EventStatusChanged = "status_changed"
+)
+
+// OrderEvent событие истории обработки заказа
+type OrderEvent struct {
+	ID            int64
+	OrderID       int
+	EventType     string
+	Status        string
+	AccrualStatus *string
+	Accrual       *float32
+	Detail        *string
+	CreatedAt     time.Time
+}
+
+// UserOrderEvent событие заказа вместе с номером заказа, для ленты событий пользователя
+type UserOrderEvent struct {
+	OrderEvent
+	OrderNumber string
+}
+
+// Поля сортировки списка заказов
+const (
+	SortUploadedAt = "uploaded_at"
+	SortAccrual    = "accrual"
+)
+
+// ListFilter параметры выборки заказов пользователя с keyset-пагинацией
+type ListFilter struct {
+	UserID    int
+	Statuses  []string
+	From      *time.Time
+	To        *time.Time
+	SortField string
+	Desc      bool
+	// AfterValue и AfterID задают позицию последней записи предыдущей страницы
+	AfterValue any
+	AfterID    int
+	Offset     int
+	Limit      int
+}
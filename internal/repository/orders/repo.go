@@ -3,7 +3,9 @@ package orders
 import (
 	"context"
 	"errors"
+	"fmt"
 	"gophermart-service/internal/config"
+	"strings"
 
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
@@ -104,6 +106,11 @@ func (r *Repository) AddNewOrderWithCheck(ctx context.Context, userID int, order
 		return 0, err
 	}
 
+	eventQuery := `INSERT INTO order_events (order_id, event_type, status) VALUES ($1, $2, 'NEW')`
+	if _, err = tx.Exec(ctx, eventQuery, orderID, EventUploaded); err != nil {
+		return 0, err
+	}
+
 	if err = tx.Commit(ctx); err != nil {
 		return 0, err
 	}
@@ -115,7 +122,7 @@ func (r *Repository) GetUserOrders(ctx context.Context, userID int, limit, offse
 	query := `SELECT id, user_id, order_number, status, accrual, uploaded_at
 			  FROM orders
 			  WHERE user_id = $1
-			  ORDER BY uploaded_at DESC
+			  ORDER BY uploaded_at DESC, id DESC
 			  LIMIT $2 OFFSET $3`
 
 	rows, err := r.pool.Query(ctx, query, userID, limit, offset)
@@ -179,6 +186,33 @@ func (r *Repository) GetOrdersByStatus(ctx context.Context, status string, limit
 	return orders, nil
 }
 
+// CountOrdersByStatus возвращает количество заказов в каждом статусе
+func (r *Repository) CountOrdersByStatus(ctx context.Context) (map[string]int, error) {
+	query := `SELECT status, COUNT(*) FROM orders GROUP BY status`
+
+	rows, err := r.pool.Query(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	counts := make(map[string]int)
+	for rows.Next() {
+		var (
+			status string
+			count  int
+		)
+		if err := rows.Scan(&status, &count); err != nil {
+			return nil, err
+		}
+		counts[status] = count
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return counts, nil
+}
+
 func (r *Repository) GetOrCreateOrder(ctx context.Context, userID int, orderNumber string) (int, bool, error) {
 	exists, err := r.CheckUsersOrderExists(ctx, userID, orderNumber)
 	if err != nil {
@@ -201,11 +235,28 @@ func (r *Repository) GetOrCreateOrder(ctx context.Context, userID int, orderNumb
 	return orderID, false, nil
 }
 
-func (r *Repository) UpdateOrder(ctx context.Context, userID int, orderNumber, status string, accrual float32) error {
-	query := `UPDATE orders SET status = $1, accrual = $2 WHERE user_id = $3 AND order_number = $4`
+// UpdateOrder записывает финальный статус и начисление заказа вместе с событием истории в одной транзакции
+func (r *Repository) UpdateOrder(ctx context.Context, userID int, orderNumber, status string, accrual float32, event *OrderEvent) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
 
-	_, err := r.pool.Exec(ctx, query, status, accrual, userID, orderNumber)
-	return err
+	updateQuery := `UPDATE orders SET status = $1, accrual = $2 WHERE user_id = $3 AND order_number = $4 RETURNING id`
+	err = tx.QueryRow(ctx, updateQuery, status, accrual, userID, orderNumber).Scan(&event.OrderID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrOrderNotFound
+		}
+		return err
+	}
+
+	if err = insertOrderEvent(ctx, tx, event); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
 }
 
 func (r *Repository) UpdateOrderStatus(ctx context.Context, orderID int, status string) error {
@@ -214,3 +265,219 @@ func (r *Repository) UpdateOrderStatus(ctx context.Context, orderID int, status
 	_, err := r.pool.Exec(ctx, query, status, orderID)
 	return err
 }
+
+func (r *Repository) GetUserOrder(ctx context.Context, userID int, orderNumber string) (*Order, error) {
+	query := `SELECT id, user_id, order_number, status, accrual, uploaded_at
+			  FROM orders
+			  WHERE user_id = $1 AND order_number = $2`
+
+	var order Order
+	err := r.pool.QueryRow(ctx, query, userID, orderNumber).Scan(
+		&order.ID,
+		&order.UserID,
+		&order.OrderNumber,
+		&order.Status,
+		&order.Accrual,
+		&order.UploadedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrOrderNotFound
+		}
+		return nil, err
+	}
+	return &order, nil
+}
+
+func insertOrderEvent(ctx context.Context, tx pgx.Tx, event *OrderEvent) error {
+	query := `INSERT INTO order_events (order_id, event_type, status, accrual_status, accrual, detail)
+			  VALUES ($1, $2, $3, $4, $5, $6)
+			  RETURNING id, created_at`
+
+	return tx.QueryRow(ctx, query,
+		event.OrderID,
+		event.EventType,
+		event.Status,
+		event.AccrualStatus,
+		event.Accrual,
+		event.Detail,
+	).Scan(&event.ID, &event.CreatedAt)
+}
+
+// AddPollEvent записывает результат опроса системы начислений, только если он отличается
+// от предыдущего опроса этого заказа. Возвращает false, если событие не записано
+func (r *Repository) AddPollEvent(ctx context.Context, event *OrderEvent) (bool, error) {
+	query := `INSERT INTO order_events (order_id, event_type, status, accrual_status, accrual, detail)
+			  SELECT $1::INTEGER, $2::VARCHAR, $3::VARCHAR, $4::VARCHAR, $5::DECIMAL, $6::VARCHAR
+			  WHERE NOT EXISTS (
+				  SELECT 1 FROM (
+					  SELECT status, accrual_status, accrual, detail
+					  FROM order_events
+					  WHERE order_id = $1 AND event_type = $2
+					  ORDER BY id DESC
+					  LIMIT 1
+				  ) last
+				  WHERE last.status = $3
+					AND last.accrual_status IS NOT DISTINCT FROM $4
+					AND last.accrual IS NOT DISTINCT FROM $5
+					AND last.detail IS NOT DISTINCT FROM $6
+			  )
+			  RETURNING id, created_at`
+
+	err := r.pool.QueryRow(ctx, query,
+		event.OrderID,
+		EventAccrualPoll,
+		event.Status,
+		event.AccrualStatus,
+		event.Accrual,
+		event.Detail,
+	).Scan(&event.ID, &event.CreatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+// GetUserEventsAfter возвращает события заказов пользователя заданного типа с идентификатором больше afterID
+func (r *Repository) GetUserEventsAfter(ctx context.Context, userID int, eventType string, afterID int64, limit int) ([]*UserOrderEvent, error) {
+	query := `SELECT e.id, e.order_id, o.order_number, e.event_type, e.status, e.accrual_status, e.accrual, e.detail, e.created_at
+			  FROM order_events e
+			  JOIN orders o ON o.id = e.order_id
+			  WHERE o.user_id = $1 AND e.event_type = $2 AND e.id > $3
+			  ORDER BY e.id ASC
+			  LIMIT $4`
+
+	rows, err := r.pool.Query(ctx, query, userID, eventType, afterID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var events []*UserOrderEvent
+	for rows.Next() {
+		var event UserOrderEvent
+		if err := rows.Scan(
+			&event.ID,
+			&event.OrderID,
+			&event.OrderNumber,
+			&event.EventType,
+			&event.Status,
+			&event.AccrualStatus,
+			&event.Accrual,
+			&event.Detail,
+			&event.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		events = append(events, &event)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+func (r *Repository) GetOrderEvents(ctx context.Context, orderID int) ([]*OrderEvent, error) {
+	query := `SELECT id, order_id, event_type, status, accrual_status, accrual, detail, created_at
+			  FROM order_events
+			  WHERE order_id = $1
+			  ORDER BY id ASC`
+
+	rows, err := r.pool.Query(ctx, query, orderID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var events []*OrderEvent
+	for rows.Next() {
+		var event OrderEvent
+		if err := rows.Scan(
+			&event.ID,
+			&event.OrderID,
+			&event.EventType,
+			&event.Status,
+			&event.AccrualStatus,
+			&event.Accrual,
+			&event.Detail,
+			&event.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		events = append(events, &event)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+func (r *Repository) ListUserOrders(ctx context.Context, filter *ListFilter) ([]*Order, error) {
+	sortColumn := SortUploadedAt
+	if filter.SortField == SortAccrual {
+		sortColumn = SortAccrual
+	}
+	direction, comparison := "ASC", ">"
+	if filter.Desc {
+		direction, comparison = "DESC", "<"
+	}
+
+	conditions := []string{"user_id = $1"}
+	args := []any{filter.UserID}
+	addArg := func(value any) string {
+		args = append(args, value)
+		return fmt.Sprintf("$%d", len(args))
+	}
+
+	if len(filter.Statuses) > 0 {
+		conditions = append(conditions, "status = ANY("+addArg(filter.Statuses)+")")
+	}
+	if filter.From != nil {
+		conditions = append(conditions, "uploaded_at >= "+addArg(*filter.From))
+	}
+	if filter.To != nil {
+		conditions = append(conditions, "uploaded_at < "+addArg(*filter.To))
+	}
+	if filter.AfterValue != nil {
+		conditions = append(conditions, fmt.Sprintf("(%s, id) %s (%s, %s)",
+			sortColumn, comparison, addArg(filter.AfterValue), addArg(filter.AfterID)))
+	}
+
+	query := fmt.Sprintf(`SELECT id, user_id, order_number, status, accrual, uploaded_at
+			  FROM orders
+			  WHERE %s
+			  ORDER BY %s %s, id %s
+			  LIMIT %s OFFSET %s`,
+		strings.Join(conditions, " AND "),
+		sortColumn, direction, direction,
+		addArg(filter.Limit), addArg(filter.Offset))
+
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var orders []*Order
+	for rows.Next() {
+		var order Order
+		if err := rows.Scan(
+			&order.ID,
+			&order.UserID,
+			&order.OrderNumber,
+			&order.Status,
+			&order.Accrual,
+			&order.UploadedAt,
+		); err != nil {
+			return nil, err
+		}
+		orders = append(orders, &order)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return orders, nil
+}
@@ -0,0 +1,11 @@
+package passwordreset
+
+import "errors"
+
+var (
+	ErrTokenNotFound = errors.New("reset token not found, expired or already used")
+)
+
+func IsErrTokenNotFound(err error) bool {
+	return errors.Is(err, ErrTokenNotFound)
+}
@@ -0,0 +1,21 @@
+package passwordreset
+
+import (
+	"context"
+	"time"
+)
+
+type RepositoryInterface interface {
+	RepositoryReaderInterface
+	RepositoryWriterInterface
+}
+
+type RepositoryReaderInterface interface {
+	GetUserID(ctx context.Context, tokenHash string) (int, error)
+}
+
+type RepositoryWriterInterface interface {
+	Add(ctx context.Context, userID int, tokenHash string, expiresAt time.Time) error
+	Consume(ctx context.Context, tokenHash string) (int, error)
+	DeleteUserTokens(ctx context.Context, userID int) error
+}
@@ -0,0 +1,72 @@
+package passwordreset
+
+import (
+	"context"
+	"errors"
+	"gophermart-service/internal/config"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+func NewPasswordResetRepository(logger config.LoggerInterface, pool *pgxpool.Pool) RepositoryInterface {
+	return &Repository{
+		logger: logger,
+		pool:   pool,
+	}
+}
+
+type Repository struct {
+	logger config.LoggerInterface
+	pool   *pgxpool.Pool
+}
+
+func (r *Repository) Add(ctx context.Context, userID int, tokenHash string, expiresAt time.Time) error {
+	query := `INSERT INTO password_reset_tokens (user_id, token_hash, expires_at) VALUES ($1, $2, $3)`
+
+	_, err := r.pool.Exec(ctx, query, userID, tokenHash, expiresAt)
+	return err
+}
+
+// GetUserID возвращает ID владельца действующего токена без его погашения
+func (r *Repository) GetUserID(ctx context.Context, tokenHash string) (int, error) {
+	query := `SELECT user_id FROM password_reset_tokens
+			  WHERE token_hash = $1 AND used_at IS NULL AND expires_at > NOW()`
+
+	var userID int
+	err := r.pool.QueryRow(ctx, query, tokenHash).Scan(&userID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, ErrTokenNotFound
+		}
+		return 0, err
+	}
+	return userID, nil
+}
+
+// Consume атомарно помечает токен использованным и возвращает ID пользователя.
+// Просроченные и уже использованные токены не принимаются.
+func (r *Repository) Consume(ctx context.Context, tokenHash string) (int, error) {
+	query := `UPDATE password_reset_tokens
+			  SET used_at = NOW()
+			  WHERE token_hash = $1 AND used_at IS NULL AND expires_at > NOW()
+			  RETURNING user_id`
+
+	var userID int
+	err := r.pool.QueryRow(ctx, query, tokenHash).Scan(&userID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, ErrTokenNotFound
+		}
+		return 0, err
+	}
+	return userID, nil
+}
+
+func (r *Repository) DeleteUserTokens(ctx context.Context, userID int) error {
+	query := `DELETE FROM password_reset_tokens WHERE user_id = $1`
+
+	_, err := r.pool.Exec(ctx, query, userID)
+	return err
+}
@@ -0,0 +1,9 @@
+package sessions
+
+import "errors"
+
+var ErrSessionNotFound = errors.New("session not found")
+
+func IsErrSessionNotFound(err error) bool {
+	return errors.Is(err, ErrSessionNotFound)
+}
@@ -0,0 +1,13 @@
+package sessions
+
+import "context"
+
+type RepositoryInterface interface {
+	Add(ctx context.Context, session *Session) (int, error)
+	Get(ctx context.Context, userID, sessionID int) (*Session, error)
+	GetActiveUserSessions(ctx context.Context, userID int) ([]*Session, error)
+	GetUserSessions(ctx context.Context, userID int) ([]*Session, error)
+	TouchLastSeen(ctx context.Context, sessionID int) error
+	Revoke(ctx context.Context, userID, sessionID int) error
+	RevokeAllExcept(ctx context.Context, userID, sessionID int) error
+}
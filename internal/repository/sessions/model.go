@@ -0,0 +1,15 @@
+package sessions
+
+import "time"
+
+// Session представляет сессию пользователя, созданную при выдаче JWT
+type Session struct {
+	ID         int
+	UserID     int
+	UserAgent  string
+	IPAddress  string
+	CreatedAt  time.Time
+	LastSeenAt time.Time
+	ExpiresAt  time.Time
+	RevokedAt  *time.Time
+}
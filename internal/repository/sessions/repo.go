@@ -0,0 +1,141 @@
+package sessions
+
+import (
+	"context"
+	"errors"
+	"gophermart-service/internal/config"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+func NewSessionsRepository(logger config.LoggerInterface, pool *pgxpool.Pool) RepositoryInterface {
+	return &Repository{
+		logger: logger,
+		pool:   pool,
+	}
+}
+
+type Repository struct {
+	logger config.LoggerInterface
+	pool   *pgxpool.Pool
+}
+
+func (r *Repository) Add(ctx context.Context, session *Session) (int, error) {
+	query := `INSERT INTO user_sessions (user_id, user_agent, ip_address, expires_at)
+			  VALUES ($1, $2, $3, $4)
+			  RETURNING id`
+
+	var sessionID int
+	err := r.pool.QueryRow(ctx, query,
+		session.UserID,
+		session.UserAgent,
+		session.IPAddress,
+		session.ExpiresAt,
+	).Scan(&sessionID)
+	if err != nil {
+		return 0, err
+	}
+	return sessionID, nil
+}
+
+func (r *Repository) Get(ctx context.Context, userID, sessionID int) (*Session, error) {
+	query := `SELECT id, user_id, user_agent, ip_address, created_at, last_seen_at, expires_at, revoked_at
+			  FROM user_sessions
+			  WHERE id = $1 AND user_id = $2`
+
+	session := &Session{}
+	err := r.pool.QueryRow(ctx, query, sessionID, userID).Scan(
+		&session.ID,
+		&session.UserID,
+		&session.UserAgent,
+		&session.IPAddress,
+		&session.CreatedAt,
+		&session.LastSeenAt,
+		&session.ExpiresAt,
+		&session.RevokedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrSessionNotFound
+		}
+		return nil, err
+	}
+	return session, nil
+}
+
+func (r *Repository) GetActiveUserSessions(ctx context.Context, userID int) ([]*Session, error) {
+	query := `SELECT id, user_id, user_agent, ip_address, created_at, last_seen_at, expires_at, revoked_at
+			  FROM user_sessions
+			  WHERE user_id = $1 AND revoked_at IS NULL AND expires_at > NOW()
+			  ORDER BY last_seen_at DESC`
+
+	return r.querySessions(ctx, query, userID)
+}
+
+// GetUserSessions возвращает все сессии пользователя, включая завершенные
+func (r *Repository) GetUserSessions(ctx context.Context, userID int) ([]*Session, error) {
+	query := `SELECT id, user_id, user_agent, ip_address, created_at, last_seen_at, expires_at, revoked_at
+			  FROM user_sessions
+			  WHERE user_id = $1
+			  ORDER BY created_at DESC`
+
+	return r.querySessions(ctx, query, userID)
+}
+
+func (r *Repository) querySessions(ctx context.Context, query string, args ...interface{}) ([]*Session, error) {
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []*Session
+	for rows.Next() {
+		session := &Session{}
+		if err = rows.Scan(
+			&session.ID,
+			&session.UserID,
+			&session.UserAgent,
+			&session.IPAddress,
+			&session.CreatedAt,
+			&session.LastSeenAt,
+			&session.ExpiresAt,
+			&session.RevokedAt,
+		); err != nil {
+			return nil, err
+		}
+		result = append(result, session)
+	}
+	return result, rows.Err()
+}
+
+func (r *Repository) TouchLastSeen(ctx context.Context, sessionID int) error {
+	query := `UPDATE user_sessions SET last_seen_at = NOW() WHERE id = $1`
+
+	_, err := r.pool.Exec(ctx, query, sessionID)
+	return err
+}
+
+func (r *Repository) Revoke(ctx context.Context, userID, sessionID int) error {
+	query := `UPDATE user_sessions SET revoked_at = NOW()
+			  WHERE id = $1 AND user_id = $2 AND revoked_at IS NULL AND expires_at > NOW()`
+
+	tag, err := r.pool.Exec(ctx, query, sessionID, userID)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrSessionNotFound
+	}
+	return nil
+}
+
+// RevokeAllExcept завершает все активные сессии пользователя, кроме указанной
+func (r *Repository) RevokeAllExcept(ctx context.Context, userID, sessionID int) error {
+	query := `UPDATE user_sessions SET revoked_at = NOW()
+			  WHERE user_id = $1 AND id <> $2 AND revoked_at IS NULL`
+
+	_, err := r.pool.Exec(ctx, query, userID, sessionID)
+	return err
+}
@@ -0,0 +1,15 @@
+package twofactor
+
+import "errors"
+
+var (
+	ErrTOTPNotFound         = errors.New("totp is not configured for user")
+	ErrRecoveryCodeNotFound = errors.New("recovery code not found or already used")
+	ErrStepAlreadyUsed      = errors.New("totp code already used")
+	ErrChallengeAlreadyUsed = errors.New("two-factor challenge already used")
+)
+
+func IsErrTOTPNotFound(err error) bool         { return errors.Is(err, ErrTOTPNotFound) }
+func IsErrRecoveryCodeNotFound(err error) bool { return errors.Is(err, ErrRecoveryCodeNotFound) }
+func IsErrStepAlreadyUsed(err error) bool      { return errors.Is(err, ErrStepAlreadyUsed) }
+func IsErrChallengeAlreadyUsed(err error) bool { return errors.Is(err, ErrChallengeAlreadyUsed) }
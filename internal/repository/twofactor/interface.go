@@ -0,0 +1,26 @@
+package twofactor
+
+import (
+	"context"
+	"time"
+)
+
+type RepositoryInterface interface {
+	RepositoryReaderInterface
+	RepositoryWriterInterface
+}
+
+type RepositoryReaderInterface interface {
+	GetTOTP(ctx context.Context, userID int) (*TOTP, error)
+}
+
+type RepositoryWriterInterface interface {
+	SaveSecret(ctx context.Context, userID int, secret string) error
+	Enable(ctx context.Context, userID int, recoveryCodeHashes []string) error
+	Delete(ctx context.Context, userID int) error
+	MarkStepUsed(ctx context.Context, userID int, step int64) error
+	UseRecoveryCode(ctx context.Context, userID int, codeHash string) error
+	RegisterFailure(ctx context.Context, userID, maxAttempts int, lockUntil time.Time) (bool, error)
+	ResetFailures(ctx context.Context, userID int) error
+	ConsumeChallenge(ctx context.Context, challengeID string, userID int, expiresAt time.Time) error
+}
@@ -0,0 +1,15 @@
+package twofactor
+
+import "time"
+
+type TOTP struct {
+	UserID       int    `json:"user_id"`
+	Secret       string `json:"-"`
+	Enabled      bool   `json:"enabled"`
+	LastUsedStep *int64 `json:"-"`
+	// FailedAttempts число неверных кодов подряд, LockedUntil — окончание блокировки проверки кодов
+	FailedAttempts int        `json:"-"`
+	LockedUntil    *time.Time `json:"-"`
+	ConfirmedAt    *time.Time `json:"confirmed_at"`
+	CreatedAt      time.Time  `json:"created_at"`
+}
@@ -0,0 +1,187 @@
+package twofactor
+
+import (
+	"context"
+	"errors"
+	"gophermart-service/internal/config"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+func NewTwoFactorRepository(logger config.LoggerInterface, pool *pgxpool.Pool) RepositoryInterface {
+	return &Repository{
+		logger: logger,
+		pool:   pool,
+	}
+}
+
+type Repository struct {
+	logger config.LoggerInterface
+	pool   *pgxpool.Pool
+}
+
+func (r *Repository) GetTOTP(ctx context.Context, userID int) (*TOTP, error) {
+	query := `SELECT user_id, secret, enabled, last_used_step, failed_attempts, locked_until, confirmed_at, created_at
+			  FROM user_totp
+			  WHERE user_id = $1`
+
+	var totp TOTP
+	err := r.pool.QueryRow(ctx, query, userID).Scan(
+		&totp.UserID,
+		&totp.Secret,
+		&totp.Enabled,
+		&totp.LastUsedStep,
+		&totp.FailedAttempts,
+		&totp.LockedUntil,
+		&totp.ConfirmedAt,
+		&totp.CreatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrTOTPNotFound
+		}
+		return nil, err
+	}
+	return &totp, nil
+}
+
+// SaveSecret сохраняет новый неподтвержденный секрет (повторная регистрация заменяет прежний)
+func (r *Repository) SaveSecret(ctx context.Context, userID int, secret string) error {
+	query := `INSERT INTO user_totp (user_id, secret) VALUES ($1, $2)
+			  ON CONFLICT (user_id) DO UPDATE
+			  SET secret = EXCLUDED.secret, enabled = FALSE, last_used_step = NULL,
+			      failed_attempts = 0, locked_until = NULL, confirmed_at = NULL, created_at = NOW()`
+
+	_, err := r.pool.Exec(ctx, query, userID, secret)
+	return err
+}
+
+// Enable включает 2FA и заменяет коды восстановления в одной транзакции
+func (r *Repository) Enable(ctx context.Context, userID int, recoveryCodeHashes []string) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	tag, err := tx.Exec(ctx,
+		`UPDATE user_totp SET enabled = TRUE, confirmed_at = NOW() WHERE user_id = $1`,
+		userID)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrTOTPNotFound
+	}
+
+	if _, err = tx.Exec(ctx, `DELETE FROM user_recovery_codes WHERE user_id = $1`, userID); err != nil {
+		return err
+	}
+
+	for _, codeHash := range recoveryCodeHashes {
+		if _, err = tx.Exec(ctx,
+			`INSERT INTO user_recovery_codes (user_id, code_hash) VALUES ($1, $2)`,
+			userID, codeHash); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit(ctx)
+}
+
+// Delete отключает 2FA и удаляет коды восстановления
+func (r *Repository) Delete(ctx context.Context, userID int) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err = tx.Exec(ctx, `DELETE FROM user_recovery_codes WHERE user_id = $1`, userID); err != nil {
+		return err
+	}
+	if _, err = tx.Exec(ctx, `DELETE FROM user_totp WHERE user_id = $1`, userID); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+// MarkStepUsed запоминает временной шаг принятого кода, защищая от его повторного использования
+func (r *Repository) MarkStepUsed(ctx context.Context, userID int, step int64) error {
+	query := `UPDATE user_totp SET last_used_step = $2
+			  WHERE user_id = $1 AND (last_used_step IS NULL OR last_used_step < $2)`
+
+	tag, err := r.pool.Exec(ctx, query, userID, step)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrStepAlreadyUsed
+	}
+	return nil
+}
+
+func (r *Repository) UseRecoveryCode(ctx context.Context, userID int, codeHash string) error {
+	query := `UPDATE user_recovery_codes SET used_at = NOW()
+			  WHERE user_id = $1 AND code_hash = $2 AND used_at IS NULL`
+
+	tag, err := r.pool.Exec(ctx, query, userID, codeHash)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrRecoveryCodeNotFound
+	}
+	return nil
+}
+
+// RegisterFailure учитывает неверный код. Когда число неверных кодов подряд достигает maxAttempts,
+// проверка блокируется до lockUntil, а счетчик сбрасывается. Сообщает, установлена ли блокировка.
+func (r *Repository) RegisterFailure(ctx context.Context, userID, maxAttempts int, lockUntil time.Time) (bool, error) {
+	query := `UPDATE user_totp
+			  SET failed_attempts = CASE WHEN failed_attempts + 1 >= $2 THEN 0 ELSE failed_attempts + 1 END,
+			      locked_until = CASE WHEN failed_attempts + 1 >= $2 THEN $3 ELSE locked_until END
+			  WHERE user_id = $1
+			  RETURNING failed_attempts = 0`
+
+	var locked bool
+	err := r.pool.QueryRow(ctx, query, userID, maxAttempts, lockUntil).Scan(&locked)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return false, ErrTOTPNotFound
+		}
+		return false, err
+	}
+	return locked, nil
+}
+
+// ResetFailures сбрасывает счетчик неверных кодов после успешной проверки
+func (r *Repository) ResetFailures(ctx context.Context, userID int) error {
+	query := `UPDATE user_totp SET failed_attempts = 0, locked_until = NULL WHERE user_id = $1`
+
+	_, err := r.pool.Exec(ctx, query, userID)
+	return err
+}
+
+// ConsumeChallenge отмечает промежуточный токен 2FA использованным. Записи об истекших токенах
+// больше не нужны: такие токены отклоняются проверкой срока действия.
+func (r *Repository) ConsumeChallenge(ctx context.Context, challengeID string, userID int, expiresAt time.Time) error {
+	if _, err := r.pool.Exec(ctx, `DELETE FROM used_two_factor_challenges WHERE expires_at < NOW()`); err != nil {
+		return err
+	}
+
+	query := `INSERT INTO used_two_factor_challenges (jti, user_id, expires_at) VALUES ($1, $2, $3)
+			  ON CONFLICT (jti) DO NOTHING`
+
+	tag, err := r.pool.Exec(ctx, query, challengeID, userID, expiresAt)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrChallengeAlreadyUsed
+	}
+	return nil
+}
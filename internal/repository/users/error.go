@@ -5,6 +5,7 @@ import "errors"
 var (
 	ErrUserLoginAlreadyExists = errors.New("user login already exists")
 	ErrUserNotFound           = errors.New("user not found")
+	ErrEmailAlreadyVerified   = errors.New("email is already verified by another user")
 )
 
 func IsErrUserLoginAlreadyExists(err error) bool {
@@ -14,3 +15,7 @@ func IsErrUserLoginAlreadyExists(err error) bool {
 func IsErrUserNotFound(err error) bool {
 	return errors.Is(err, ErrUserNotFound)
 }
+
+func IsErrEmailAlreadyVerified(err error) bool {
+	return errors.Is(err, ErrEmailAlreadyVerified)
+}
@@ -4,10 +4,28 @@ package users
 import "context"
 
 type RepositoryInterface interface {
+	ReaderRepositoryInterface
 	WriterRepositoryInterface
 }
 
+type ReaderRepositoryInterface interface {
+	GetUserIDByLogin(ctx context.Context, login string) (int, error)
+	GetLoginByID(ctx context.Context, userID int) (string, error)
+	GetUserHashPasswordByID(ctx context.Context, userID int) (string, error)
+	GetTokenVersion(ctx context.Context, userID int) (int, error)
+	GetRole(ctx context.Context, userID int) (string, error)
+	GetUsers(ctx context.Context, limit, offset int) ([]*User, error)
+	GetUser(ctx context.Context, userID int) (*User, error)
+	GetProfile(ctx context.Context, userID int) (*Profile, error)
+}
+
 type WriterRepositoryInterface interface {
 	Add(ctx context.Context, login, passwordHash string) (int, error)
 	GetUserHashPassword(ctx context.Context, login string) (int, string, error)
+	UpdatePassword(ctx context.Context, userID int, passwordHash string) (int, error)
+	UpdatePasswordHash(ctx context.Context, userID int, oldHash, newHash string) (bool, error)
+	UpdateRole(ctx context.Context, userID int, role string) error
+	Anonymize(ctx context.Context, userID int, login, passwordHash string) error
+	UpdateProfile(ctx context.Context, profile *Profile) error
+	MarkEmailVerified(ctx context.Context, userID int, email string) error
 }
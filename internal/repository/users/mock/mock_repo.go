@@ -6,6 +6,7 @@ package mock
 
 import (
 	context "context"
+	users "gophermart-service/internal/repository/users"
 	reflect "reflect"
 
 	gomock "github.com/golang/mock/gomock"
@@ -35,10 +36,10 @@ func (m *MockRepositoryInterface) EXPECT() *MockRepositoryInterfaceMockRecorder
 }
 
 // Add mocks base method.
-func (m *MockRepositoryInterface) Add(ctx context.Context, login, passwordHash string) (int64, error) {
+func (m *MockRepositoryInterface) Add(ctx context.Context, login, passwordHash string) (int, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "Add", ctx, login, passwordHash)
-	ret0, _ := ret[0].(int64)
+	ret0, _ := ret[0].(int)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -49,6 +50,371 @@ func (mr *MockRepositoryInterfaceMockRecorder) Add(ctx, login, passwordHash inte
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Add", reflect.TypeOf((*MockRepositoryInterface)(nil).Add), ctx, login, passwordHash)
 }
 
+// Anonymize mocks base method.
+func (m *MockRepositoryInterface) Anonymize(ctx context.Context, userID int, login, passwordHash string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Anonymize", ctx, userID, login, passwordHash)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Anonymize indicates an expected call of Anonymize.
+func (mr *MockRepositoryInterfaceMockRecorder) Anonymize(ctx, userID, login, passwordHash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Anonymize", reflect.TypeOf((*MockRepositoryInterface)(nil).Anonymize), ctx, userID, login, passwordHash)
+}
+
+// GetLoginByID mocks base method.
+func (m *MockRepositoryInterface) GetLoginByID(ctx context.Context, userID int) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLoginByID", ctx, userID)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLoginByID indicates an expected call of GetLoginByID.
+func (mr *MockRepositoryInterfaceMockRecorder) GetLoginByID(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLoginByID", reflect.TypeOf((*MockRepositoryInterface)(nil).GetLoginByID), ctx, userID)
+}
+
+// GetProfile mocks base method.
+func (m *MockRepositoryInterface) GetProfile(ctx context.Context, userID int) (*users.Profile, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProfile", ctx, userID)
+	ret0, _ := ret[0].(*users.Profile)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProfile indicates an expected call of GetProfile.
+func (mr *MockRepositoryInterfaceMockRecorder) GetProfile(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProfile", reflect.TypeOf((*MockRepositoryInterface)(nil).GetProfile), ctx, userID)
+}
+
+// GetRole mocks base method.
+func (m *MockRepositoryInterface) GetRole(ctx context.Context, userID int) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRole", ctx, userID)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRole indicates an expected call of GetRole.
+func (mr *MockRepositoryInterfaceMockRecorder) GetRole(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRole", reflect.TypeOf((*MockRepositoryInterface)(nil).GetRole), ctx, userID)
+}
+
+// GetTokenVersion mocks base method.
+func (m *MockRepositoryInterface) GetTokenVersion(ctx context.Context, userID int) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTokenVersion", ctx, userID)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTokenVersion indicates an expected call of GetTokenVersion.
+func (mr *MockRepositoryInterfaceMockRecorder) GetTokenVersion(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTokenVersion", reflect.TypeOf((*MockRepositoryInterface)(nil).GetTokenVersion), ctx, userID)
+}
+
+// GetUser mocks base method.
+func (m *MockRepositoryInterface) GetUser(ctx context.Context, userID int) (*users.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUser", ctx, userID)
+	ret0, _ := ret[0].(*users.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUser indicates an expected call of GetUser.
+func (mr *MockRepositoryInterfaceMockRecorder) GetUser(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUser", reflect.TypeOf((*MockRepositoryInterface)(nil).GetUser), ctx, userID)
+}
+
+// GetUserHashPassword mocks base method.
+func (m *MockRepositoryInterface) GetUserHashPassword(ctx context.Context, login string) (int, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserHashPassword", ctx, login)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetUserHashPassword indicates an expected call of GetUserHashPassword.
+func (mr *MockRepositoryInterfaceMockRecorder) GetUserHashPassword(ctx, login interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserHashPassword", reflect.TypeOf((*MockRepositoryInterface)(nil).GetUserHashPassword), ctx, login)
+}
+
+// GetUserHashPasswordByID mocks base method.
+func (m *MockRepositoryInterface) GetUserHashPasswordByID(ctx context.Context, userID int) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserHashPasswordByID", ctx, userID)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserHashPasswordByID indicates an expected call of GetUserHashPasswordByID.
+func (mr *MockRepositoryInterfaceMockRecorder) GetUserHashPasswordByID(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserHashPasswordByID", reflect.TypeOf((*MockRepositoryInterface)(nil).GetUserHashPasswordByID), ctx, userID)
+}
+
+// GetUserIDByLogin mocks base method.
+func (m *MockRepositoryInterface) GetUserIDByLogin(ctx context.Context, login string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserIDByLogin", ctx, login)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserIDByLogin indicates an expected call of GetUserIDByLogin.
+func (mr *MockRepositoryInterfaceMockRecorder) GetUserIDByLogin(ctx, login interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserIDByLogin", reflect.TypeOf((*MockRepositoryInterface)(nil).GetUserIDByLogin), ctx, login)
+}
+
+// GetUsers mocks base method.
+func (m *MockRepositoryInterface) GetUsers(ctx context.Context, limit, offset int) ([]*users.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUsers", ctx, limit, offset)
+	ret0, _ := ret[0].([]*users.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUsers indicates an expected call of GetUsers.
+func (mr *MockRepositoryInterfaceMockRecorder) GetUsers(ctx, limit, offset interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUsers", reflect.TypeOf((*MockRepositoryInterface)(nil).GetUsers), ctx, limit, offset)
+}
+
+// MarkEmailVerified mocks base method.
+func (m *MockRepositoryInterface) MarkEmailVerified(ctx context.Context, userID int, email string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkEmailVerified", ctx, userID, email)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkEmailVerified indicates an expected call of MarkEmailVerified.
+func (mr *MockRepositoryInterfaceMockRecorder) MarkEmailVerified(ctx, userID, email interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkEmailVerified", reflect.TypeOf((*MockRepositoryInterface)(nil).MarkEmailVerified), ctx, userID, email)
+}
+
+// UpdatePassword mocks base method.
+func (m *MockRepositoryInterface) UpdatePassword(ctx context.Context, userID int, passwordHash string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePassword", ctx, userID, passwordHash)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdatePassword indicates an expected call of UpdatePassword.
+func (mr *MockRepositoryInterfaceMockRecorder) UpdatePassword(ctx, userID, passwordHash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePassword", reflect.TypeOf((*MockRepositoryInterface)(nil).UpdatePassword), ctx, userID, passwordHash)
+}
+
+// UpdatePasswordHash mocks base method.
+func (m *MockRepositoryInterface) UpdatePasswordHash(ctx context.Context, userID int, oldHash, newHash string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePasswordHash", ctx, userID, oldHash, newHash)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdatePasswordHash indicates an expected call of UpdatePasswordHash.
+func (mr *MockRepositoryInterfaceMockRecorder) UpdatePasswordHash(ctx, userID, oldHash, newHash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePasswordHash", reflect.TypeOf((*MockRepositoryInterface)(nil).UpdatePasswordHash), ctx, userID, oldHash, newHash)
+}
+
+// UpdateProfile mocks base method.
+func (m *MockRepositoryInterface) UpdateProfile(ctx context.Context, profile *users.Profile) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateProfile", ctx, profile)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateProfile indicates an expected call of UpdateProfile.
+func (mr *MockRepositoryInterfaceMockRecorder) UpdateProfile(ctx, profile interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateProfile", reflect.TypeOf((*MockRepositoryInterface)(nil).UpdateProfile), ctx, profile)
+}
+
+// UpdateRole mocks base method.
+func (m *MockRepositoryInterface) UpdateRole(ctx context.Context, userID int, role string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateRole", ctx, userID, role)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateRole indicates an expected call of UpdateRole.
+func (mr *MockRepositoryInterfaceMockRecorder) UpdateRole(ctx, userID, role interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateRole", reflect.TypeOf((*MockRepositoryInterface)(nil).UpdateRole), ctx, userID, role)
+}
+
+// MockReaderRepositoryInterface is a mock of ReaderRepositoryInterface interface.
+type MockReaderRepositoryInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockReaderRepositoryInterfaceMockRecorder
+}
+
+// MockReaderRepositoryInterfaceMockRecorder is the mock recorder for MockReaderRepositoryInterface.
+type MockReaderRepositoryInterfaceMockRecorder struct {
+	mock *MockReaderRepositoryInterface
+}
+
+// NewMockReaderRepositoryInterface creates a new mock instance.
+func NewMockReaderRepositoryInterface(ctrl *gomock.Controller) *MockReaderRepositoryInterface {
+	mock := &MockReaderRepositoryInterface{ctrl: ctrl}
+	mock.recorder = &MockReaderRepositoryInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReaderRepositoryInterface) EXPECT() *MockReaderRepositoryInterfaceMockRecorder {
+	return m.recorder
+}
+
+// GetLoginByID mocks base method.
+func (m *MockReaderRepositoryInterface) GetLoginByID(ctx context.Context, userID int) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLoginByID", ctx, userID)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLoginByID indicates an expected call of GetLoginByID.
+func (mr *MockReaderRepositoryInterfaceMockRecorder) GetLoginByID(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLoginByID", reflect.TypeOf((*MockReaderRepositoryInterface)(nil).GetLoginByID), ctx, userID)
+}
+
+// GetProfile mocks base method.
+func (m *MockReaderRepositoryInterface) GetProfile(ctx context.Context, userID int) (*users.Profile, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProfile", ctx, userID)
+	ret0, _ := ret[0].(*users.Profile)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProfile indicates an expected call of GetProfile.
+func (mr *MockReaderRepositoryInterfaceMockRecorder) GetProfile(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProfile", reflect.TypeOf((*MockReaderRepositoryInterface)(nil).GetProfile), ctx, userID)
+}
+
+// GetRole mocks base method.
+func (m *MockReaderRepositoryInterface) GetRole(ctx context.Context, userID int) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRole", ctx, userID)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRole indicates an expected call of GetRole.
+func (mr *MockReaderRepositoryInterfaceMockRecorder) GetRole(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRole", reflect.TypeOf((*MockReaderRepositoryInterface)(nil).GetRole), ctx, userID)
+}
+
+// GetTokenVersion mocks base method.
+func (m *MockReaderRepositoryInterface) GetTokenVersion(ctx context.Context, userID int) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTokenVersion", ctx, userID)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTokenVersion indicates an expected call of GetTokenVersion.
+func (mr *MockReaderRepositoryInterfaceMockRecorder) GetTokenVersion(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTokenVersion", reflect.TypeOf((*MockReaderRepositoryInterface)(nil).GetTokenVersion), ctx, userID)
+}
+
+// GetUser mocks base method.
+func (m *MockReaderRepositoryInterface) GetUser(ctx context.Context, userID int) (*users.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUser", ctx, userID)
+	ret0, _ := ret[0].(*users.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUser indicates an expected call of GetUser.
+func (mr *MockReaderRepositoryInterfaceMockRecorder) GetUser(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUser", reflect.TypeOf((*MockReaderRepositoryInterface)(nil).GetUser), ctx, userID)
+}
+
+// GetUserHashPasswordByID mocks base method.
+func (m *MockReaderRepositoryInterface) GetUserHashPasswordByID(ctx context.Context, userID int) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserHashPasswordByID", ctx, userID)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserHashPasswordByID indicates an expected call of GetUserHashPasswordByID.
+func (mr *MockReaderRepositoryInterfaceMockRecorder) GetUserHashPasswordByID(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserHashPasswordByID", reflect.TypeOf((*MockReaderRepositoryInterface)(nil).GetUserHashPasswordByID), ctx, userID)
+}
+
+// GetUserIDByLogin mocks base method.
+func (m *MockReaderRepositoryInterface) GetUserIDByLogin(ctx context.Context, login string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserIDByLogin", ctx, login)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserIDByLogin indicates an expected call of GetUserIDByLogin.
+func (mr *MockReaderRepositoryInterfaceMockRecorder) GetUserIDByLogin(ctx, login interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserIDByLogin", reflect.TypeOf((*MockReaderRepositoryInterface)(nil).GetUserIDByLogin), ctx, login)
+}
+
+// GetUsers mocks base method.
+func (m *MockReaderRepositoryInterface) GetUsers(ctx context.Context, limit, offset int) ([]*users.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUsers", ctx, limit, offset)
+	ret0, _ := ret[0].([]*users.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUsers indicates an expected call of GetUsers.
+func (mr *MockReaderRepositoryInterfaceMockRecorder) GetUsers(ctx, limit, offset interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUsers", reflect.TypeOf((*MockReaderRepositoryInterface)(nil).GetUsers), ctx, limit, offset)
+}
+
 // MockWriterRepositoryInterface is a mock of WriterRepositoryInterface interface.
 type MockWriterRepositoryInterface struct {
 	ctrl     *gomock.Controller
@@ -73,10 +439,10 @@ func (m *MockWriterRepositoryInterface) EXPECT() *MockWriterRepositoryInterfaceM
 }
 
 // Add mocks base method.
-func (m *MockWriterRepositoryInterface) Add(ctx context.Context, login, passwordHash string) (int64, error) {
+func (m *MockWriterRepositoryInterface) Add(ctx context.Context, login, passwordHash string) (int, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "Add", ctx, login, passwordHash)
-	ret0, _ := ret[0].(int64)
+	ret0, _ := ret[0].(int)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -86,3 +452,105 @@ func (mr *MockWriterRepositoryInterfaceMockRecorder) Add(ctx, login, passwordHas
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Add", reflect.TypeOf((*MockWriterRepositoryInterface)(nil).Add), ctx, login, passwordHash)
 }
+
+// Anonymize mocks base method.
+func (m *MockWriterRepositoryInterface) Anonymize(ctx context.Context, userID int, login, passwordHash string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Anonymize", ctx, userID, login, passwordHash)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Anonymize indicates an expected call of Anonymize.
+func (mr *MockWriterRepositoryInterfaceMockRecorder) Anonymize(ctx, userID, login, passwordHash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Anonymize", reflect.TypeOf((*MockWriterRepositoryInterface)(nil).Anonymize), ctx, userID, login, passwordHash)
+}
+
+// GetUserHashPassword mocks base method.
+func (m *MockWriterRepositoryInterface) GetUserHashPassword(ctx context.Context, login string) (int, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserHashPassword", ctx, login)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetUserHashPassword indicates an expected call of GetUserHashPassword.
+func (mr *MockWriterRepositoryInterfaceMockRecorder) GetUserHashPassword(ctx, login interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserHashPassword", reflect.TypeOf((*MockWriterRepositoryInterface)(nil).GetUserHashPassword), ctx, login)
+}
+
+// MarkEmailVerified mocks base method.
+func (m *MockWriterRepositoryInterface) MarkEmailVerified(ctx context.Context, userID int, email string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkEmailVerified", ctx, userID, email)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkEmailVerified indicates an expected call of MarkEmailVerified.
+func (mr *MockWriterRepositoryInterfaceMockRecorder) MarkEmailVerified(ctx, userID, email interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkEmailVerified", reflect.TypeOf((*MockWriterRepositoryInterface)(nil).MarkEmailVerified), ctx, userID, email)
+}
+
+// UpdatePassword mocks base method.
+func (m *MockWriterRepositoryInterface) UpdatePassword(ctx context.Context, userID int, passwordHash string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePassword", ctx, userID, passwordHash)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdatePassword indicates an expected call of UpdatePassword.
+func (mr *MockWriterRepositoryInterfaceMockRecorder) UpdatePassword(ctx, userID, passwordHash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePassword", reflect.TypeOf((*MockWriterRepositoryInterface)(nil).UpdatePassword), ctx, userID, passwordHash)
+}
+
+// UpdatePasswordHash mocks base method.
+func (m *MockWriterRepositoryInterface) UpdatePasswordHash(ctx context.Context, userID int, oldHash, newHash string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePasswordHash", ctx, userID, oldHash, newHash)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdatePasswordHash indicates an expected call of UpdatePasswordHash.
+func (mr *MockWriterRepositoryInterfaceMockRecorder) UpdatePasswordHash(ctx, userID, oldHash, newHash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePasswordHash", reflect.TypeOf((*MockWriterRepositoryInterface)(nil).UpdatePasswordHash), ctx, userID, oldHash, newHash)
+}
+
+// UpdateProfile mocks base method.
+func (m *MockWriterRepositoryInterface) UpdateProfile(ctx context.Context, profile *users.Profile) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateProfile", ctx, profile)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateProfile indicates an expected call of UpdateProfile.
+func (mr *MockWriterRepositoryInterfaceMockRecorder) UpdateProfile(ctx, profile interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateProfile", reflect.TypeOf((*MockWriterRepositoryInterface)(nil).UpdateProfile), ctx, profile)
+}
+
+// UpdateRole mocks base method.
+func (m *MockWriterRepositoryInterface) UpdateRole(ctx context.Context, userID int, role string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateRole", ctx, userID, role)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateRole indicates an expected call of UpdateRole.
+func (mr *MockWriterRepositoryInterfaceMockRecorder) UpdateRole(ctx, userID, role interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateRole", reflect.TypeOf((*MockWriterRepositoryInterface)(nil).UpdateRole), ctx, userID, role)
+}
@@ -0,0 +1,24 @@
+package users
+
+import "time"
+
+// User представляет учетную запись пользователя без секретных данных
+type User struct {
+	ID        int
+	Login     string
+	Role      string
+	CreatedAt time.Time
+	DeletedAt *time.Time
+}
+
+// Profile представляет необязательные данные профиля пользователя
+type Profile struct {
+	UserID             int
+	Login              string
+	DisplayName        *string
+	Email              *string
+	EmailVerifiedAt    *time.Time
+	Phone              *string
+	MarketingConsent   bool
+	MarketingConsentAt *time.Time
+}
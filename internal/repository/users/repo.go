@@ -41,7 +41,7 @@ func (r *Repository) Add(ctx context.Context, username, passwordHash string) (in
 }
 
 func (r *Repository) GetUserHashPassword(ctx context.Context, login string) (int, string, error) {
-	query := `SELECT id, password_hash FROM users WHERE login = $1`
+	query := `SELECT id, password_hash FROM users WHERE login = $1 AND deleted_at IS NULL`
 
 	var (
 		userID       int
@@ -56,3 +56,275 @@ func (r *Repository) GetUserHashPassword(ctx context.Context, login string) (int
 	}
 	return userID, passwordHash, nil
 }
+
+func (r *Repository) GetUserIDByLogin(ctx context.Context, login string) (int, error) {
+	query := `SELECT id FROM users WHERE login = $1 AND deleted_at IS NULL`
+
+	var userID int
+	err := r.pool.QueryRow(ctx, query, login).Scan(&userID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, ErrUserNotFound
+		}
+		return 0, err
+	}
+	return userID, nil
+}
+
+func (r *Repository) GetLoginByID(ctx context.Context, userID int) (string, error) {
+	query := `SELECT login FROM users WHERE id = $1`
+
+	var login string
+	err := r.pool.QueryRow(ctx, query, userID).Scan(&login)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", ErrUserNotFound
+		}
+		return "", err
+	}
+	return login, nil
+}
+
+func (r *Repository) GetUserHashPasswordByID(ctx context.Context, userID int) (string, error) {
+	query := `SELECT password_hash FROM users WHERE id = $1`
+
+	var passwordHash string
+	err := r.pool.QueryRow(ctx, query, userID).Scan(&passwordHash)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", ErrUserNotFound
+		}
+		return "", err
+	}
+	return passwordHash, nil
+}
+
+func (r *Repository) GetTokenVersion(ctx context.Context, userID int) (int, error) {
+	query := `SELECT token_version FROM users WHERE id = $1`
+
+	var tokenVersion int
+	err := r.pool.QueryRow(ctx, query, userID).Scan(&tokenVersion)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, ErrUserNotFound
+		}
+		return 0, err
+	}
+	return tokenVersion, nil
+}
+
+// UpdatePassword сохраняет новый хеш пароля и увеличивает версию токенов,
+// тем самым отзывая все ранее выданные пользователю JWT
+func (r *Repository) UpdatePassword(ctx context.Context, userID int, passwordHash string) (int, error) {
+	query := `UPDATE users
+			  SET password_hash = $1, token_version = token_version + 1
+			  WHERE id = $2
+			  RETURNING token_version`
+
+	var tokenVersion int
+	err := r.pool.QueryRow(ctx, query, passwordHash, userID).Scan(&tokenVersion)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, ErrUserNotFound
+		}
+		return 0, err
+	}
+	return tokenVersion, nil
+}
+
+// UpdatePasswordHash заменяет хеш пароля без отзыва токенов (пересчет хеша при входе).
+// Хеш заменяется, только если он все еще равен oldHash, чтобы пересчет не затер пароль,
+// смененный параллельным запросом. Возвращает false, если хеш уже изменился
+func (r *Repository) UpdatePasswordHash(ctx context.Context, userID int, oldHash, newHash string) (bool, error) {
+	query := `UPDATE users SET password_hash = $1 WHERE id = $2 AND password_hash = $3`
+
+	tag, err := r.pool.Exec(ctx, query, newHash, userID, oldHash)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() > 0, nil
+}
+
+func (r *Repository) GetRole(ctx context.Context, userID int) (string, error) {
+	query := `SELECT role FROM users WHERE id = $1`
+
+	var role string
+	err := r.pool.QueryRow(ctx, query, userID).Scan(&role)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", ErrUserNotFound
+		}
+		return "", err
+	}
+	return role, nil
+}
+
+func (r *Repository) GetUsers(ctx context.Context, limit, offset int) ([]*User, error) {
+	query := `SELECT id, login, role, created_at, deleted_at FROM users ORDER BY id LIMIT $1 OFFSET $2`
+
+	rows, err := r.pool.Query(ctx, query, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []*User
+	for rows.Next() {
+		user := &User{}
+		if err = rows.Scan(&user.ID, &user.Login, &user.Role, &user.CreatedAt, &user.DeletedAt); err != nil {
+			return nil, err
+		}
+		result = append(result, user)
+	}
+	return result, rows.Err()
+}
+
+// UpdateRole меняет роль пользователя и увеличивает версию токенов,
+// чтобы выданные ранее JWT не сохраняли прежнюю роль
+func (r *Repository) UpdateRole(ctx context.Context, userID int, role string) error {
+	query := `UPDATE users
+			  SET role = $1, token_version = token_version + 1, updated_at = NOW()
+			  WHERE id = $2`
+
+	tag, err := r.pool.Exec(ctx, query, role, userID)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrUserNotFound
+	}
+	return nil
+}
+
+func (r *Repository) GetUser(ctx context.Context, userID int) (*User, error) {
+	query := `SELECT id, login, role, created_at, deleted_at FROM users WHERE id = $1`
+
+	user := &User{}
+	err := r.pool.QueryRow(ctx, query, userID).Scan(&user.ID, &user.Login, &user.Role, &user.CreatedAt, &user.DeletedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrUserNotFound
+		}
+		return nil, err
+	}
+	return user, nil
+}
+
+// Anonymize обезличивает учетную запись: заменяет логин и хеш пароля, отзывает токены
+// и удаляет персональные данные из связанных таблиц. Заказы и списания сохраняются для учета.
+func (r *Repository) Anonymize(ctx context.Context, userID int, login, passwordHash string) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	tag, err := tx.Exec(ctx,
+		`UPDATE users
+		 SET login = $2, password_hash = $3, role = 'customer',
+		     display_name = NULL, email = NULL, email_verified_at = NULL, phone = NULL,
+		     marketing_consent = FALSE, marketing_consent_at = NULL,
+		     token_version = token_version + 1, deleted_at = NOW()
+		 WHERE id = $1 AND deleted_at IS NULL`,
+		userID, login, passwordHash)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrUserNotFound
+	}
+
+	for _, query := range []string{
+		`DELETE FROM user_sessions WHERE user_id = $1`,
+		`DELETE FROM api_keys WHERE user_id = $1`,
+		`DELETE FROM user_identities WHERE user_id = $1`,
+		`DELETE FROM user_recovery_codes WHERE user_id = $1`,
+		`DELETE FROM user_totp WHERE user_id = $1`,
+		`DELETE FROM password_reset_tokens WHERE user_id = $1`,
+		`DELETE FROM email_verification_tokens WHERE user_id = $1`,
+		// Журнал доставок удаляется каскадом вместе с подписками
+		`DELETE FROM webhooks WHERE user_id = $1`,
+	} {
+		if _, err = tx.Exec(ctx, query, userID); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (r *Repository) GetProfile(ctx context.Context, userID int) (*Profile, error) {
+	query := `SELECT id, login, display_name, email, email_verified_at, phone, marketing_consent, marketing_consent_at
+			  FROM users
+			  WHERE id = $1`
+
+	profile := &Profile{}
+	err := r.pool.QueryRow(ctx, query, userID).Scan(
+		&profile.UserID,
+		&profile.Login,
+		&profile.DisplayName,
+		&profile.Email,
+		&profile.EmailVerifiedAt,
+		&profile.Phone,
+		&profile.MarketingConsent,
+		&profile.MarketingConsentAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrUserNotFound
+		}
+		return nil, err
+	}
+	return profile, nil
+}
+
+// UpdateProfile сохраняет данные профиля целиком
+func (r *Repository) UpdateProfile(ctx context.Context, profile *Profile) error {
+	query := `UPDATE users
+			  SET display_name = $2, email = $3, email_verified_at = $4, phone = $5,
+			      marketing_consent = $6, marketing_consent_at = $7
+			  WHERE id = $1`
+
+	tag, err := r.pool.Exec(ctx, query,
+		profile.UserID,
+		profile.DisplayName,
+		profile.Email,
+		profile.EmailVerifiedAt,
+		profile.Phone,
+		profile.MarketingConsent,
+		profile.MarketingConsentAt,
+	)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return ErrEmailAlreadyVerified
+		}
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrUserNotFound
+	}
+	return nil
+}
+
+// MarkEmailVerified подтверждает email, если он не был изменен после выпуска токена
+func (r *Repository) MarkEmailVerified(ctx context.Context, userID int, email string) error {
+	query := `UPDATE users SET email_verified_at = NOW()
+			  WHERE id = $1 AND email = $2`
+
+	tag, err := r.pool.Exec(ctx, query, userID, email)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return ErrEmailAlreadyVerified
+		}
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrUserNotFound
+	}
+	return nil
+}
+
+func isUniqueViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == "23505"
+}
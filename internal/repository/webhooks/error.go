@@ -0,0 +1,9 @@
+package webhooks
+
+import "errors"
+
+var ErrWebhookNotFound = errors.New("webhook not found")
+
+func IsErrWebhookNotFound(err error) bool {
+	return errors.Is(err, ErrWebhookNotFound)
+}
@@ -0,0 +1,19 @@
+package webhooks
+
+import (
+	"context"
+	"time"
+)
+
+type RepositoryInterface interface {
+	Add(ctx context.Context, webhook *Webhook) (*Webhook, error)
+	CountUserWebhooks(ctx context.Context, userID int) (int, error)
+	GetUserWebhooks(ctx context.Context, userID int) ([]*Webhook, error)
+	GetUserWebhook(ctx context.Context, userID, webhookID int) (*Webhook, error)
+	Delete(ctx context.Context, userID, webhookID int) error
+
+	Enqueue(ctx context.Context, userID int, eventType, payload string) (int64, error)
+	ClaimDue(ctx context.Context, limit int, lease time.Duration) ([]*Delivery, error)
+	SaveAttempt(ctx context.Context, result *AttemptResult) error
+	GetDeliveries(ctx context.Context, webhookID int, status string, limit int) ([]*Delivery, error)
+}
@@ -0,0 +1,50 @@
+package webhooks
+
+import "time"
+
+// Статусы доставки вебхука
+const (
+	DeliveryPending   = "pending"
+	DeliveryDelivered = "delivered"
+	DeliveryFailed    = "failed"
+)
+
+// Webhook подписка пользователя на события; Secret используется для HMAC-подписи доставок
+type Webhook struct {
+	ID        int
+	UserID    int
+	URL       string
+	Events    []string
+	Secret    string
+	CreatedAt time.Time
+}
+
+// Delivery попытки доставки одного события на один вебхук
+type Delivery struct {
+	ID             int64
+	WebhookID      int
+	EventType      string
+	Payload        string
+	Status         string
+	Attempts       int
+	NextAttemptAt  time.Time
+	LastAttemptAt  *time.Time
+	ResponseStatus *int
+	LastError      *string
+	CreatedAt      time.Time
+	DeliveredAt    *time.Time
+
+	// Заполняются только при выборке доставок для отправки
+	URL    string
+	Secret string
+}
+
+// AttemptResult результат очередной попытки доставки.
+// NextAttemptAt == nil означает, что попытки исчерпаны и доставка помечается как failed.
+type AttemptResult struct {
+	DeliveryID     int64
+	Delivered      bool
+	ResponseStatus *int
+	Error          *string
+	NextAttemptAt  *time.Time
+}
@@ -0,0 +1,245 @@
+package webhooks
+
+import (
+	"context"
+	"errors"
+	"gophermart-service/internal/config"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+func NewWebhooksRepository(logger config.LoggerInterface, pool *pgxpool.Pool) RepositoryInterface {
+	return &Repository{
+		logger: logger,
+		pool:   pool,
+	}
+}
+
+type Repository struct {
+	logger config.LoggerInterface
+	pool   *pgxpool.Pool
+}
+
+func (r *Repository) Add(ctx context.Context, webhook *Webhook) (*Webhook, error) {
+	query := `INSERT INTO webhooks (user_id, url, events, secret)
+			  VALUES ($1, $2, $3, $4)
+			  RETURNING id, created_at`
+
+	created := *webhook
+	err := r.pool.QueryRow(ctx, query,
+		webhook.UserID,
+		webhook.URL,
+		webhook.Events,
+		webhook.Secret,
+	).Scan(&created.ID, &created.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &created, nil
+}
+
+func (r *Repository) CountUserWebhooks(ctx context.Context, userID int) (int, error) {
+	var count int
+	err := r.pool.QueryRow(ctx, `SELECT COUNT(*) FROM webhooks WHERE user_id = $1`, userID).Scan(&count)
+	return count, err
+}
+
+func (r *Repository) GetUserWebhooks(ctx context.Context, userID int) ([]*Webhook, error) {
+	query := `SELECT id, user_id, url, events, secret, created_at
+			  FROM webhooks
+			  WHERE user_id = $1
+			  ORDER BY id`
+
+	rows, err := r.pool.Query(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var webhooks []*Webhook
+	for rows.Next() {
+		var webhook Webhook
+		if err := rows.Scan(
+			&webhook.ID,
+			&webhook.UserID,
+			&webhook.URL,
+			&webhook.Events,
+			&webhook.Secret,
+			&webhook.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		webhooks = append(webhooks, &webhook)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return webhooks, nil
+}
+
+func (r *Repository) GetUserWebhook(ctx context.Context, userID, webhookID int) (*Webhook, error) {
+	query := `SELECT id, user_id, url, events, secret, created_at
+			  FROM webhooks
+			  WHERE id = $1 AND user_id = $2`
+
+	var webhook Webhook
+	err := r.pool.QueryRow(ctx, query, webhookID, userID).Scan(
+		&webhook.ID,
+		&webhook.UserID,
+		&webhook.URL,
+		&webhook.Events,
+		&webhook.Secret,
+		&webhook.CreatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrWebhookNotFound
+		}
+		return nil, err
+	}
+	return &webhook, nil
+}
+
+// Delete удаляет подписку вместе с журналом ее доставок
+func (r *Repository) Delete(ctx context.Context, userID, webhookID int) error {
+	tag, err := r.pool.Exec(ctx, `DELETE FROM webhooks WHERE id = $1 AND user_id = $2`, webhookID, userID)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrWebhookNotFound
+	}
+	return nil
+}
+
+// Enqueue ставит событие в очередь доставки для всех вебхуков пользователя, подписанных на него
+func (r *Repository) Enqueue(ctx context.Context, userID int, eventType, payload string) (int64, error) {
+	query := `INSERT INTO webhook_deliveries (webhook_id, event_type, payload)
+			  SELECT id, $2, $3 FROM webhooks
+			  WHERE user_id = $1 AND $2 = ANY(events)`
+
+	tag, err := r.pool.Exec(ctx, query, userID, eventType, payload)
+	if err != nil {
+		return 0, err
+	}
+	return tag.RowsAffected(), nil
+}
+
+// ClaimDue забирает готовые к отправке доставки и откладывает их на время lease,
+// чтобы другие экземпляры сервиса не отправили их повторно. Если процесс упадет
+// во время отправки, доставка вернется в очередь по истечении lease.
+func (r *Repository) ClaimDue(ctx context.Context, limit int, lease time.Duration) ([]*Delivery, error) {
+	query := `WITH due AS (
+				  SELECT id FROM webhook_deliveries
+				  WHERE status = 'pending' AND next_attempt_at <= NOW()
+				  ORDER BY next_attempt_at
+				  LIMIT $1
+				  FOR UPDATE SKIP LOCKED
+			  )
+			  UPDATE webhook_deliveries d
+			  SET next_attempt_at = NOW() + make_interval(secs => $2)
+			  FROM due, webhooks w
+			  WHERE d.id = due.id AND w.id = d.webhook_id
+			  RETURNING d.id, d.webhook_id, d.event_type, d.payload, d.attempts, w.url, w.secret`
+
+	rows, err := r.pool.Query(ctx, query, limit, lease.Seconds())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var deliveries []*Delivery
+	for rows.Next() {
+		delivery := Delivery{Status: DeliveryPending}
+		if err := rows.Scan(
+			&delivery.ID,
+			&delivery.WebhookID,
+			&delivery.EventType,
+			&delivery.Payload,
+			&delivery.Attempts,
+			&delivery.URL,
+			&delivery.Secret,
+		); err != nil {
+			return nil, err
+		}
+		deliveries = append(deliveries, &delivery)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return deliveries, nil
+}
+
+// SaveAttempt фиксирует результат попытки доставки
+func (r *Repository) SaveAttempt(ctx context.Context, result *AttemptResult) error {
+	status := DeliveryPending
+	switch {
+	case result.Delivered:
+		status = DeliveryDelivered
+	case result.NextAttemptAt == nil:
+		status = DeliveryFailed
+	}
+
+	query := `UPDATE webhook_deliveries
+			  SET status = $2,
+			      attempts = attempts + 1,
+			      last_attempt_at = NOW(),
+			      response_status = $3,
+			      last_error = $4,
+			      next_attempt_at = COALESCE($5, next_attempt_at),
+			      delivered_at = CASE WHEN $2 = 'delivered' THEN NOW() END
+			  WHERE id = $1`
+
+	_, err := r.pool.Exec(ctx, query,
+		result.DeliveryID,
+		status,
+		result.ResponseStatus,
+		result.Error,
+		result.NextAttemptAt,
+	)
+	return err
+}
+
+// GetDeliveries возвращает журнал доставок вебхука, новые записи первыми; пустой status не фильтрует
+func (r *Repository) GetDeliveries(ctx context.Context, webhookID int, status string, limit int) ([]*Delivery, error) {
+	query := `SELECT id, webhook_id, event_type, payload, status, attempts, next_attempt_at,
+			         last_attempt_at, response_status, last_error, created_at, delivered_at
+			  FROM webhook_deliveries
+			  WHERE webhook_id = $1 AND ($2 = '' OR status = $2)
+			  ORDER BY id DESC
+			  LIMIT $3`
+
+	rows, err := r.pool.Query(ctx, query, webhookID, status, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var deliveries []*Delivery
+	for rows.Next() {
+		var delivery Delivery
+		if err := rows.Scan(
+			&delivery.ID,
+			&delivery.WebhookID,
+			&delivery.EventType,
+			&delivery.Payload,
+			&delivery.Status,
+			&delivery.Attempts,
+			&delivery.NextAttemptAt,
+			&delivery.LastAttemptAt,
+			&delivery.ResponseStatus,
+			&delivery.LastError,
+			&delivery.CreatedAt,
+			&delivery.DeliveredAt,
+		); err != nil {
+			return nil, err
+		}
+		deliveries = append(deliveries, &delivery)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return deliveries, nil
+}
@@ -9,9 +9,10 @@ type RepositoryInterface interface {
 
 type RepositoryWriterInterface interface {
 	AddNew(ctx context.Context, userID int, orderNumber string, sum float32) error
-	AddNewWithBalanceCheck(ctx context.Context, userID int, orderNumber string, sum float32) error
+	AddNewWithBalanceCheck(ctx context.Context, userID int, orderNumber string, sumCents int64) error
 }
 
 type RepositoryReaderInterface interface {
 	GetUserWithdrawals(ctx context.Context, userID int) ([]Withdrawal, error)
+	ListUserWithdrawals(ctx context.Context, filter *ListFilter) ([]Withdrawal, error)
 }
@@ -9,7 +9,28 @@ type NewWithdraw struct {
 }
 
 type Withdrawal struct {
+	ID          int       `json:"-"`
 	Order       string    `json:"order"`
 	Sum         float32   `json:"sum"`
 	ProcessedAt time.Time `json:"processed_at"`
 }
+
+// Поля сортировки списка списаний
+const (
+	SortProcessedAt = "processed_at"
+	SortSum         = "sum"
+)
+
+// ListFilter параметры выборки списаний пользователя с keyset-пагинацией
+type ListFilter struct {
+	UserID    int
+	From      *time.Time
+	To        *time.Time
+	SortField string
+	Desc      bool
+	// AfterValue и AfterID задают позицию последней записи предыдущей страницы
+	AfterValue any
+	AfterID    int
+	// Limit 0 — выборка без ограничения
+	Limit int
+}
@@ -3,7 +3,9 @@ package withdraw
 import (
 	"context"
 	"errors"
+	"fmt"
 	"gophermart-service/internal/config"
+	"strings"
 
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
@@ -34,7 +36,9 @@ func (r Repository) AddNew(ctx context.Context, userID int, orderNumber string,
 	return nil
 }
 
-func (r Repository) AddNewWithBalanceCheck(ctx context.Context, userID int, orderNumber string, sum float32) error {
+// AddNewWithBalanceCheck добавляет списание суммы в копейках, если ее покрывает баланс.
+// Баланс сравнивается и списание записывается в копейках, без округлений float
+func (r Repository) AddNewWithBalanceCheck(ctx context.Context, userID int, orderNumber string, sumCents int64) error {
 	// Начинаем транзакцию с уровнем изоляции SERIALIZABLE
 	txOptions := pgx.TxOptions{
 		IsoLevel: pgx.Serializable,
@@ -47,11 +51,11 @@ func (r Repository) AddNewWithBalanceCheck(ctx context.Context, userID int, orde
 
 	// Блокируем пользователя для чтения его баланса с FOR UPDATE
 	// Это предотвращает другие транзакции от изменения баланса пользователя
-	var currentBalance float32
+	var currentBalanceCents int64
 	balanceQuery := `
 		SELECT 
-			COALESCE(SUM(CASE WHEN o.status = 'PROCESSED' THEN o.accrual ELSE 0 END), 0) - 
-			COALESCE(SUM(w.sum), 0) as current_balance
+			ROUND((COALESCE(SUM(CASE WHEN o.status = 'PROCESSED' THEN o.accrual ELSE 0 END), 0) - 
+			COALESCE(SUM(w.sum), 0)) * 100)::BIGINT as current_balance_cents
 		FROM users u
 		LEFT JOIN orders o ON u.id = o.user_id
 		LEFT JOIN withdrawals w ON u.id = w.user_id
@@ -59,19 +63,19 @@ func (r Repository) AddNewWithBalanceCheck(ctx context.Context, userID int, orde
 		GROUP BY u.id
 		FOR UPDATE OF u`
 
-	err = tx.QueryRow(ctx, balanceQuery, userID).Scan(&currentBalance)
+	err = tx.QueryRow(ctx, balanceQuery, userID).Scan(&currentBalanceCents)
 	if err != nil {
 		return err
 	}
 
 	// Проверяем, достаточно ли средств
-	if currentBalance < sum {
+	if currentBalanceCents < sumCents {
 		return ErrInsufficientBalance
 	}
 
 	// Добавляем списание
-	insertQuery := `INSERT INTO withdrawals (user_id, order_number, sum) VALUES ($1, $2, $3)`
-	_, err = tx.Exec(ctx, insertQuery, userID, orderNumber, sum)
+	insertQuery := `INSERT INTO withdrawals (user_id, order_number, sum) VALUES ($1, $2, $3::BIGINT / 100.0)`
+	_, err = tx.Exec(ctx, insertQuery, userID, orderNumber, sumCents)
 	if err != nil {
 		return err
 	}
@@ -109,3 +113,64 @@ func (r Repository) GetUserWithdrawals(ctx context.Context, userID int) ([]Withd
 
 	return withdrawals, nil
 }
+
+func (r Repository) ListUserWithdrawals(ctx context.Context, filter *ListFilter) ([]Withdrawal, error) {
+	sortColumn := SortProcessedAt
+	if filter.SortField == SortSum {
+		sortColumn = SortSum
+	}
+	direction, comparison := "ASC", ">"
+	if filter.Desc {
+		direction, comparison = "DESC", "<"
+	}
+
+	conditions := []string{"user_id = $1"}
+	args := []any{filter.UserID}
+	addArg := func(value any) string {
+		args = append(args, value)
+		return fmt.Sprintf("$%d", len(args))
+	}
+
+	if filter.From != nil {
+		conditions = append(conditions, "processed_at >= "+addArg(*filter.From))
+	}
+	if filter.To != nil {
+		conditions = append(conditions, "processed_at < "+addArg(*filter.To))
+	}
+	if filter.AfterValue != nil {
+		conditions = append(conditions, fmt.Sprintf("(%s, id) %s (%s, %s)",
+			sortColumn, comparison, addArg(filter.AfterValue), addArg(filter.AfterID)))
+	}
+
+	query := fmt.Sprintf(`SELECT id, order_number, sum, processed_at
+			  FROM withdrawals
+			  WHERE %s
+			  ORDER BY %s %s, id %s`,
+		strings.Join(conditions, " AND "),
+		sortColumn, direction, direction)
+	if filter.Limit > 0 {
+		query += " LIMIT " + addArg(filter.Limit)
+	}
+
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var withdrawals []Withdrawal
+	for rows.Next() {
+		var withdrawal Withdrawal
+		err := rows.Scan(&withdrawal.ID, &withdrawal.Order, &withdrawal.Sum, &withdrawal.ProcessedAt)
+		if err != nil {
+			return nil, err
+		}
+		withdrawals = append(withdrawals, withdrawal)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return withdrawals, nil
+}
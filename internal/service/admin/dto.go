@@ -0,0 +1,16 @@
+package admin
+
+import "time"
+
+const (
+	DefaultUsersLimit = 50
+	MaxUsersLimit     = 500
+)
+
+// UserOutDTO представляет пользователя в административном списке
+type UserOutDTO struct {
+	ID        int       `json:"id"`
+	Login     string    `json:"login"`
+	Role      string    `json:"role"`
+	CreatedAt time.Time `json:"created_at"`
+}
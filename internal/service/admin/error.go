@@ -0,0 +1,13 @@
+package admin
+
+import "errors"
+
+var (
+	ErrUnknownRole      = errors.New("unknown role")
+	ErrUserNotFound     = errors.New("user not found")
+	ErrCannotChangeSelf = errors.New("cannot change own role")
+)
+
+func IsErrUnknownRole(err error) bool      { return errors.Is(err, ErrUnknownRole) }
+func IsErrUserNotFound(err error) bool     { return errors.Is(err, ErrUserNotFound) }
+func IsErrCannotChangeSelf(err error) bool { return errors.Is(err, ErrCannotChangeSelf) }
@@ -0,0 +1,8 @@
+package admin
+
+import "context"
+
+type ServiceInterface interface {
+	ListUsers(ctx context.Context, limit, offset int) ([]*UserOutDTO, error)
+	SetRole(ctx context.Context, actorID, userID int, role string) error
+}
@@ -0,0 +1,90 @@
+package admin
+
+import (
+	"context"
+	"gophermart-service/internal/base"
+	"gophermart-service/internal/config"
+	usersRepo "gophermart-service/internal/repository/users"
+	userAuth "gophermart-service/internal/service/user/auth"
+)
+
+// Service представляет сервис администрирования пользователей
+type Service struct {
+	logger    config.LoggerInterface
+	usersRepo usersRepo.RepositoryInterface
+}
+
+// NewAdminService создает новый экземпляр сервиса администрирования
+func NewAdminService(
+	logger config.LoggerInterface,
+	usersRepo usersRepo.RepositoryInterface,
+) ServiceInterface {
+	return &Service{
+		logger:    logger,
+		usersRepo: usersRepo,
+	}
+}
+
+// ListUsers возвращает страницу списка пользователей
+func (s *Service) ListUsers(ctx context.Context, limit, offset int) ([]*UserOutDTO, error) {
+	if limit <= 0 {
+		limit = DefaultUsersLimit
+	}
+	if limit > MaxUsersLimit {
+		limit = MaxUsersLimit
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	users, err := s.usersRepo.GetUsers(ctx, limit, offset)
+	if err != nil {
+		s.logger.Errorw("Failed to get users",
+			"requestID", base.GetRequestID(ctx),
+			"error", err)
+		return nil, err
+	}
+
+	result := make([]*UserOutDTO, 0, len(users))
+	for _, user := range users {
+		result = append(result, &UserOutDTO{
+			ID:        user.ID,
+			Login:     user.Login,
+			Role:      user.Role,
+			CreatedAt: user.CreatedAt,
+		})
+	}
+	return result, nil
+}
+
+// SetRole назначает пользователю роль. Изменение собственной роли запрещено,
+// чтобы администратор случайно не лишил себя доступа.
+func (s *Service) SetRole(ctx context.Context, actorID, userID int, role string) error {
+	requestID := base.GetRequestID(ctx)
+
+	if !userAuth.IsValidRole(role) {
+		return ErrUnknownRole
+	}
+	if actorID == userID {
+		return ErrCannotChangeSelf
+	}
+
+	if err := s.usersRepo.UpdateRole(ctx, userID, role); err != nil {
+		if usersRepo.IsErrUserNotFound(err) {
+			return ErrUserNotFound
+		}
+		s.logger.Errorw("Failed to update user role",
+			"requestID", requestID,
+			"userID", userID,
+			"error", err)
+		return err
+	}
+
+	s.logger.Infow("User role changed",
+		"requestID", requestID,
+		"actorID", actorID,
+		"userID", userID,
+		"role", role)
+
+	return nil
+}
@@ -0,0 +1,18 @@
+package apikey
+
+// Права доступа, которые можно выдать API-ключу
+const (
+	ScopeOrdersWrite   = "orders:write"
+	ScopeBalanceRead   = "balance:read"
+	ScopeWithdrawWrite = "withdraw:write"
+)
+
+// KeyPrefix отличает API-ключи от JWT в заголовке Authorization
+const KeyPrefix = "gm_"
+
+// AllScopes содержит все поддерживаемые права доступа
+var AllScopes = []string{
+	ScopeOrdersWrite,
+	ScopeBalanceRead,
+	ScopeWithdrawWrite,
+}
@@ -0,0 +1,30 @@
+package apikey
+
+import "time"
+
+// CreateInDTO представляет запрос на выпуск API-ключа
+type CreateInDTO struct {
+	Name   string   `json:"name"`
+	Scopes []string `json:"scopes"`
+}
+
+// CreateOutDTO содержит выпущенный ключ; значение ключа показывается только один раз
+type CreateOutDTO struct {
+	ID        int       `json:"id"`
+	Name      string    `json:"name"`
+	Key       string    `json:"key"`
+	Prefix    string    `json:"prefix"`
+	Scopes    []string  `json:"scopes"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// OutDTO представляет API-ключ в списке ключей пользователя
+type OutDTO struct {
+	ID         int        `json:"id"`
+	Name       string     `json:"name"`
+	Prefix     string     `json:"prefix"`
+	Scopes     []string   `json:"scopes"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
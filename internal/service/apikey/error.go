@@ -0,0 +1,20 @@
+package apikey
+
+import "errors"
+
+var (
+	ErrNameIsRequired      = errors.New("api key name is required")
+	ErrNameTooLong         = errors.New("api key name is too long")
+	ErrScopesAreRequired   = errors.New("at least one scope is required")
+	ErrUnknownScope        = errors.New("unknown scope")
+	ErrInvalidAPIKey       = errors.New("invalid api key")
+	ErrAPIKeyNotFound      = errors.New("api key not found")
+	ErrFailedToGenerateKey = errors.New("failed to generate api key")
+)
+
+func IsErrNameIsRequired(err error) bool    { return errors.Is(err, ErrNameIsRequired) }
+func IsErrNameTooLong(err error) bool       { return errors.Is(err, ErrNameTooLong) }
+func IsErrScopesAreRequired(err error) bool { return errors.Is(err, ErrScopesAreRequired) }
+func IsErrUnknownScope(err error) bool      { return errors.Is(err, ErrUnknownScope) }
+func IsErrInvalidAPIKey(err error) bool     { return errors.Is(err, ErrInvalidAPIKey) }
+func IsErrAPIKeyNotFound(err error) bool    { return errors.Is(err, ErrAPIKeyNotFound) }
@@ -0,0 +1,13 @@
+package apikey
+
+import (
+	"context"
+	"gophermart-service/internal/service/jwt"
+)
+
+type ServiceInterface interface {
+	Create(ctx context.Context, userID int, dtoIn *CreateInDTO) (*CreateOutDTO, error)
+	List(ctx context.Context, userID int) ([]*OutDTO, error)
+	Revoke(ctx context.Context, userID, apiKeyID int) error
+	Authenticate(ctx context.Context, rawKey string) (*jwt.InDTO, error)
+}
@@ -0,0 +1,239 @@
+package apikey
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"gophermart-service/internal/base"
+	"gophermart-service/internal/config"
+	apiKeysRepo "gophermart-service/internal/repository/apikeys"
+	"gophermart-service/internal/service/jwt"
+	"strings"
+)
+
+const (
+	maxNameLength = 255
+	prefixBytes   = 6  // 12 hex-символов для поиска ключа
+	secretBytes   = 32 // 256 бит секретной части
+)
+
+// Service представляет сервис API-ключей партнерских интеграций.
+// Ключ имеет вид gm_<prefix>_<secret>; в БД хранятся prefix и SHA-256 от ключа.
+type Service struct {
+	logger config.LoggerInterface
+	repo   apiKeysRepo.RepositoryInterface
+}
+
+// NewAPIKeyService создает новый экземпляр сервиса API-ключей
+func NewAPIKeyService(
+	logger config.LoggerInterface,
+	repo apiKeysRepo.RepositoryInterface,
+) ServiceInterface {
+	return &Service{
+		logger: logger,
+		repo:   repo,
+	}
+}
+
+// Create выпускает новый API-ключ с указанными правами доступа
+func (s *Service) Create(ctx context.Context, userID int, dtoIn *CreateInDTO) (*CreateOutDTO, error) {
+	requestID := base.GetRequestID(ctx)
+
+	scopes, err := validateCreateRequest(dtoIn)
+	if err != nil {
+		return nil, err
+	}
+
+	prefix, rawKey, err := generateKey()
+	if err != nil {
+		s.logger.Errorw("Failed to generate api key", "requestID", requestID, "error", err)
+		return nil, ErrFailedToGenerateKey
+	}
+
+	created, err := s.repo.Add(ctx, &apiKeysRepo.APIKey{
+		UserID:  userID,
+		Name:    strings.TrimSpace(dtoIn.Name),
+		Prefix:  prefix,
+		KeyHash: hashKey(rawKey),
+		Scopes:  scopes,
+	})
+	if err != nil {
+		s.logger.Errorw("Failed to store api key", "requestID", requestID, "userID", userID, "error", err)
+		return nil, err
+	}
+
+	s.logger.Infow("API key created",
+		"requestID", requestID,
+		"userID", userID,
+		"apiKeyID", created.ID,
+		"scopes", scopes)
+
+	return &CreateOutDTO{
+		ID:        created.ID,
+		Name:      created.Name,
+		Key:       rawKey,
+		Prefix:    created.Prefix,
+		Scopes:    created.Scopes,
+		CreatedAt: created.CreatedAt,
+	}, nil
+}
+
+// List возвращает все API-ключи пользователя без секретной части
+func (s *Service) List(ctx context.Context, userID int) ([]*OutDTO, error) {
+	apiKeys, err := s.repo.GetUserAPIKeys(ctx, userID)
+	if err != nil {
+		s.logger.Errorw("Failed to get api keys",
+			"requestID", base.GetRequestID(ctx),
+			"userID", userID,
+			"error", err)
+		return nil, err
+	}
+
+	result := make([]*OutDTO, 0, len(apiKeys))
+	for _, apiKey := range apiKeys {
+		result = append(result, &OutDTO{
+			ID:         apiKey.ID,
+			Name:       apiKey.Name,
+			Prefix:     apiKey.Prefix,
+			Scopes:     apiKey.Scopes,
+			LastUsedAt: apiKey.LastUsedAt,
+			RevokedAt:  apiKey.RevokedAt,
+			CreatedAt:  apiKey.CreatedAt,
+		})
+	}
+	return result, nil
+}
+
+// Revoke отзывает API-ключ пользователя
+func (s *Service) Revoke(ctx context.Context, userID, apiKeyID int) error {
+	requestID := base.GetRequestID(ctx)
+
+	if err := s.repo.Revoke(ctx, userID, apiKeyID); err != nil {
+		if apiKeysRepo.IsErrAPIKeyNotFound(err) {
+			return ErrAPIKeyNotFound
+		}
+		s.logger.Errorw("Failed to revoke api key",
+			"requestID", requestID,
+			"userID", userID,
+			"apiKeyID", apiKeyID,
+			"error", err)
+		return err
+	}
+
+	s.logger.Infow("API key revoked", "requestID", requestID, "userID", userID, "apiKeyID", apiKeyID)
+	return nil
+}
+
+// Authenticate проверяет API-ключ и возвращает пользователя с правами ключа
+func (s *Service) Authenticate(ctx context.Context, rawKey string) (*jwt.InDTO, error) {
+	requestID := base.GetRequestID(ctx)
+
+	prefix, ok := parsePrefix(rawKey)
+	if !ok {
+		return nil, ErrInvalidAPIKey
+	}
+
+	apiKey, err := s.repo.GetActiveByPrefix(ctx, prefix)
+	if err != nil {
+		if apiKeysRepo.IsErrAPIKeyNotFound(err) {
+			return nil, ErrInvalidAPIKey
+		}
+		return nil, err
+	}
+
+	if subtle.ConstantTimeCompare([]byte(apiKey.KeyHash), []byte(hashKey(rawKey))) != 1 {
+		s.logger.Warnw("API key hash mismatch", "requestID", requestID, "prefix", prefix)
+		return nil, ErrInvalidAPIKey
+	}
+
+	if err = s.repo.TouchLastUsed(ctx, apiKey.ID); err != nil {
+		s.logger.Warnw("Failed to update api key last usage",
+			"requestID", requestID,
+			"apiKeyID", apiKey.ID,
+			"error", err)
+	}
+
+	return &jwt.InDTO{
+		ID:       apiKey.UserID,
+		Login:    apiKey.UserLogin,
+		APIKeyID: apiKey.ID,
+		Scopes:   apiKey.Scopes,
+	}, nil
+}
+
+// IsAPIKey сообщает, что строка похожа на API-ключ, а не на JWT
+func IsAPIKey(token string) bool {
+	return strings.HasPrefix(token, KeyPrefix)
+}
+
+func validateCreateRequest(dtoIn *CreateInDTO) ([]string, error) {
+	name := strings.TrimSpace(dtoIn.Name)
+	if name == "" {
+		return nil, ErrNameIsRequired
+	}
+	if len(name) > maxNameLength {
+		return nil, ErrNameTooLong
+	}
+	if len(dtoIn.Scopes) == 0 {
+		return nil, ErrScopesAreRequired
+	}
+
+	scopes := make([]string, 0, len(dtoIn.Scopes))
+	seen := make(map[string]struct{}, len(dtoIn.Scopes))
+	for _, scope := range dtoIn.Scopes {
+		if !isKnownScope(scope) {
+			return nil, fmt.Errorf("%w: %s", ErrUnknownScope, scope)
+		}
+		if _, ok := seen[scope]; ok {
+			continue
+		}
+		seen[scope] = struct{}{}
+		scopes = append(scopes, scope)
+	}
+	return scopes, nil
+}
+
+func isKnownScope(scope string) bool {
+	for _, known := range AllScopes {
+		if scope == known {
+			return true
+		}
+	}
+	return false
+}
+
+func generateKey() (string, string, error) {
+	prefixBuf := make([]byte, prefixBytes)
+	if _, err := rand.Read(prefixBuf); err != nil {
+		return "", "", err
+	}
+	secretBuf := make([]byte, secretBytes)
+	if _, err := rand.Read(secretBuf); err != nil {
+		return "", "", err
+	}
+
+	prefix := hex.EncodeToString(prefixBuf)
+	rawKey := KeyPrefix + prefix + "_" + base64.RawURLEncoding.EncodeToString(secretBuf)
+	return prefix, rawKey, nil
+}
+
+// parsePrefix извлекает prefix из ключа вида gm_<prefix>_<secret>
+func parsePrefix(rawKey string) (string, bool) {
+	if !IsAPIKey(rawKey) {
+		return "", false
+	}
+	prefix, secret, ok := strings.Cut(strings.TrimPrefix(rawKey, KeyPrefix), "_")
+	if !ok || len(prefix) != prefixBytes*2 || secret == "" {
+		return "", false
+	}
+	return prefix, true
+}
+
+func hashKey(rawKey string) string {
+	sum := sha256.Sum256([]byte(rawKey))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,95 @@
+package apikey
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParsePrefix(t *testing.T) {
+	tests := []struct {
+		name       string
+		rawKey     string
+		wantPrefix string
+		wantOK     bool
+	}{
+		{name: "valid", rawKey: "gm_0123456789ab_c2VjcmV0", wantPrefix: "0123456789ab", wantOK: true},
+		{name: "secret may contain separators", rawKey: "gm_0123456789ab_se_cr-et", wantPrefix: "0123456789ab", wantOK: true},
+		{name: "jwt", rawKey: "eyJhbGciOiJIUzI1NiJ9.e30.sig"},
+		{name: "missing secret", rawKey: "gm_0123456789ab_"},
+		{name: "missing separator", rawKey: "gm_0123456789ab"},
+		{name: "short prefix", rawKey: "gm_0123_c2VjcmV0"},
+		{name: "long prefix", rawKey: "gm_0123456789abcd_c2VjcmV0"},
+		{name: "empty", rawKey: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prefix, ok := parsePrefix(tt.rawKey)
+			if ok != tt.wantOK || prefix != tt.wantPrefix {
+				t.Errorf("parsePrefix(%q) = (%q, %v), want (%q, %v)", tt.rawKey, prefix, ok, tt.wantPrefix, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestGenerateKey(t *testing.T) {
+	prefix, rawKey, err := generateKey()
+	if err != nil {
+		t.Fatalf("generateKey: %v", err)
+	}
+	if !IsAPIKey(rawKey) {
+		t.Errorf("IsAPIKey(%q) = false", rawKey)
+	}
+	parsed, ok := parsePrefix(rawKey)
+	if !ok || parsed != prefix {
+		t.Errorf("parsePrefix(%q) = (%q, %v), want (%q, true)", rawKey, parsed, ok, prefix)
+	}
+
+	_, other, err := generateKey()
+	if err != nil {
+		t.Fatalf("generateKey: %v", err)
+	}
+	if other == rawKey || hashKey(other) == hashKey(rawKey) {
+		t.Errorf("two generated keys collide: %q", rawKey)
+	}
+}
+
+func TestValidateCreateRequest(t *testing.T) {
+	tests := []struct {
+		name       string
+		in         *CreateInDTO
+		wantScopes []string
+		wantErr    error
+	}{
+		{
+			name:       "valid",
+			in:         &CreateInDTO{Name: "partner", Scopes: []string{ScopeOrdersWrite, ScopeBalanceRead}},
+			wantScopes: []string{ScopeOrdersWrite, ScopeBalanceRead},
+		},
+		{
+			name:       "duplicate scopes are collapsed",
+			in:         &CreateInDTO{Name: "partner", Scopes: []string{ScopeBalanceRead, ScopeBalanceRead}},
+			wantScopes: []string{ScopeBalanceRead},
+		},
+		{name: "blank name", in: &CreateInDTO{Name: "  ", Scopes: []string{ScopeBalanceRead}}, wantErr: ErrNameIsRequired},
+		{
+			name:    "name too long",
+			in:      &CreateInDTO{Name: strings.Repeat("n", maxNameLength+1), Scopes: []string{ScopeBalanceRead}},
+			wantErr: ErrNameTooLong,
+		},
+		{name: "no scopes", in: &CreateInDTO{Name: "partner"}, wantErr: ErrScopesAreRequired},
+		{name: "unknown scope", in: &CreateInDTO{Name: "partner", Scopes: []string{"admin"}}, wantErr: ErrUnknownScope},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scopes, err := validateCreateRequest(tt.in)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("error = %v, want %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(scopes, tt.wantScopes) {
+				t.Errorf("scopes = %v, want %v", scopes, tt.wantScopes)
+			}
+		})
+	}
+}
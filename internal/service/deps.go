@@ -5,11 +5,23 @@ import (
 	"gophermart-service/internal/integration"
 	"gophermart-service/internal/integration/accrual"
 	"gophermart-service/internal/repository"
+	"gophermart-service/internal/service/admin"
+	"gophermart-service/internal/service/apikey"
+	"gophermart-service/internal/service/hasher"
 	"gophermart-service/internal/service/health"
 	"gophermart-service/internal/service/jwt"
+	userAccount "gophermart-service/internal/service/user/account"
 	userAuth "gophermart-service/internal/service/user/auth"
 	userBalance "gophermart-service/internal/service/user/balance"
+	userOIDC "gophermart-service/internal/service/user/oidc"
 	userOrder "gophermart-service/internal/service/user/order"
+	userPassword "gophermart-service/internal/service/user/password"
+	userProfile "gophermart-service/internal/service/user/profile"
+	userSession "gophermart-service/internal/service/user/session"
+	userStatement "gophermart-service/internal/service/user/statement"
+	userTwoFactor "gophermart-service/internal/service/user/twofactor"
+	userUpdates "gophermart-service/internal/service/user/updates"
+	userWebhook "gophermart-service/internal/service/user/webhook"
 	userWithdraw "gophermart-service/internal/service/user/withdraw"
 )
 
@@ -19,6 +31,17 @@ type Services struct {
 	UserOrder    userOrder.ServiceInterface
 	UserBalance  userBalance.ServiceInterface
 	UserWithdraw userWithdraw.ServiceInterface
+	UserPassword userPassword.ServiceInterface
+	TwoFactor    userTwoFactor.ServiceInterface
+	APIKey       apikey.ServiceInterface
+	Admin        admin.ServiceInterface
+	OIDC         userOIDC.ServiceInterface
+	Session      userSession.ServiceInterface
+	Account      userAccount.ServiceInterface
+	Profile      userProfile.ServiceInterface
+	Updates      userUpdates.ServiceInterface
+	Webhook      userWebhook.ServiceInterface
+	Statement    userStatement.ServiceInterface
 	JWT          jwt.ServiceInterface
 	Accrual      *accrual.Service
 }
@@ -28,13 +51,97 @@ func NewServices(
 	settings *config.Settings,
 	repos *repository.Repositories,
 	integrations *integration.Integrations,
-) *Services {
-	healthService := health.NewHealthService(logger, repos.Health)
-	userAuthService := userAuth.NewRegisterService(logger, repos.Users)
+) (*Services, error) {
+	passwordHasher, err := hasher.NewHasherService(settings.Environment.Password)
+	if err != nil {
+		return nil, err
+	}
+
+	expectedMigration, err := config.LatestMigrationVersion(settings.Environment.Database.MigrationsPath)
+	if err != nil {
+		return nil, err
+	}
+
+	userAuthService := userAuth.NewRegisterService(
+		logger,
+		repos.Users,
+		passwordHasher,
+		userAuth.NewPasswordPolicy(settings.Environment.Password),
+	)
 	jwtService := jwt.NewJWTService(settings.Environment.JWT, logger)
-	userOrderService := userOrder.NewOrderService(logger, repos.Orders, integrations.Accrual)
 	userBalanceService := userBalance.NewUserBalanceService(logger, repos.Views)
-	userWithdrawService := userWithdraw.NewUserWithdrawService(logger, repos.Orders, repos.Views, repos.Withdraw)
+	updatesService := userUpdates.NewUpdatesService(logger, repos.Orders, userBalanceService)
+	webhookService := userWebhook.NewWebhookService(
+		logger,
+		settings.Environment.Webhook,
+		repos.Webhooks,
+		integrations.Webhook,
+	)
+	userOrderService := userOrder.NewOrderService(
+		logger,
+		repos.Orders,
+		integrations.Accrual,
+		updatesService,
+		webhookService,
+	)
+	healthService := health.NewHealthService(
+		logger,
+		repos.Health,
+		integrations.Accrual,
+		userOrderService,
+		expectedMigration,
+	)
+	userWithdrawService := userWithdraw.NewUserWithdrawService(
+		logger,
+		repos.Orders,
+		repos.Views,
+		repos.Withdraw,
+		webhookService,
+	)
+	userPasswordService := userPassword.NewPasswordService(
+		logger,
+		settings.Environment.Password,
+		repos.Users,
+		repos.PasswordReset,
+		repos.Sessions,
+		userAuthService,
+		integrations.Notifier,
+	)
+	twoFactorService := userTwoFactor.NewTwoFactorService(
+		logger,
+		settings.Environment.TwoFactor,
+		repos.TwoFactor,
+	)
+	apiKeyService := apikey.NewAPIKeyService(logger, repos.APIKeys)
+	adminService := admin.NewAdminService(logger, repos.Users)
+	oidcService := userOIDC.NewOIDCService(
+		logger,
+		settings.Environment.OIDC,
+		integrations.OIDC,
+		repos.Users,
+		repos.Identities,
+	)
+	sessionService := userSession.NewSessionService(logger, repos.Sessions)
+	statementService := userStatement.NewStatementService(logger, repos.Orders, repos.Withdraw)
+	accountService := userAccount.NewAccountService(
+		logger,
+		repos.Users,
+		repos.Orders,
+		repos.Withdraw,
+		repos.Views,
+		repos.Sessions,
+		repos.APIKeys,
+		repos.Identities,
+		repos.TwoFactor,
+		userAuthService,
+	)
+	profileService := userProfile.NewProfileService(
+		logger,
+		settings.Environment.Profile,
+		repos.Users,
+		repos.EmailVerify,
+		integrations.Notifier,
+	)
 
 	return &Services{
 		Health:       healthService,
@@ -42,6 +149,17 @@ func NewServices(
 		UserOrder:    userOrderService,
 		UserBalance:  userBalanceService,
 		UserWithdraw: userWithdrawService,
+		UserPassword: userPasswordService,
+		TwoFactor:    twoFactorService,
+		APIKey:       apiKeyService,
+		Admin:        adminService,
+		OIDC:         oidcService,
+		Session:      sessionService,
+		Account:      accountService,
+		Profile:      profileService,
+		Updates:      updatesService,
+		Webhook:      webhookService,
+		Statement:    statementService,
 		JWT:          jwtService,
-	}
+	}, nil
 }
@@ -0,0 +1,114 @@
+package hasher
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+)
+
+const argon2idPrefix = "$argon2id$"
+
+// argon2Params содержит параметры алгоритма argon2id
+type argon2Params struct {
+	time       uint32
+	memory     uint32
+	threads    uint8
+	keyLength  uint32
+	saltLength uint32
+}
+
+// argon2idHasher хеширует пароли алгоритмом argon2id.
+// Формат хеша: $argon2id$v=19$m=<memory>,t=<time>,p=<threads>$<salt>$<key>
+type argon2idHasher struct {
+	params argon2Params
+}
+
+func newArgon2idHasher(params argon2Params) *argon2idHasher {
+	return &argon2idHasher{params: params}
+}
+
+func (h *argon2idHasher) Hash(password string) (string, error) {
+	salt := make([]byte, h.params.saltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return "", err
+	}
+
+	key := argon2.IDKey([]byte(password), salt, h.params.time, h.params.memory, h.params.threads, h.params.keyLength)
+
+	return fmt.Sprintf("%sv=%d$m=%d,t=%d,p=%d$%s$%s",
+		argon2idPrefix,
+		argon2.Version,
+		h.params.memory,
+		h.params.time,
+		h.params.threads,
+		base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(key),
+	), nil
+}
+
+func (h *argon2idHasher) Verify(encodedHash, password string) error {
+	params, salt, key, err := decodeArgon2idHash(encodedHash)
+	if err != nil {
+		return err
+	}
+
+	otherKey := argon2.IDKey([]byte(password), salt, params.time, params.memory, params.threads, params.keyLength)
+	if subtle.ConstantTimeCompare(key, otherKey) != 1 {
+		return ErrMismatchedHashAndPassword
+	}
+	return nil
+}
+
+func (h *argon2idHasher) NeedsRehash(encodedHash string) bool {
+	params, salt, _, err := decodeArgon2idHash(encodedHash)
+	if err != nil {
+		return true
+	}
+	return params.time != h.params.time ||
+		params.memory != h.params.memory ||
+		params.threads != h.params.threads ||
+		params.keyLength != h.params.keyLength ||
+		uint32(len(salt)) != h.params.saltLength
+}
+
+func isArgon2idHash(encodedHash string) bool {
+	return strings.HasPrefix(encodedHash, argon2idPrefix)
+}
+
+func decodeArgon2idHash(encodedHash string) (*argon2Params, []byte, []byte, error) {
+	// "", "argon2id", "v=19", "m=...,t=...,p=...", salt, key
+	parts := strings.Split(encodedHash, "$")
+	if len(parts) != 6 || parts[1] != "argon2id" {
+		return nil, nil, nil, ErrInvalidHash
+	}
+
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil {
+		return nil, nil, nil, ErrInvalidHash
+	}
+	if version != argon2.Version {
+		return nil, nil, nil, ErrInvalidHash
+	}
+
+	var params argon2Params
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &params.memory, &params.time, &params.threads); err != nil {
+		return nil, nil, nil, ErrInvalidHash
+	}
+
+	salt, err := base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil {
+		return nil, nil, nil, ErrInvalidHash
+	}
+	key, err := base64.RawStdEncoding.DecodeString(parts[5])
+	if err != nil {
+		return nil, nil, nil, ErrInvalidHash
+	}
+	params.saltLength = uint32(len(salt))
+	params.keyLength = uint32(len(key))
+
+	return &params, salt, key, nil
+}
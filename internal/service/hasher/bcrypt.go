@@ -0,0 +1,51 @@
+package hasher
+
+import (
+	"errors"
+	"strings"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// bcryptHasher хеширует пароли алгоритмом bcrypt
+type bcryptHasher struct {
+	cost int
+}
+
+func newBcryptHasher(cost int) *bcryptHasher {
+	return &bcryptHasher{cost: cost}
+}
+
+func (h *bcryptHasher) Hash(password string) (string, error) {
+	hashedBytes, err := bcrypt.GenerateFromPassword([]byte(password), h.cost)
+	if err != nil {
+		if errors.Is(err, bcrypt.ErrPasswordTooLong) {
+			return "", ErrPasswordTooLong
+		}
+		return "", err
+	}
+	return string(hashedBytes), nil
+}
+
+func (h *bcryptHasher) Verify(encodedHash, password string) error {
+	err := bcrypt.CompareHashAndPassword([]byte(encodedHash), []byte(password))
+	if errors.Is(err, bcrypt.ErrMismatchedHashAndPassword) {
+		return ErrMismatchedHashAndPassword
+	}
+	return err
+}
+
+func (h *bcryptHasher) NeedsRehash(encodedHash string) bool {
+	cost, err := bcrypt.Cost([]byte(encodedHash))
+	if err != nil {
+		return true
+	}
+	return cost != h.cost
+}
+
+// isBcryptHash проверяет префикс хеша bcrypt ($2a$, $2b$, $2y$)
+func isBcryptHash(encodedHash string) bool {
+	return strings.HasPrefix(encodedHash, "$2a$") ||
+		strings.HasPrefix(encodedHash, "$2b$") ||
+		strings.HasPrefix(encodedHash, "$2y$")
+}
@@ -0,0 +1,18 @@
+package hasher
+
+import "errors"
+
+var (
+	ErrMismatchedHashAndPassword = errors.New("hash and password do not match")
+	ErrUnknownAlgorithm          = errors.New("unknown password hash algorithm")
+	ErrInvalidHash               = errors.New("invalid password hash format")
+	ErrPasswordTooLong           = errors.New("password is too long for the hash algorithm")
+)
+
+func IsErrMismatchedHashAndPassword(err error) bool {
+	return errors.Is(err, ErrMismatchedHashAndPassword)
+}
+
+func IsErrPasswordTooLong(err error) bool {
+	return errors.Is(err, ErrPasswordTooLong)
+}
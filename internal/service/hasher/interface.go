@@ -0,0 +1,12 @@
+package hasher
+
+// HasherInterface представляет интерфейс хеширования паролей.
+// Алгоритм и его параметры хранятся в самой строке хеша (формат PHC / modular crypt).
+type HasherInterface interface {
+	// Hash хеширует пароль текущим алгоритмом с текущими параметрами
+	Hash(password string) (string, error)
+	// Verify проверяет пароль против хеша, алгоритм определяется по хешу
+	Verify(encodedHash, password string) error
+	// NeedsRehash сообщает, что хеш получен другим алгоритмом или с другими параметрами
+	NeedsRehash(encodedHash string) bool
+}
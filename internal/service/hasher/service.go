@@ -0,0 +1,64 @@
+package hasher
+
+import (
+	"fmt"
+	"gophermart-service/internal/config"
+)
+
+// Service хеширует новые пароли настроенным алгоритмом и проверяет хеши,
+// полученные любым из поддерживаемых алгоритмов
+type Service struct {
+	algorithm string
+	bcrypt    *bcryptHasher
+	argon2id  *argon2idHasher
+}
+
+// NewHasherService создает новый сервис хеширования паролей
+func NewHasherService(settings *config.PasswordSettings) (HasherInterface, error) {
+	switch settings.HashAlgorithm {
+	case config.PasswordHashAlgorithmBcrypt, config.PasswordHashAlgorithmArgon2id:
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnknownAlgorithm, settings.HashAlgorithm)
+	}
+
+	return &Service{
+		algorithm: settings.HashAlgorithm,
+		bcrypt:    newBcryptHasher(settings.BcryptCost),
+		argon2id: newArgon2idHasher(argon2Params{
+			time:       settings.Argon2Time,
+			memory:     settings.Argon2Memory,
+			threads:    settings.Argon2Threads,
+			keyLength:  settings.Argon2KeyLength,
+			saltLength: settings.Argon2SaltLength,
+		}),
+	}, nil
+}
+
+func (s *Service) Hash(password string) (string, error) {
+	if s.algorithm == config.PasswordHashAlgorithmArgon2id {
+		return s.argon2id.Hash(password)
+	}
+	return s.bcrypt.Hash(password)
+}
+
+func (s *Service) Verify(encodedHash, password string) error {
+	switch {
+	case isArgon2idHash(encodedHash):
+		return s.argon2id.Verify(encodedHash, password)
+	case isBcryptHash(encodedHash):
+		return s.bcrypt.Verify(encodedHash, password)
+	default:
+		return ErrUnknownAlgorithm
+	}
+}
+
+func (s *Service) NeedsRehash(encodedHash string) bool {
+	switch {
+	case isArgon2idHash(encodedHash):
+		return s.algorithm != config.PasswordHashAlgorithmArgon2id || s.argon2id.NeedsRehash(encodedHash)
+	case isBcryptHash(encodedHash):
+		return s.algorithm != config.PasswordHashAlgorithmBcrypt || s.bcrypt.NeedsRehash(encodedHash)
+	default:
+		return true
+	}
+}
@@ -0,0 +1,158 @@
+package hasher
+
+import (
+	"errors"
+	"gophermart-service/internal/config"
+	"strings"
+	"testing"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// testSettings настройки с минимальной стоимостью хеширования, чтобы тесты работали быстро
+func testSettings(algorithm string) *config.PasswordSettings {
+	return &config.PasswordSettings{
+		HashAlgorithm:    algorithm,
+		BcryptCost:       bcrypt.MinCost,
+		Argon2Time:       1,
+		Argon2Memory:     64,
+		Argon2Threads:    1,
+		Argon2KeyLength:  16,
+		Argon2SaltLength: 8,
+	}
+}
+
+func newTestHasher(t *testing.T, settings *config.PasswordSettings) HasherInterface {
+	t.Helper()
+	h, err := NewHasherService(settings)
+	if err != nil {
+		t.Fatalf("NewHasherService: %v", err)
+	}
+	return h
+}
+
+func TestHasherRoundTrip(t *testing.T) {
+	for _, algorithm := range []string{config.PasswordHashAlgorithmBcrypt, config.PasswordHashAlgorithmArgon2id} {
+		t.Run(algorithm, func(t *testing.T) {
+			h := newTestHasher(t, testSettings(algorithm))
+
+			hash, err := h.Hash("s3cret")
+			if err != nil {
+				t.Fatalf("Hash: %v", err)
+			}
+			if err = h.Verify(hash, "s3cret"); err != nil {
+				t.Errorf("Verify correct password: %v", err)
+			}
+			if err = h.Verify(hash, "S3cret"); !IsErrMismatchedHashAndPassword(err) {
+				t.Errorf("Verify wrong password error = %v, want %v", err, ErrMismatchedHashAndPassword)
+			}
+			if h.NeedsRehash(hash) {
+				t.Errorf("NeedsRehash(%q) = true for a hash with current parameters", hash)
+			}
+
+			// Соль случайная: хеши одного пароля различаются
+			other, err := h.Hash("s3cret")
+			if err != nil {
+				t.Fatalf("Hash: %v", err)
+			}
+			if other == hash {
+				t.Errorf("two hashes of the same password are equal: %q", hash)
+			}
+		})
+	}
+}
+
+func TestHasherNeedsRehash(t *testing.T) {
+	hashWith := func(algorithm string) string {
+		hash, err := newTestHasher(t, testSettings(algorithm)).Hash("s3cret")
+		if err != nil {
+			t.Fatalf("Hash: %v", err)
+		}
+		return hash
+	}
+	bcryptStored := hashWith(config.PasswordHashAlgorithmBcrypt)
+	argon2Stored := hashWith(config.PasswordHashAlgorithmArgon2id)
+
+	higherCost := testSettings(config.PasswordHashAlgorithmBcrypt)
+	higherCost.BcryptCost = bcrypt.MinCost + 1
+	moreMemory := testSettings(config.PasswordHashAlgorithmArgon2id)
+	moreMemory.Argon2Memory = 128
+	longerSalt := testSettings(config.PasswordHashAlgorithmArgon2id)
+	longerSalt.Argon2SaltLength = 16
+
+	tests := []struct {
+		name     string
+		settings *config.PasswordSettings
+		stored   string
+		want     bool
+	}{
+		{name: "bcrypt with same cost", settings: testSettings(config.PasswordHashAlgorithmBcrypt), stored: bcryptStored},
+		{name: "bcrypt cost changed", settings: higherCost, stored: bcryptStored, want: true},
+		{name: "bcrypt hash after switch to argon2id", settings: testSettings(config.PasswordHashAlgorithmArgon2id), stored: bcryptStored, want: true},
+		{name: "argon2id with same parameters", settings: testSettings(config.PasswordHashAlgorithmArgon2id), stored: argon2Stored},
+		{name: "argon2id memory changed", settings: moreMemory, stored: argon2Stored, want: true},
+		{name: "argon2id salt length changed", settings: longerSalt, stored: argon2Stored, want: true},
+		{name: "argon2id hash after switch to bcrypt", settings: testSettings(config.PasswordHashAlgorithmBcrypt), stored: argon2Stored, want: true},
+		{name: "unknown format", settings: testSettings(config.PasswordHashAlgorithmBcrypt), stored: "plain", want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := newTestHasher(t, tt.settings).NeedsRehash(tt.stored); got != tt.want {
+				t.Errorf("NeedsRehash = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHasherVerifyAnyAlgorithm(t *testing.T) {
+	// Хеши, созданные до смены алгоритма, продолжают проверяться
+	bcryptStored, err := newTestHasher(t, testSettings(config.PasswordHashAlgorithmBcrypt)).Hash("s3cret")
+	if err != nil {
+		t.Fatalf("Hash: %v", err)
+	}
+	argon2Stored, err := newTestHasher(t, testSettings(config.PasswordHashAlgorithmArgon2id)).Hash("s3cret")
+	if err != nil {
+		t.Fatalf("Hash: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		stored  string
+		wantErr error
+	}{
+		{name: "bcrypt", stored: bcryptStored},
+		{name: "argon2id", stored: argon2Stored},
+		{name: "unknown prefix", stored: "$md5$abc", wantErr: ErrUnknownAlgorithm},
+		{name: "argon2id with missing parts", stored: "$argon2id$v=19$m=64,t=1,p=1$c2FsdA", wantErr: ErrInvalidHash},
+		{name: "argon2id with unsupported version", stored: "$argon2id$v=16$m=64,t=1,p=1$c2FsdA$a2V5", wantErr: ErrInvalidHash},
+		{name: "argon2id with broken salt", stored: "$argon2id$v=19$m=64,t=1,p=1$!!!$a2V5", wantErr: ErrInvalidHash},
+	}
+	for _, algorithm := range []string{config.PasswordHashAlgorithmBcrypt, config.PasswordHashAlgorithmArgon2id} {
+		h := newTestHasher(t, testSettings(algorithm))
+		for _, tt := range tests {
+			t.Run(algorithm+"/"+tt.name, func(t *testing.T) {
+				if err := h.Verify(tt.stored, "s3cret"); !errors.Is(err, tt.wantErr) {
+					t.Errorf("Verify error = %v, want %v", err, tt.wantErr)
+				}
+			})
+		}
+	}
+}
+
+func TestNewHasherServiceUnknownAlgorithm(t *testing.T) {
+	if _, err := NewHasherService(testSettings("md5")); !errors.Is(err, ErrUnknownAlgorithm) {
+		t.Fatalf("error = %v, want %v", err, ErrUnknownAlgorithm)
+	}
+}
+
+func TestBcryptPasswordTooLong(t *testing.T) {
+	h := newTestHasher(t, testSettings(config.PasswordHashAlgorithmBcrypt))
+
+	if _, err := h.Hash(strings.Repeat("a", config.BcryptMaxPasswordBytes)); err != nil {
+		t.Fatalf("Hash of %d bytes: %v", config.BcryptMaxPasswordBytes, err)
+	}
+	// 37 кириллических символов — 74 байта
+	if _, err := h.Hash(strings.Repeat("п", 37)); !IsErrPasswordTooLong(err) {
+		t.Fatalf("Hash error = %v, want %v", err, ErrPasswordTooLong)
+	}
+}
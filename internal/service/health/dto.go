@@ -0,0 +1,51 @@
+package health
+
+// Статусы проверки готовности
+const (
+	StatusReady        = "ready"
+	StatusNotReady     = "not_ready"
+	StatusShuttingDown = "shutting_down"
+
+	ComponentUp   = "up"
+	ComponentDown = "down"
+)
+
+// Компоненты, которые проверяет готовность
+const (
+	ComponentDatabase     = "database"
+	ComponentMigrations   = "migrations"
+	ComponentAccrual      = "accrual"
+	ComponentOrderWorkers = "order_workers"
+)
+
+// LivenessDTO ответ проверки жизнеспособности процесса
+type LivenessDTO struct {
+	Status string `json:"status"`
+}
+
+// ReadinessDTO итог проверки готовности и состояние каждого компонента
+type ReadinessDTO struct {
+	Status     string                   `json:"status"`
+	Components map[string]*ComponentDTO `json:"components,omitempty"`
+}
+
+// Ready сообщает, можно ли направлять запросы в этот экземпляр
+func (r *ReadinessDTO) Ready() bool {
+	return r.Status == StatusReady
+}
+
+// ComponentDTO состояние компонента. Некритичный компонент отображается в отчете,
+// но не переводит экземпляр в неготовое состояние.
+type ComponentDTO struct {
+	Status   string `json:"status"`
+	Critical bool   `json:"critical"`
+	Error    string `json:"error,omitempty"`
+
+	// Версии схемы БД: примененная и ожидаемая приложением
+	Version         *uint `json:"version,omitempty"`
+	ExpectedVersion *uint `json:"expected_version,omitempty"`
+
+	// Воркеры обработки заказов: работающие и запущенные
+	Running *int `json:"running,omitempty"`
+	Total   *int `json:"total,omitempty"`
+}
@@ -4,4 +4,8 @@ import "context"
 
 type ServiceInterface interface {
 	Check(ctx context.Context) error
+	Live(ctx context.Context) *LivenessDTO
+	Ready(ctx context.Context) *ReadinessDTO
+	// StartShutdown переводит экземпляр в неготовое состояние на время остановки
+	StartShutdown()
 }
@@ -2,24 +2,43 @@ package health
 
 import (
 	"context"
+	"fmt"
 	"gophermart-service/internal/base"
 	"gophermart-service/internal/config"
+	"gophermart-service/internal/integration/accrual"
 	healthRepo "gophermart-service/internal/repository/health"
+	userOrder "gophermart-service/internal/service/user/order"
+	"sync"
+	"sync/atomic"
+	"time"
 )
 
+// checkTimeout ограничивает каждую проверку, чтобы зависшая зависимость не задерживала пробу
+const checkTimeout = 2 * time.Second
+
 func NewHealthService(
 	logger config.LoggerInterface,
 	repo healthRepo.RepositoryInterface,
+	accrualClient accrual.ClientInterface,
+	orderService userOrder.ServiceInterface,
+	expectedMigration uint,
 ) ServiceInterface {
 	return &Service{
-		logger: logger,
-		repo:   repo,
+		logger:            logger,
+		repo:              repo,
+		accrualClient:     accrualClient,
+		orderService:      orderService,
+		expectedMigration: expectedMigration,
 	}
 }
 
 type Service struct {
-	logger config.LoggerInterface
-	repo   healthRepo.RepositoryInterface
+	logger            config.LoggerInterface
+	repo              healthRepo.RepositoryInterface
+	accrualClient     accrual.ClientInterface
+	orderService      userOrder.ServiceInterface
+	expectedMigration uint
+	shuttingDown      atomic.Bool
 }
 
 func (s *Service) Check(ctx context.Context) error {
@@ -35,3 +54,118 @@ func (s *Service) Check(ctx context.Context) error {
 	s.logger.Infow("Health check completed", "requestID", requestID)
 	return nil
 }
+
+// Live не проверяет зависимости: перезапуск процесса не вылечит недоступную БД
+func (s *Service) Live(_ context.Context) *LivenessDTO {
+	return &LivenessDTO{Status: ComponentUp}
+}
+
+// Ready проверяет компоненты параллельно. Экземпляр готов, если работают все критичные
+// компоненты; система начислений некритична — без нее заказы копятся в очереди.
+func (s *Service) Ready(ctx context.Context) *ReadinessDTO {
+	if s.shuttingDown.Load() {
+		return &ReadinessDTO{Status: StatusShuttingDown}
+	}
+
+	checks := map[string]func(context.Context) *ComponentDTO{
+		ComponentDatabase:     s.checkDatabase,
+		ComponentMigrations:   s.checkMigrations,
+		ComponentAccrual:      s.checkAccrual,
+		ComponentOrderWorkers: s.checkOrderWorkers,
+	}
+
+	var (
+		mu sync.Mutex
+		wg sync.WaitGroup
+	)
+	result := &ReadinessDTO{
+		Status:     StatusReady,
+		Components: make(map[string]*ComponentDTO, len(checks)),
+	}
+	for name, check := range checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			checkCtx, cancel := context.WithTimeout(ctx, checkTimeout)
+			defer cancel()
+			component := check(checkCtx)
+
+			mu.Lock()
+			defer mu.Unlock()
+			result.Components[name] = component
+			if component.Critical && component.Status != ComponentUp {
+				result.Status = StatusNotReady
+			}
+		}()
+	}
+	wg.Wait()
+
+	if !result.Ready() {
+		s.logger.Warnw("Readiness check failed", "requestID", base.GetRequestID(ctx), "components", result.Components)
+	}
+	return result
+}
+
+func (s *Service) StartShutdown() {
+	if !s.shuttingDown.Swap(true) {
+		s.logger.Info("Readiness switched to shutting down")
+	}
+}
+
+func (s *Service) checkDatabase(ctx context.Context) *ComponentDTO {
+	return componentFromError(s.repo.Ping(ctx), true)
+}
+
+func (s *Service) checkMigrations(ctx context.Context) *ComponentDTO {
+	version, dirty, err := s.repo.MigrationVersion(ctx)
+	if err != nil {
+		return componentFromError(err, true)
+	}
+
+	component := componentFromError(nil, true)
+	component.Version = &version
+	component.ExpectedVersion = &s.expectedMigration
+
+	switch {
+	case dirty:
+		component.Status = ComponentDown
+		component.Error = fmt.Sprintf("migration %d is dirty", version)
+	case version < s.expectedMigration:
+		component.Status = ComponentDown
+		component.Error = fmt.Sprintf("schema version %d is behind expected %d", version, s.expectedMigration)
+	}
+	// Более новая схема допустима: ее мог применить следующий релиз при поэтапном обновлении
+	return component
+}
+
+func (s *Service) checkAccrual(ctx context.Context) *ComponentDTO {
+	return componentFromError(s.accrualClient.Ping(ctx), false)
+}
+
+func (s *Service) checkOrderWorkers(_ context.Context) *ComponentDTO {
+	status := s.orderService.WorkerStatus()
+
+	component := componentFromError(nil, true)
+	component.Running = &status.Running
+	component.Total = &status.Total
+
+	switch {
+	case status.Stopped:
+		component.Status = ComponentDown
+		component.Error = "order workers are stopped"
+	case status.Running < status.Total:
+		component.Status = ComponentDown
+		component.Error = fmt.Sprintf("%d of %d order workers are running", status.Running, status.Total)
+	}
+	return component
+}
+
+func componentFromError(err error, critical bool) *ComponentDTO {
+	component := &ComponentDTO{Status: ComponentUp, Critical: critical}
+	if err != nil {
+		component.Status = ComponentDown
+		component.Error = err.Error()
+	}
+	return component
+}
@@ -1,6 +1,47 @@
 package jwt
 
+import "time"
+
 type InDTO struct {
-	ID    int    `json:"user_id"`
-	Login string `json:"user_login"`
+	ID           int    `json:"user_id"`
+	Login        string `json:"user_login"`
+	TokenVersion int    `json:"token_version"`
+	Role         string `json:"role"`
+	SessionID    int    `json:"session_id"`
+
+	// Заполняются только при аутентификации по API-ключу
+	APIKeyID int      `json:"-"`
+	Scopes   []string `json:"-"`
+
+	// Заполняются только для промежуточного токена 2FA
+	ChallengeID        string    `json:"-"`
+	ChallengeExpiresAt time.Time `json:"-"`
+}
+
+// HasRole проверяет, что пользователю назначена одна из ролей
+func (u *InDTO) HasRole(roles ...string) bool {
+	for _, role := range roles {
+		if u.Role == role {
+			return true
+		}
+	}
+	return false
+}
+
+// IsAPIKey сообщает, что пользователь аутентифицирован по API-ключу, а не по JWT
+func (u *InDTO) IsAPIKey() bool {
+	return u.APIKeyID != 0
+}
+
+// HasScope проверяет право доступа: JWT-сессия имеет все права, API-ключ — только выданные
+func (u *InDTO) HasScope(scope string) bool {
+	if !u.IsAPIKey() {
+		return true
+	}
+	for _, s := range u.Scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
 }
@@ -11,6 +11,8 @@ var (
 	ErrInvalidTokenFormat       = errors.New("invalid token format")
 	ErrInvalidTokenClaims       = errors.New("invalid token claims")
 	ErrTokenHasNoExpirationTime = errors.New("token has no expiration time")
+	ErrTokenRevoked             = errors.New("token has been revoked")
+	ErrInvalidTokenPurpose      = errors.New("invalid token purpose")
 )
 
 type ErrTokenSigning struct {
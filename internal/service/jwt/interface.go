@@ -7,4 +7,6 @@ type ServiceInterface interface {
 	ValidateToken(ctx context.Context, tokenString string) (*InDTO, error)
 	IsTokenExpired(ctx context.Context, tokenString string) (bool, error)
 	RefreshToken(ctx context.Context, tokenString string) (string, error)
+	GenerateChallengeToken(ctx context.Context, user *InDTO) (string, error)
+	ValidateChallengeToken(ctx context.Context, tokenString string) (*InDTO, error)
 }
@@ -2,6 +2,8 @@ package jwt
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/hex"
 	"fmt"
 	"gophermart-service/internal/base"
 	"gophermart-service/internal/config"
@@ -13,32 +15,56 @@ import (
 
 // Claims представляет структуру JWT claims
 type Claims struct {
-	UserID    int    `json:"user_id"`
-	UserLogin string `json:"user_login"`
+	UserID       int    `json:"user_id"`
+	UserLogin    string `json:"user_login"`
+	TokenVersion int    `json:"token_version"`
+	Role         string `json:"role,omitempty"`
+	SessionID    int    `json:"sid,omitempty"`
+	Purpose      string `json:"purpose,omitempty"`
 	jwt.RegisteredClaims
 }
 
+// PurposeChallenge помечает промежуточный токен второго шага входа (2FA)
+const PurposeChallenge = "2fa_challenge"
+
 type jwtService struct {
-	secretKey     string
-	tokenDuration time.Duration
-	issuer        string
-	algorithm     string
-	logger        config.LoggerInterface
+	secretKey              string
+	tokenDuration          time.Duration
+	challengeTokenDuration time.Duration
+	issuer                 string
+	algorithm              string
+	logger                 config.LoggerInterface
 }
 
 // NewJWTService создает новый экземпляр JWT сервиса
 func NewJWTService(settings *config.JWTSettings, logger config.LoggerInterface) ServiceInterface {
 	return &jwtService{
-		secretKey:     settings.SecretKey,
-		tokenDuration: settings.TokenDuration,
-		issuer:        settings.Issuer,
-		algorithm:     settings.Algorithm,
-		logger:        logger,
+		secretKey:              settings.SecretKey,
+		tokenDuration:          settings.TokenDuration,
+		challengeTokenDuration: settings.ChallengeTokenDuration,
+		issuer:                 settings.Issuer,
+		algorithm:              settings.Algorithm,
+		logger:                 logger,
 	}
 }
 
 // GenerateToken генерирует JWT токен для конкретного пользователя
 func (s *jwtService) GenerateToken(ctx context.Context, user *InDTO) (string, error) {
+	return s.generateToken(ctx, user, "", s.tokenDuration)
+}
+
+// GenerateChallengeToken генерирует короткоживущий токен второго шага входа.
+// Такой токен не дает доступа к API и принимается только ValidateChallengeToken.
+func (s *jwtService) GenerateChallengeToken(ctx context.Context, user *InDTO) (string, error) {
+	return s.generateToken(ctx, user, PurposeChallenge, s.challengeTokenDuration)
+}
+
+func (s *jwtService) generateToken(
+	ctx context.Context,
+	user *InDTO,
+	purpose string,
+	duration time.Duration,
+) (string, error) {
 	requestID := base.GetRequestID(ctx)
 
 	if user == nil {
@@ -49,17 +75,31 @@ func (s *jwtService) GenerateToken(ctx context.Context, user *InDTO) (string, er
 	// Создаем claims для токена
 	now := time.Now()
 	claims := Claims{
-		UserID:    user.ID,
-		UserLogin: user.Login,
+		UserID:       user.ID,
+		UserLogin:    user.Login,
+		TokenVersion: user.TokenVersion,
+		Role:         user.Role,
+		SessionID:    user.SessionID,
+		Purpose:      purpose,
 		RegisteredClaims: jwt.RegisteredClaims{
 			Issuer:    s.issuer,
 			Subject:   user.Login,
 			IssuedAt:  jwt.NewNumericDate(now),
-			ExpiresAt: jwt.NewNumericDate(now.Add(s.tokenDuration)),
+			ExpiresAt: jwt.NewNumericDate(now.Add(duration)),
 			NotBefore: jwt.NewNumericDate(now),
 		},
 	}
 
+	// Промежуточный токен одноразовый: по jti второй шаг входа отмечает его использованным
+	if purpose == PurposeChallenge {
+		tokenID, err := newTokenID()
+		if err != nil {
+			s.logger.Errorw("Failed to generate token id", "error", err, "request_id", requestID)
+			return "", err
+		}
+		claims.ID = tokenID
+	}
+
 	// Создаем токен
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
 
@@ -88,6 +128,15 @@ func (s *jwtService) GenerateToken(ctx context.Context, user *InDTO) (string, er
 
 // ValidateToken валидирует JWT токен и возвращает информацию о пользователе
 func (s *jwtService) ValidateToken(ctx context.Context, tokenString string) (*InDTO, error) {
+	return s.validateToken(ctx, tokenString, "")
+}
+
+// ValidateChallengeToken валидирует токен второго шага входа
+func (s *jwtService) ValidateChallengeToken(ctx context.Context, tokenString string) (*InDTO, error) {
+	return s.validateToken(ctx, tokenString, PurposeChallenge)
+}
+
+func (s *jwtService) validateToken(ctx context.Context, tokenString, purpose string) (*InDTO, error) {
 	requestID := base.GetRequestID(ctx)
 
 	if tokenString == "" {
@@ -132,10 +181,27 @@ func (s *jwtService) ValidateToken(ctx context.Context, tokenString string) (*In
 		return nil, ErrInvalidTokenClaims
 	}
 
+	// Токен выпущен для другой цели (например, промежуточный токен 2FA вместо токена доступа)
+	if claims.Purpose != purpose {
+		s.logger.Warnw("Unexpected token purpose", "purpose", claims.Purpose, "request_id", requestID)
+		return nil, ErrInvalidTokenPurpose
+	}
+
 	// Создаем модель пользователя
 	user := &InDTO{
-		ID:    claims.UserID,
-		Login: claims.UserLogin,
+		ID:           claims.UserID,
+		Login:        claims.UserLogin,
+		TokenVersion: claims.TokenVersion,
+		Role:         claims.Role,
+		SessionID:    claims.SessionID,
+	}
+	if purpose == PurposeChallenge {
+		if claims.ID == "" || claims.ExpiresAt == nil {
+			s.logger.Warnw("Challenge token without id", "request_id", requestID)
+			return nil, ErrInvalidTokenClaims
+		}
+		user.ChallengeID = claims.ID
+		user.ChallengeExpiresAt = claims.ExpiresAt.Time
 	}
 
 	s.logger.Debugw(
@@ -237,3 +303,11 @@ func (s *jwtService) GetTokenExpirationTime(ctx context.Context, tokenString str
 
 	return &claims.ExpiresAt.Time, nil
 }
+
+func newTokenID() (string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
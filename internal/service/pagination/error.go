@@ -0,0 +1,13 @@
+package pagination
+
+import "errors"
+
+var (
+	ErrInvalidCursor    = errors.New("pagination cursor is invalid")
+	ErrInvalidSort      = errors.New("unsupported sort field")
+	ErrInvalidDateRange = errors.New("invalid date range, expected RFC 3339 timestamp or YYYY-MM-DD date")
+)
+
+func IsErrInvalidCursor(err error) bool    { return errors.Is(err, ErrInvalidCursor) }
+func IsErrInvalidSort(err error) bool      { return errors.Is(err, ErrInvalidSort) }
+func IsErrInvalidDateRange(err error) bool { return errors.Is(err, ErrInvalidDateRange) }
@@ -0,0 +1,155 @@
+package pagination
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+const (
+	DefaultLimit = 100
+	MaxLimit     = 100
+)
+
+const dateLayout = "2006-01-02"
+
+// Sort поле и направление сортировки списка. В запросе задается как "field" или "-field" (по убыванию)
+type Sort struct {
+	Field string
+	Desc  bool
+}
+
+func (s Sort) String() string {
+	if s.Desc {
+		return "-" + s.Field
+	}
+	return s.Field
+}
+
+// ParseSort разбирает параметр сортировки, допуская только поля из allowed
+func ParseSort(raw string, allowed []string, defaultSort Sort) (Sort, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return defaultSort, nil
+	}
+
+	sort := Sort{Field: strings.TrimPrefix(raw, "-"), Desc: strings.HasPrefix(raw, "-")}
+	if !slices.Contains(allowed, sort.Field) {
+		return Sort{}, fmt.Errorf("%w: %s", ErrInvalidSort, sort.Field)
+	}
+
+	return sort, nil
+}
+
+// Cursor непрозрачный для клиента указатель на последнюю запись страницы.
+// Хранит значение поля сортировки и id записи, чтобы продолжить выборку по ключу (keyset).
+type Cursor struct {
+	Sort  string `json:"s"`
+	Value string `json:"v"`
+	ID    int    `json:"id"`
+}
+
+// Encode кодирует курсор в строку для передачи клиенту
+func (c *Cursor) Encode() string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// DecodeCursor восстанавливает курсор и проверяет, что он выдан для той же сортировки
+func DecodeCursor(raw string, sort Sort) (*Cursor, error) {
+	if raw == "" {
+		return nil, nil
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(raw)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	var cursor Cursor
+	if err = json.Unmarshal(data, &cursor); err != nil || cursor.ID <= 0 {
+		return nil, ErrInvalidCursor
+	}
+	if cursor.Sort != sort.String() {
+		return nil, fmt.Errorf("%w: cursor was issued for sort %q", ErrInvalidCursor, cursor.Sort)
+	}
+
+	return &cursor, nil
+}
+
+// TimeValue возвращает значение курсора для сортировки по времени
+func (c *Cursor) TimeValue() (time.Time, error) {
+	value, err := time.Parse(time.RFC3339Nano, c.Value)
+	if err != nil {
+		return time.Time{}, ErrInvalidCursor
+	}
+	return value, nil
+}
+
+// FormatTime форматирует значение поля сортировки по времени для курсора
+func FormatTime(value time.Time) string {
+	return value.UTC().Format(time.RFC3339Nano)
+}
+
+// FormatAmount форматирует денежное значение поля сортировки для курсора
+func FormatAmount(value float32) string {
+	return fmt.Sprintf("%.2f", value)
+}
+
+// DateRange полуинтервал [From, To) по времени; пустые границы не ограничивают выборку
+type DateRange struct {
+	From *time.Time
+	To   *time.Time
+}
+
+// ParseDateRange разбирает границы периода. Дата без времени в to включает весь день.
+func ParseDateRange(from, to string) (DateRange, error) {
+	var result DateRange
+
+	if from != "" {
+		value, _, err := parseBound(from)
+		if err != nil {
+			return DateRange{}, err
+		}
+		result.From = &value
+	}
+	if to != "" {
+		value, dateOnly, err := parseBound(to)
+		if err != nil {
+			return DateRange{}, err
+		}
+		if dateOnly {
+			value = value.AddDate(0, 0, 1)
+		}
+		result.To = &value
+	}
+	if result.From != nil && result.To != nil && !result.From.Before(*result.To) {
+		return DateRange{}, fmt.Errorf("%w: from must be before to", ErrInvalidDateRange)
+	}
+
+	return result, nil
+}
+
+func parseBound(raw string) (time.Time, bool, error) {
+	if value, err := time.Parse(time.RFC3339, raw); err == nil {
+		return value, false, nil
+	}
+	if value, err := time.Parse(dateLayout, raw); err == nil {
+		return value, true, nil
+	}
+	return time.Time{}, false, fmt.Errorf("%w: %s", ErrInvalidDateRange, raw)
+}
+
+// NormalizeLimit приводит размер страницы к допустимому диапазону
+func NormalizeLimit(limit int) int {
+	if limit <= 0 {
+		return DefaultLimit
+	}
+	if limit > MaxLimit {
+		return MaxLimit
+	}
+	return limit
+}
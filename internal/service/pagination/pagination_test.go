@@ -0,0 +1,159 @@
+package pagination
+
+import (
+	"encoding/base64"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestCursorRoundTrip(t *testing.T) {
+	sort := Sort{Field: "uploaded_at", Desc: true}
+	uploadedAt := time.Date(2026, 1, 31, 12, 30, 0, 123456789, time.FixedZone("MSK", 3*60*60))
+	cursor := &Cursor{Sort: sort.String(), Value: FormatTime(uploadedAt), ID: 42}
+
+	decoded, err := DecodeCursor(cursor.Encode(), sort)
+	if err != nil {
+		t.Fatalf("DecodeCursor: %v", err)
+	}
+	if *decoded != *cursor {
+		t.Fatalf("decoded = %+v, want %+v", decoded, cursor)
+	}
+
+	value, err := decoded.TimeValue()
+	if err != nil {
+		t.Fatalf("TimeValue: %v", err)
+	}
+	if !value.Equal(uploadedAt) {
+		t.Errorf("time value = %v, want %v", value, uploadedAt)
+	}
+}
+
+func TestDecodeCursor(t *testing.T) {
+	sort := Sort{Field: "sum", Desc: true}
+	encode := func(raw string) string { return base64.RawURLEncoding.EncodeToString([]byte(raw)) }
+
+	tests := []struct {
+		name    string
+		raw     string
+		wantNil bool
+		wantErr error
+	}{
+		{name: "empty means first page", raw: "", wantNil: true},
+		{name: "valid", raw: (&Cursor{Sort: "-sum", Value: FormatAmount(751.5), ID: 7}).Encode()},
+		{name: "not base64", raw: "%%%", wantErr: ErrInvalidCursor},
+		{name: "not json", raw: encode("cursor"), wantErr: ErrInvalidCursor},
+		{name: "missing id", raw: encode(`{"s":"-sum","v":"1.00"}`), wantErr: ErrInvalidCursor},
+		{name: "negative id", raw: encode(`{"s":"-sum","v":"1.00","id":-1}`), wantErr: ErrInvalidCursor},
+		{name: "issued for another sort", raw: (&Cursor{Sort: "sum", Value: "1.00", ID: 1}).Encode(), wantErr: ErrInvalidCursor},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cursor, err := DecodeCursor(tt.raw, sort)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr == nil && (cursor == nil) != tt.wantNil {
+				t.Errorf("cursor = %+v, want nil: %v", cursor, tt.wantNil)
+			}
+		})
+	}
+}
+
+func TestCursorTimeValueInvalid(t *testing.T) {
+	if _, err := (&Cursor{Value: "yesterday"}).TimeValue(); !errors.Is(err, ErrInvalidCursor) {
+		t.Fatalf("error = %v, want %v", err, ErrInvalidCursor)
+	}
+}
+
+func TestParseSort(t *testing.T) {
+	allowed := []string{"uploaded_at", "accrual"}
+	defaultSort := Sort{Field: "uploaded_at", Desc: true}
+
+	tests := []struct {
+		raw     string
+		want    Sort
+		wantErr error
+	}{
+		{raw: "", want: defaultSort},
+		{raw: "accrual", want: Sort{Field: "accrual"}},
+		{raw: "-accrual", want: Sort{Field: "accrual", Desc: true}},
+		{raw: " uploaded_at ", want: Sort{Field: "uploaded_at"}},
+		{raw: "status", wantErr: ErrInvalidSort},
+		{raw: "--accrual", wantErr: ErrInvalidSort},
+	}
+	for _, tt := range tests {
+		t.Run(tt.raw, func(t *testing.T) {
+			got, err := ParseSort(tt.raw, allowed, defaultSort)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("error = %v, want %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("sort = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseDateRange(t *testing.T) {
+	date := func(value string) *time.Time {
+		parsed, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			t.Fatalf("parse %s: %v", value, err)
+		}
+		return &parsed
+	}
+
+	tests := []struct {
+		name     string
+		from, to string
+		want     DateRange
+		wantErr  error
+	}{
+		{name: "unbounded"},
+		{name: "date to includes the whole day", from: "2026-01-01", to: "2026-01-31",
+			want: DateRange{From: date("2026-01-01T00:00:00Z"), To: date("2026-02-01T00:00:00Z")}},
+		{name: "timestamps are exact", from: "2026-01-01T10:00:00Z", to: "2026-01-01T12:00:00Z",
+			want: DateRange{From: date("2026-01-01T10:00:00Z"), To: date("2026-01-01T12:00:00Z")}},
+		{name: "same day", from: "2026-01-31", to: "2026-01-31",
+			want: DateRange{From: date("2026-01-31T00:00:00Z"), To: date("2026-02-01T00:00:00Z")}},
+		{name: "from after to", from: "2026-02-01", to: "2026-01-01", wantErr: ErrInvalidDateRange},
+		{name: "unknown format", from: "31.01.2026", wantErr: ErrInvalidDateRange},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseDateRange(tt.from, tt.to)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("error = %v, want %v", err, tt.wantErr)
+			}
+			if !equalTime(got.From, tt.want.From) || !equalTime(got.To, tt.want.To) {
+				t.Errorf("range = [%v, %v), want [%v, %v)", got.From, got.To, tt.want.From, tt.want.To)
+			}
+		})
+	}
+}
+
+func TestNormalizeLimit(t *testing.T) {
+	tests := []struct {
+		limit int
+		want  int
+	}{
+		{limit: 0, want: DefaultLimit},
+		{limit: -5, want: DefaultLimit},
+		{limit: 1, want: 1},
+		{limit: MaxLimit, want: MaxLimit},
+		{limit: MaxLimit + 1, want: MaxLimit},
+	}
+	for _, tt := range tests {
+		if got := NormalizeLimit(tt.limit); got != tt.want {
+			t.Errorf("NormalizeLimit(%d) = %d, want %d", tt.limit, got, tt.want)
+		}
+	}
+}
+
+func equalTime(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Equal(*b)
+}
@@ -0,0 +1,86 @@
+package account
+
+import "time"
+
+// DeleteInDTO подтверждает удаление учетной записи паролем.
+// Пользователи без пароля (вход через OIDC) подтверждают удаление своим логином.
+type DeleteInDTO struct {
+	Password     string `json:"password"`
+	ConfirmLogin string `json:"confirm_login"`
+}
+
+// ExportOutDTO представляет выгрузку всех данных пользователя
+type ExportOutDTO struct {
+	ExportedAt  time.Time          `json:"exported_at"`
+	Profile     *ProfileDTO        `json:"profile"`
+	Balance     *BalanceDTO        `json:"balance"`
+	Orders      []*OrderDTO        `json:"orders"`
+	Withdrawals []*WithdrawalDTO   `json:"withdrawals"`
+	Sessions    []*SessionDTO      `json:"sessions"`
+	APIKeys     []*APIKeyDTO       `json:"api_keys"`
+	Identities  []*IdentityDTO     `json:"identities"`
+	TwoFactor   *TwoFactorStateDTO `json:"two_factor"`
+}
+
+type ProfileDTO struct {
+	ID                 int        `json:"id"`
+	Login              string     `json:"login"`
+	Role               string     `json:"role"`
+	DisplayName        *string    `json:"display_name"`
+	Email              *string    `json:"email"`
+	EmailVerifiedAt    *time.Time `json:"email_verified_at"`
+	Phone              *string    `json:"phone"`
+	MarketingConsent   bool       `json:"marketing_consent"`
+	MarketingConsentAt *time.Time `json:"marketing_consent_at"`
+	CreatedAt          time.Time  `json:"created_at"`
+}
+
+type BalanceDTO struct {
+	Current   float32 `json:"current"`
+	Withdrawn float32 `json:"withdrawn"`
+}
+
+type OrderDTO struct {
+	Number     string    `json:"number"`
+	Status     string    `json:"status"`
+	Accrual    float32   `json:"accrual"`
+	UploadedAt time.Time `json:"uploaded_at"`
+}
+
+type WithdrawalDTO struct {
+	Order       string    `json:"order"`
+	Sum         float32   `json:"sum"`
+	ProcessedAt time.Time `json:"processed_at"`
+}
+
+type SessionDTO struct {
+	ID         int        `json:"id"`
+	UserAgent  string     `json:"user_agent"`
+	IPAddress  string     `json:"ip_address"`
+	CreatedAt  time.Time  `json:"created_at"`
+	LastSeenAt time.Time  `json:"last_seen_at"`
+	ExpiresAt  time.Time  `json:"expires_at"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty"`
+}
+
+type APIKeyDTO struct {
+	Name       string     `json:"name"`
+	Prefix     string     `json:"prefix"`
+	Scopes     []string   `json:"scopes"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+type IdentityDTO struct {
+	Issuer      string     `json:"issuer"`
+	Subject     string     `json:"subject"`
+	Email       string     `json:"email,omitempty"`
+	LastLoginAt *time.Time `json:"last_login_at,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
+
+type TwoFactorStateDTO struct {
+	Enabled     bool       `json:"enabled"`
+	ConfirmedAt *time.Time `json:"confirmed_at,omitempty"`
+}
@@ -0,0 +1,13 @@
+package account
+
+import "errors"
+
+var (
+	ErrUserNotFound         = errors.New("user not found")
+	ErrConfirmationRequired = errors.New("password confirmation is required")
+	ErrWrongConfirmation    = errors.New("account deletion is not confirmed")
+)
+
+func IsErrUserNotFound(err error) bool         { return errors.Is(err, ErrUserNotFound) }
+func IsErrConfirmationRequired(err error) bool { return errors.Is(err, ErrConfirmationRequired) }
+func IsErrWrongConfirmation(err error) bool    { return errors.Is(err, ErrWrongConfirmation) }
@@ -0,0 +1,10 @@
+package account
+
+import "context"
+
+type ServiceInterface interface {
+	// Export собирает все данные о пользователе для выгрузки по запросу субъекта данных
+	Export(ctx context.Context, userID int) (*ExportOutDTO, error)
+	// Delete обезличивает учетную запись, сохраняя финансовые записи
+	Delete(ctx context.Context, userID int, dtoIn *DeleteInDTO) error
+}
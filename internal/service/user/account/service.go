@@ -0,0 +1,268 @@
+package account
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"gophermart-service/internal/base"
+	"gophermart-service/internal/config"
+	apiKeysRepo "gophermart-service/internal/repository/apikeys"
+	identitiesRepo "gophermart-service/internal/repository/identities"
+	ordersRepo "gophermart-service/internal/repository/orders"
+	sessionsRepo "gophermart-service/internal/repository/sessions"
+	twoFactorRepo "gophermart-service/internal/repository/twofactor"
+	usersRepo "gophermart-service/internal/repository/users"
+	viewsRepo "gophermart-service/internal/repository/views"
+	withdrawRepo "gophermart-service/internal/repository/withdraw"
+	userAuth "gophermart-service/internal/service/user/auth"
+	"time"
+)
+
+const exportOrdersPageSize = 500
+
+// Service представляет сервис выгрузки данных и удаления учетной записи
+type Service struct {
+	logger         config.LoggerInterface
+	usersRepo      usersRepo.RepositoryInterface
+	ordersRepo     ordersRepo.RepositoryInterface
+	withdrawRepo   withdrawRepo.RepositoryInterface
+	viewsRepo      viewsRepo.RepositoryInterface
+	sessionsRepo   sessionsRepo.RepositoryInterface
+	apiKeysRepo    apiKeysRepo.RepositoryInterface
+	identitiesRepo identitiesRepo.RepositoryInterface
+	twoFactorRepo  twoFactorRepo.RepositoryInterface
+	authService    userAuth.ServiceInterface
+}
+
+// NewAccountService создает новый экземпляр сервиса учетной записи
+func NewAccountService(
+	logger config.LoggerInterface,
+	usersRepo usersRepo.RepositoryInterface,
+	ordersRepo ordersRepo.RepositoryInterface,
+	withdrawRepo withdrawRepo.RepositoryInterface,
+	viewsRepo viewsRepo.RepositoryInterface,
+	sessionsRepo sessionsRepo.RepositoryInterface,
+	apiKeysRepo apiKeysRepo.RepositoryInterface,
+	identitiesRepo identitiesRepo.RepositoryInterface,
+	twoFactorRepo twoFactorRepo.RepositoryInterface,
+	authService userAuth.ServiceInterface,
+) ServiceInterface {
+	return &Service{
+		logger:         logger,
+		usersRepo:      usersRepo,
+		ordersRepo:     ordersRepo,
+		withdrawRepo:   withdrawRepo,
+		viewsRepo:      viewsRepo,
+		sessionsRepo:   sessionsRepo,
+		apiKeysRepo:    apiKeysRepo,
+		identitiesRepo: identitiesRepo,
+		twoFactorRepo:  twoFactorRepo,
+		authService:    authService,
+	}
+}
+
+func (s *Service) Export(ctx context.Context, userID int) (*ExportOutDTO, error) {
+	requestID := base.GetRequestID(ctx)
+
+	user, err := s.usersRepo.GetUser(ctx, userID)
+	if err != nil {
+		if usersRepo.IsErrUserNotFound(err) {
+			return nil, ErrUserNotFound
+		}
+		return nil, err
+	}
+
+	profile, err := s.usersRepo.GetProfile(ctx, userID)
+	if err != nil {
+		return nil, s.exportError(requestID, "profile", err)
+	}
+
+	result := &ExportOutDTO{
+		ExportedAt: time.Now().UTC(),
+		Profile: &ProfileDTO{
+			ID:                 user.ID,
+			Login:              user.Login,
+			Role:               user.Role,
+			DisplayName:        profile.DisplayName,
+			Email:              profile.Email,
+			EmailVerifiedAt:    profile.EmailVerifiedAt,
+			Phone:              profile.Phone,
+			MarketingConsent:   profile.MarketingConsent,
+			MarketingConsentAt: profile.MarketingConsentAt,
+			CreatedAt:          user.CreatedAt,
+		},
+		TwoFactor: &TwoFactorStateDTO{},
+	}
+
+	if result.Orders, err = s.exportOrders(ctx, userID); err != nil {
+		return nil, s.exportError(requestID, "orders", err)
+	}
+
+	withdrawals, err := s.withdrawRepo.GetUserWithdrawals(ctx, userID)
+	if err != nil {
+		return nil, s.exportError(requestID, "withdrawals", err)
+	}
+	result.Withdrawals = make([]*WithdrawalDTO, 0, len(withdrawals))
+	for _, withdrawal := range withdrawals {
+		result.Withdrawals = append(result.Withdrawals, &WithdrawalDTO{
+			Order:       withdrawal.Order,
+			Sum:         withdrawal.Sum,
+			ProcessedAt: withdrawal.ProcessedAt,
+		})
+	}
+
+	balance, err := s.viewsRepo.GetUserBalance(ctx, userID)
+	if err != nil {
+		return nil, s.exportError(requestID, "balance", err)
+	}
+	result.Balance = &BalanceDTO{
+		Current:   balance.CurrentBalance,
+		Withdrawn: float32(balance.TotalWithdrawn),
+	}
+
+	sessions, err := s.sessionsRepo.GetUserSessions(ctx, userID)
+	if err != nil {
+		return nil, s.exportError(requestID, "sessions", err)
+	}
+	result.Sessions = make([]*SessionDTO, 0, len(sessions))
+	for _, session := range sessions {
+		result.Sessions = append(result.Sessions, &SessionDTO{
+			ID:         session.ID,
+			UserAgent:  session.UserAgent,
+			IPAddress:  session.IPAddress,
+			CreatedAt:  session.CreatedAt,
+			LastSeenAt: session.LastSeenAt,
+			ExpiresAt:  session.ExpiresAt,
+			RevokedAt:  session.RevokedAt,
+		})
+	}
+
+	apiKeys, err := s.apiKeysRepo.GetUserAPIKeys(ctx, userID)
+	if err != nil {
+		return nil, s.exportError(requestID, "api keys", err)
+	}
+	result.APIKeys = make([]*APIKeyDTO, 0, len(apiKeys))
+	for _, apiKey := range apiKeys {
+		result.APIKeys = append(result.APIKeys, &APIKeyDTO{
+			Name:       apiKey.Name,
+			Prefix:     apiKey.Prefix,
+			Scopes:     apiKey.Scopes,
+			LastUsedAt: apiKey.LastUsedAt,
+			RevokedAt:  apiKey.RevokedAt,
+			CreatedAt:  apiKey.CreatedAt,
+		})
+	}
+
+	identities, err := s.identitiesRepo.GetUserIdentities(ctx, userID)
+	if err != nil {
+		return nil, s.exportError(requestID, "identities", err)
+	}
+	result.Identities = make([]*IdentityDTO, 0, len(identities))
+	for _, identity := range identities {
+		result.Identities = append(result.Identities, &IdentityDTO{
+			Issuer:      identity.Issuer,
+			Subject:     identity.Subject,
+			Email:       identity.Email,
+			LastLoginAt: identity.LastLoginAt,
+			CreatedAt:   identity.CreatedAt,
+		})
+	}
+
+	totp, err := s.twoFactorRepo.GetTOTP(ctx, userID)
+	if err != nil && !twoFactorRepo.IsErrTOTPNotFound(err) {
+		return nil, s.exportError(requestID, "two-factor", err)
+	}
+	if totp != nil {
+		result.TwoFactor = &TwoFactorStateDTO{Enabled: totp.Enabled, ConfirmedAt: totp.ConfirmedAt}
+	}
+
+	s.logger.Infow("User data exported", "requestID", requestID, "userID", userID)
+	return result, nil
+}
+
+func (s *Service) Delete(ctx context.Context, userID int, dtoIn *DeleteInDTO) error {
+	requestID := base.GetRequestID(ctx)
+
+	if err := s.confirmDeletion(ctx, userID, dtoIn); err != nil {
+		return err
+	}
+
+	// Логин освобождается для повторной регистрации, хеш пароля заменяется на непригодное значение
+	suffix := make([]byte, 8)
+	if _, err := rand.Read(suffix); err != nil {
+		return err
+	}
+	login := fmt.Sprintf("%s%d", userAuth.DeletedLoginPrefix, userID)
+	unusableHash := "!deleted-" + hex.EncodeToString(suffix)
+
+	if err := s.usersRepo.Anonymize(ctx, userID, login, unusableHash); err != nil {
+		if usersRepo.IsErrUserNotFound(err) {
+			return ErrUserNotFound
+		}
+		s.logger.Errorw("Failed to anonymize user", "requestID", requestID, "userID", userID, "error", err)
+		return err
+	}
+
+	s.logger.Infow("User account deleted", "requestID", requestID, "userID", userID)
+	return nil
+}
+
+func (s *Service) confirmDeletion(ctx context.Context, userID int, dtoIn *DeleteInDTO) error {
+	hashPassword, err := s.usersRepo.GetUserHashPasswordByID(ctx, userID)
+	if err != nil {
+		if usersRepo.IsErrUserNotFound(err) {
+			return ErrUserNotFound
+		}
+		return err
+	}
+
+	// Учетные записи, созданные через OIDC, не имеют пароля
+	if len(hashPassword) > 0 && hashPassword[0] == '!' {
+		login, err := s.usersRepo.GetLoginByID(ctx, userID)
+		if err != nil {
+			return err
+		}
+		if dtoIn.ConfirmLogin == "" {
+			return ErrConfirmationRequired
+		}
+		if dtoIn.ConfirmLogin != login {
+			return ErrWrongConfirmation
+		}
+		return nil
+	}
+
+	if dtoIn.Password == "" {
+		return ErrConfirmationRequired
+	}
+	if err = s.authService.VerifyPassword(hashPassword, dtoIn.Password); err != nil {
+		return ErrWrongConfirmation
+	}
+	return nil
+}
+
+func (s *Service) exportOrders(ctx context.Context, userID int) ([]*OrderDTO, error) {
+	result := make([]*OrderDTO, 0)
+	for offset := 0; ; offset += exportOrdersPageSize {
+		orders, err := s.ordersRepo.GetUserOrders(ctx, userID, exportOrdersPageSize, offset)
+		if err != nil {
+			return nil, err
+		}
+		for _, order := range orders {
+			result = append(result, &OrderDTO{
+				Number:     order.OrderNumber,
+				Status:     order.Status,
+				Accrual:    order.Accrual,
+				UploadedAt: order.UploadedAt,
+			})
+		}
+		if len(orders) < exportOrdersPageSize {
+			return result, nil
+		}
+	}
+}
+
+func (s *Service) exportError(requestID, section string, err error) error {
+	s.logger.Errorw("Failed to export user data", "requestID", requestID, "section", section, "error", err)
+	return err
+}
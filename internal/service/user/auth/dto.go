@@ -3,44 +3,59 @@ package auth
 import "strings"
 
 const (
-	MaxLoginLength    = 50
-	MaxPasswordLength = 100
-	MinLoginLength    = 3
-	MinPasswordLength = 6
+	MaxLoginLength = 50
+	MinLoginLength = 3
+
+	// DeletedLoginPrefix префикс логинов обезличенных учетных записей; зарегистрировать такой логин нельзя,
+	// иначе занятый логин помешал бы удалению учетной записи
+	DeletedLoginPrefix = "deleted-"
 )
 
+// IsReservedLogin сообщает, что логин зарезервирован для служебных учетных записей
+func IsReservedLogin(login string) bool {
+	return strings.HasPrefix(strings.ToLower(strings.TrimSpace(login)), DeletedLoginPrefix)
+}
+
 // InDTO представляет запрос на регистрацию пользователя
 type InDTO struct {
 	Login    string `json:"login" binding:"required"`
 	Password string `json:"password" binding:"required"`
 }
 
-// validateRequest выполняет валидацию запроса регистрации
-func (in *InDTO) validateRequest() error {
-	// Проверка на пустые поля
+// validateCredentials выполняет валидацию запроса входа. Политика сложности здесь не применяется:
+// иначе ее изменение закрыло бы вход пользователям со старыми паролями. Ограничение длины
+// защищает от DoS дорогим хешированием; для пароля это настроенный максимум в байтах
+func (in *InDTO) validateCredentials(maxPasswordBytes int) error {
 	if strings.TrimSpace(in.Login) == "" {
 		return ErrLoginIsRequired
 	}
-	if strings.TrimSpace(in.Password) == "" {
+	if in.Password == "" {
 		return ErrPasswordIsRequired
 	}
 
-	// Проверка минимальной длины логина
-	if len(in.Login) < MinLoginLength {
-		return ErrLoginTooShort
+	if len(in.Login) > MaxLoginLength {
+		return ErrLoginTooLong
 	}
-
-	// Проверка минимальной длины пароля
-	if len(in.Password) < MinPasswordLength {
-		return ErrPasswordTooShort
+	if len(in.Password) > maxPasswordBytes {
+		return ErrPasswordTooLong
 	}
 
-	// Проверка максимальной длины (защита от DoS атак)
+	return nil
+}
+
+// validateLogin выполняет валидацию логина; пароль при регистрации проверяется политикой сложности
+func (in *InDTO) validateLogin() error {
+	if strings.TrimSpace(in.Login) == "" {
+		return ErrLoginIsRequired
+	}
+	if len(in.Login) < MinLoginLength {
+		return ErrLoginTooShort
+	}
 	if len(in.Login) > MaxLoginLength {
 		return ErrLoginTooLong
 	}
-	if len(in.Password) > MaxPasswordLength {
-		return ErrPasswordTooLong
+	if IsReservedLogin(in.Login) {
+		return ErrLoginReserved
 	}
 
 	return nil
@@ -48,5 +63,7 @@ func (in *InDTO) validateRequest() error {
 
 // OutDTO представляет ответ при успешной регистрации
 type OutDTO struct {
-	UserID int `json:"user_id"`
+	UserID       int    `json:"user_id"`
+	TokenVersion int    `json:"token_version"`
+	Role         string `json:"role"`
 }
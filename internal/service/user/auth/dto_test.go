@@ -0,0 +1,62 @@
+package auth
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestInDTOValidateLogin(t *testing.T) {
+	tests := []struct {
+		name    string
+		login   string
+		wantErr error
+	}{
+		{name: "valid", login: "alice"},
+		{name: "minimum length", login: "bob"},
+		{name: "empty", login: "  ", wantErr: ErrLoginIsRequired},
+		{name: "too short", login: "al", wantErr: ErrLoginTooShort},
+		{name: "too long", login: strings.Repeat("a", MaxLoginLength+1), wantErr: ErrLoginTooLong},
+		{name: "anonymised login", login: "deleted-42", wantErr: ErrLoginReserved},
+		{name: "anonymised login in other case", login: "Deleted-42", wantErr: ErrLoginReserved},
+		{name: "prefix inside login", login: "undeleted-42"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dtoIn := &InDTO{Login: tt.login, Password: "password"}
+			if err := dtoIn.validateLogin(); !errors.Is(err, tt.wantErr) {
+				t.Errorf("validateLogin(%q) error = %v, want %v", tt.login, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestInDTOValidateCredentials(t *testing.T) {
+	const maxPasswordBytes = 72
+
+	tests := []struct {
+		name     string
+		login    string
+		password string
+		wantErr  error
+	}{
+		{name: "valid", login: "alice", password: "secret-password"},
+		// Вход не применяет политику: пароли, заданные по прежним правилам, продолжают работать
+		{name: "shorter than policy minimum", login: "alice", password: "abc"},
+		{name: "short login", login: "al", password: "secret-password"},
+		{name: "maximum length", login: "alice", password: strings.Repeat("a", maxPasswordBytes)},
+		{name: "empty login", login: "  ", password: "secret-password", wantErr: ErrLoginIsRequired},
+		{name: "empty password", login: "alice", wantErr: ErrPasswordIsRequired},
+		{name: "login too long", login: strings.Repeat("a", MaxLoginLength+1), password: "secret-password", wantErr: ErrLoginTooLong},
+		{name: "password too long", login: "alice", password: strings.Repeat("a", maxPasswordBytes+1), wantErr: ErrPasswordTooLong},
+		{name: "password length counted in bytes", login: "alice", password: strings.Repeat("п", 40), wantErr: ErrPasswordTooLong},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dtoIn := &InDTO{Login: tt.login, Password: tt.password}
+			if err := dtoIn.validateCredentials(maxPasswordBytes); !errors.Is(err, tt.wantErr) {
+				t.Errorf("validateCredentials error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -1,6 +1,9 @@
 package auth
 
-import "errors"
+import (
+	"errors"
+	"strings"
+)
 
 var (
 	ErrLoginIsRequired        = errors.New("login is required")
@@ -8,13 +11,57 @@ var (
 	ErrPasswordTooShort       = errors.New("password is too short")
 	ErrLoginTooShort          = errors.New("login is too short")
 	ErrLoginTooLong           = errors.New("login is too long")
+	ErrLoginReserved          = errors.New("login is reserved")
 	ErrPasswordTooLong        = errors.New("password is too long")
 	ErrFiledToProcessPassword = errors.New("filed to process password")
 	ErrUserLoginAlreadyExists = errors.New("user login already exists")
 	ErrBadPassword            = errors.New("bad password")
 	ErrUserNotFound           = errors.New("user not found")
+
+	ErrPasswordNoUppercase   = errors.New("password must contain an uppercase letter")
+	ErrPasswordNoLowercase   = errors.New("password must contain a lowercase letter")
+	ErrPasswordNoDigit       = errors.New("password must contain a digit")
+	ErrPasswordNoSpecial     = errors.New("password must contain a special character")
+	ErrPasswordContainsLogin = errors.New("password must not contain the login")
+	ErrPasswordTooCommon     = errors.New("password is too common")
 )
 
+// PasswordPolicyError содержит все правила политики сложности, которым не соответствует пароль
+type PasswordPolicyError struct {
+	Violations []error
+}
+
+func (e *PasswordPolicyError) Error() string {
+	return "password does not meet the policy: " + strings.Join(e.Messages(), "; ")
+}
+
+func (e *PasswordPolicyError) Unwrap() []error {
+	return e.Violations
+}
+
+// Messages возвращает тексты нарушенных правил
+func (e *PasswordPolicyError) Messages() []string {
+	messages := make([]string, 0, len(e.Violations))
+	for _, violation := range e.Violations {
+		messages = append(messages, violation.Error())
+	}
+	return messages
+}
+
+func IsErrPasswordPolicy(err error) bool {
+	var policyErr *PasswordPolicyError
+	return errors.As(err, &policyErr)
+}
+
+// PasswordPolicyViolations возвращает тексты нарушенных правил политики или nil
+func PasswordPolicyViolations(err error) []string {
+	var policyErr *PasswordPolicyError
+	if errors.As(err, &policyErr) {
+		return policyErr.Messages()
+	}
+	return nil
+}
+
 func IsErrLoginIsRequired(err error) bool {
 	return errors.Is(err, ErrLoginIsRequired)
 }
@@ -35,6 +82,10 @@ func IsErrLoginTooLong(err error) bool {
 	return errors.Is(err, ErrLoginTooLong)
 }
 
+func IsErrLoginReserved(err error) bool {
+	return errors.Is(err, ErrLoginReserved)
+}
+
 func IsErrPasswordTooLong(err error) bool {
 	return errors.Is(err, ErrPasswordTooLong)
 }
@@ -6,4 +6,7 @@ type ServiceInterface interface {
 	RegisterUser(ctx context.Context, dtoIn *InDTO) (*OutDTO, error)
 	LoginUser(ctx context.Context, dtoIn *InDTO) (*OutDTO, error)
 	HashPassword(password string) (string, error)
+	VerifyPassword(hashedPassword, password string) error
+	ValidateNewPassword(login, password string) error
+	GetTokenVersion(ctx context.Context, userID int) (int, error)
 }
@@ -0,0 +1,102 @@
+package auth
+
+import (
+	_ "embed"
+	"fmt"
+	"gophermart-service/internal/config"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// commonPasswords содержит список самых распространенных паролей из публичных утечек
+//
+//go:embed common_passwords.txt
+var commonPasswords string
+
+// PasswordPolicy проверяет пароль на соответствие настроенной политике сложности
+type PasswordPolicy struct {
+	settings *config.PasswordSettings
+	common   map[string]struct{}
+}
+
+// NewPasswordPolicy создает политику сложности пароля
+func NewPasswordPolicy(settings *config.PasswordSettings) *PasswordPolicy {
+	common := make(map[string]struct{})
+	for _, line := range strings.Split(commonPasswords, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			common[strings.ToLower(line)] = struct{}{}
+		}
+	}
+
+	return &PasswordPolicy{
+		settings: settings,
+		common:   common,
+	}
+}
+
+// Validate проверяет пароль по всем правилам политики и возвращает
+// *PasswordPolicyError со списком всех нарушенных правил
+func (p *PasswordPolicy) Validate(login, password string) error {
+	if strings.TrimSpace(password) == "" {
+		return ErrPasswordIsRequired
+	}
+
+	var violations []error
+
+	// Минимум считается в символах, максимум — в байтах: хешер ограничивает именно байты
+	if utf8.RuneCountInString(password) < p.settings.PolicyMinLength {
+		violations = append(violations,
+			fmt.Errorf("%w: minimum %d characters", ErrPasswordTooShort, p.settings.PolicyMinLength))
+	}
+	if maxBytes := p.MaxBytes(); len(password) > maxBytes {
+		violations = append(violations,
+			fmt.Errorf("%w: maximum %d bytes", ErrPasswordTooLong, maxBytes))
+	}
+
+	var hasUpper, hasLower, hasDigit, hasSpecial bool
+	for _, r := range password {
+		switch {
+		case unicode.IsUpper(r):
+			hasUpper = true
+		case unicode.IsLower(r):
+			hasLower = true
+		case unicode.IsDigit(r):
+			hasDigit = true
+		case unicode.IsPunct(r) || unicode.IsSymbol(r) || unicode.IsSpace(r):
+			hasSpecial = true
+		}
+	}
+	if p.settings.PolicyRequireUppercase && !hasUpper {
+		violations = append(violations, ErrPasswordNoUppercase)
+	}
+	if p.settings.PolicyRequireLowercase && !hasLower {
+		violations = append(violations, ErrPasswordNoLowercase)
+	}
+	if p.settings.PolicyRequireDigit && !hasDigit {
+		violations = append(violations, ErrPasswordNoDigit)
+	}
+	if p.settings.PolicyRequireSpecial && !hasSpecial {
+		violations = append(violations, ErrPasswordNoSpecial)
+	}
+
+	lowerPassword := strings.ToLower(password)
+	if p.settings.PolicyRejectLogin && login != "" && strings.Contains(lowerPassword, strings.ToLower(login)) {
+		violations = append(violations, ErrPasswordContainsLogin)
+	}
+	if p.settings.PolicyRejectCommon {
+		if _, ok := p.common[lowerPassword]; ok {
+			violations = append(violations, ErrPasswordTooCommon)
+		}
+	}
+
+	if len(violations) > 0 {
+		return &PasswordPolicyError{Violations: violations}
+	}
+	return nil
+}
+
+// MaxBytes возвращает наибольшую длину пароля в байтах, которую можно захешировать
+func (p *PasswordPolicy) MaxBytes() int {
+	return p.settings.MaxPasswordBytes()
+}
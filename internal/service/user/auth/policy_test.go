@@ -0,0 +1,138 @@
+package auth
+
+import (
+	"errors"
+	"gophermart-service/internal/config"
+	"strings"
+	"testing"
+)
+
+func TestPasswordPolicyValidate(t *testing.T) {
+	strict := &config.PasswordSettings{
+		PolicyMinLength:        8,
+		PolicyMaxLength:        20,
+		PolicyRequireUppercase: true,
+		PolicyRequireLowercase: true,
+		PolicyRequireDigit:     true,
+		PolicyRequireSpecial:   true,
+		PolicyRejectLogin:      true,
+		PolicyRejectCommon:     true,
+	}
+	lenient := &config.PasswordSettings{PolicyMinLength: 6, PolicyMaxLength: 100}
+	bcryptDefaults := &config.PasswordSettings{
+		HashAlgorithm:   config.PasswordHashAlgorithmBcrypt,
+		PolicyMinLength: 6,
+		PolicyMaxLength: 100,
+	}
+
+	tests := []struct {
+		name     string
+		settings *config.PasswordSettings
+		login    string
+		password string
+		// wantErrs — все нарушения, которые должна вернуть проверка; nil — пароль допустим
+		wantErrs []error
+	}{
+		{
+			name:     "strong password",
+			settings: strict,
+			login:    "alice",
+			password: "Gopher#2026",
+		},
+		{
+			name:     "unicode letters count as characters",
+			settings: strict,
+			login:    "alice",
+			password: "Пароль#2026",
+		},
+		{
+			name:     "empty",
+			settings: strict,
+			password: "   ",
+			wantErrs: []error{ErrPasswordIsRequired},
+		},
+		{
+			name:     "too short",
+			settings: strict,
+			password: "Ab1#",
+			wantErrs: []error{ErrPasswordTooShort},
+		},
+		{
+			name:     "too long",
+			settings: strict,
+			password: "Ab1#" + strings.Repeat("x", 17),
+			wantErrs: []error{ErrPasswordTooLong},
+		},
+		{
+			name:     "lowercase only reports every missing class",
+			settings: strict,
+			password: "abcdefgh",
+			wantErrs: []error{ErrPasswordNoUppercase, ErrPasswordNoDigit, ErrPasswordNoSpecial},
+		},
+		{
+			name:     "contains login case-insensitively",
+			settings: strict,
+			login:    "Alice",
+			password: "xALICEx#2026",
+			wantErrs: []error{ErrPasswordContainsLogin},
+		},
+		{
+			name:     "common password",
+			settings: &config.PasswordSettings{PolicyMinLength: 6, PolicyMaxLength: 100, PolicyRejectCommon: true},
+			password: "QWERTY",
+			wantErrs: []error{ErrPasswordTooCommon},
+		},
+		{
+			name:     "maximum is counted in bytes",
+			settings: lenient,
+			password: strings.Repeat("п", 60),
+			wantErrs: []error{ErrPasswordTooLong},
+		},
+		{
+			name:     "bcrypt accepts 72 bytes",
+			settings: bcryptDefaults,
+			password: strings.Repeat("a", config.BcryptMaxPasswordBytes),
+		},
+		{
+			name:     "bcrypt caps the maximum at 72 bytes",
+			settings: bcryptDefaults,
+			password: strings.Repeat("a", config.BcryptMaxPasswordBytes+1),
+			wantErrs: []error{ErrPasswordTooLong},
+		},
+		{
+			name:     "minimum is counted in characters",
+			settings: lenient,
+			password: "пароль",
+		},
+		{
+			name:     "common password allowed when check is disabled",
+			settings: lenient,
+			password: "qwerty",
+		},
+		{
+			name:     "login allowed when check is disabled",
+			settings: lenient,
+			login:    "alice",
+			password: "alice123",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := NewPasswordPolicy(tt.settings).Validate(tt.login, tt.password)
+			if len(tt.wantErrs) == 0 {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			for _, want := range tt.wantErrs {
+				if !errors.Is(err, want) {
+					t.Errorf("error = %v, want it to contain %v", err, want)
+				}
+			}
+			if violations := PasswordPolicyViolations(err); len(violations) > len(tt.wantErrs) {
+				t.Errorf("violations = %q, want only %v", violations, tt.wantErrs)
+			}
+		})
+	}
+}
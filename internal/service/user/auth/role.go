@@ -0,0 +1,21 @@
+package auth
+
+// Роли пользователей
+const (
+	RoleCustomer = "customer"
+	RoleSupport  = "support"
+	RoleAdmin    = "admin"
+)
+
+// Roles содержит все поддерживаемые роли
+var Roles = []string{RoleCustomer, RoleSupport, RoleAdmin}
+
+// IsValidRole проверяет, что роль поддерживается
+func IsValidRole(role string) bool {
+	for _, r := range Roles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
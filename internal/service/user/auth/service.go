@@ -5,26 +5,29 @@ import (
 	"gophermart-service/internal/base"
 	"gophermart-service/internal/config"
 	usersRepo "gophermart-service/internal/repository/users"
-
-	"golang.org/x/crypto/bcrypt"
+	"gophermart-service/internal/service/hasher"
 )
 
-const PasswordHashCost = 15
-
 // Service представляет сервис регистрации пользователей
 type Service struct {
 	logger config.LoggerInterface
 	repo   usersRepo.RepositoryInterface
+	hasher hasher.HasherInterface
+	policy *PasswordPolicy
 }
 
 // NewRegisterService создает новый экземпляр сервиса регистрации
 func NewRegisterService(
 	logger config.LoggerInterface,
 	repo usersRepo.RepositoryInterface,
+	hasher hasher.HasherInterface,
+	policy *PasswordPolicy,
 ) ServiceInterface {
 	return &Service{
 		logger: logger,
 		repo:   repo,
+		hasher: hasher,
+		policy: policy,
 	}
 }
 
@@ -37,15 +40,23 @@ func (s *Service) RegisterUser(ctx context.Context, dtoIn *InDTO) (*OutDTO, erro
 		"login", dtoIn.Login)
 
 	// Валидация входных данных
-	if err := dtoIn.validateRequest(); err != nil {
+	if err := dtoIn.validateLogin(); err != nil {
 		s.logger.Warnf("Registration validation failed: requestID=%s, error=%s",
 			requestID, err.Error())
 		return nil, err
 	}
+	if err := s.ValidateNewPassword(dtoIn.Login, dtoIn.Password); err != nil {
+		s.logger.Warnf("Registration password policy failed: requestID=%s, error=%s",
+			requestID, err.Error())
+		return nil, err
+	}
 
 	// Хеширование пароля
 	passwordHash, err := s.HashPassword(dtoIn.Password)
 	if err != nil {
+		if hasher.IsErrPasswordTooLong(err) {
+			return nil, ErrPasswordTooLong
+		}
 		s.logger.Errorw("Password hashing failed",
 			"requestID", requestID,
 			"error", err.Error())
@@ -73,6 +84,7 @@ func (s *Service) RegisterUser(ctx context.Context, dtoIn *InDTO) (*OutDTO, erro
 
 	return &OutDTO{
 		UserID: userID,
+		Role:   RoleCustomer,
 	}, nil
 }
 
@@ -85,8 +97,8 @@ func (s *Service) LoginUser(ctx context.Context, dtoIn *InDTO) (*OutDTO, error)
 		"login", dtoIn.Login)
 
 	// Валидация входных данных
-	if err := dtoIn.validateRequest(); err != nil {
-		s.logger.Warnf("Registration validation failed: requestID=%s, error=%s",
+	if err := dtoIn.validateCredentials(s.policy.MaxBytes()); err != nil {
+		s.logger.Warnf("Login validation failed: requestID=%s, error=%s",
 			requestID, err.Error())
 		return nil, err
 	}
@@ -104,19 +116,82 @@ func (s *Service) LoginUser(ctx context.Context, dtoIn *InDTO) (*OutDTO, error)
 		return nil, ErrBadPassword
 	}
 
-	return &OutDTO{UserID: userID}, nil
-}
+	// Пароль верный: если хеш получен устаревшим алгоритмом или параметрами, пересчитываем его
+	if s.hasher.NeedsRehash(hashPassword) {
+		s.rehashPassword(ctx, userID, hashPassword, dtoIn.Password)
+	}
 
-// HashPassword хеширует пароль с использованием bcrypt
-func (s *Service) HashPassword(password string) (string, error) {
-	hashedBytes, err := bcrypt.GenerateFromPassword([]byte(password), PasswordHashCost)
+	tokenVersion, err := s.repo.GetTokenVersion(ctx, userID)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
-	return string(hashedBytes), nil
+
+	role, err := s.repo.GetRole(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &OutDTO{UserID: userID, TokenVersion: tokenVersion, Role: role}, nil
+}
+
+// ValidateNewPassword проверяет новый пароль пользователя на соответствие политике сложности
+func (s *Service) ValidateNewPassword(login, password string) error {
+	return s.policy.Validate(login, password)
+}
+
+// HashPassword хеширует пароль настроенным алгоритмом
+func (s *Service) HashPassword(password string) (string, error) {
+	return s.hasher.Hash(password)
 }
 
 // VerifyPassword проверяет пароль против хеша
 func (s *Service) VerifyPassword(hashedPassword, password string) error {
-	return bcrypt.CompareHashAndPassword([]byte(hashedPassword), []byte(password))
+	return s.hasher.Verify(hashedPassword, password)
+}
+
+// rehashPassword сохраняет хеш пароля, пересчитанный с текущими параметрами.
+// Ошибка не прерывает вход: пересчет будет повторен при следующей аутентификации.
+// Если пароль успели сменить после проверки oldHash, новый пароль не перезаписывается.
+func (s *Service) rehashPassword(ctx context.Context, userID int, oldHash, password string) {
+	requestID := base.GetRequestID(ctx)
+
+	passwordHash, err := s.HashPassword(password)
+	if err != nil {
+		s.logger.Errorw("Password rehashing failed",
+			"requestID", requestID,
+			"userID", userID,
+			"error", err.Error())
+		return
+	}
+
+	updated, err := s.repo.UpdatePasswordHash(ctx, userID, oldHash, passwordHash)
+	if err != nil {
+		s.logger.Errorw("Failed to store rehashed password",
+			"requestID", requestID,
+			"userID", userID,
+			"error", err.Error())
+		return
+	}
+	if !updated {
+		s.logger.Infow("Password changed concurrently, rehash skipped",
+			"requestID", requestID,
+			"userID", userID)
+		return
+	}
+
+	s.logger.Infow("Password rehashed with current parameters",
+		"requestID", requestID,
+		"userID", userID)
+}
+
+// GetTokenVersion возвращает текущую версию токенов пользователя
+func (s *Service) GetTokenVersion(ctx context.Context, userID int) (int, error) {
+	tokenVersion, err := s.repo.GetTokenVersion(ctx, userID)
+	if err != nil {
+		if usersRepo.IsErrUserNotFound(err) {
+			return 0, ErrUserNotFound
+		}
+		return 0, err
+	}
+	return tokenVersion, nil
 }
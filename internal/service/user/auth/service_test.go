@@ -0,0 +1,128 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"gophermart-service/internal/config"
+	"gophermart-service/internal/repository/users/mock"
+	"gophermart-service/internal/service/hasher"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"go.uber.org/zap"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func TestLoginUserRehash(t *testing.T) {
+	const password = "secret-password"
+	oldHash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.MinCost)
+	if err != nil {
+		t.Fatalf("bcrypt: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		updated bool
+	}{
+		{name: "stored hash replaced", updated: true},
+		// Пароль сменили между проверкой и пересчетом: новый хеш не записан, вход все равно успешен
+		{name: "password changed concurrently", updated: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			repo := mock.NewMockRepositoryInterface(ctrl)
+			h, err := hasher.NewHasherService(&config.PasswordSettings{
+				HashAlgorithm:    config.PasswordHashAlgorithmArgon2id,
+				Argon2Time:       1,
+				Argon2Memory:     64,
+				Argon2Threads:    1,
+				Argon2KeyLength:  16,
+				Argon2SaltLength: 8,
+			})
+			if err != nil {
+				t.Fatalf("NewHasherService: %v", err)
+			}
+			s := NewRegisterService(zap.NewNop().Sugar(), repo, h, NewPasswordPolicy(&config.PasswordSettings{PolicyMaxLength: 72}))
+
+			repo.EXPECT().GetUserHashPassword(gomock.Any(), "alice").Return(1, string(oldHash), nil)
+			repo.EXPECT().UpdatePasswordHash(gomock.Any(), 1, string(oldHash), gomock.Any()).Return(tt.updated, nil)
+			repo.EXPECT().GetTokenVersion(gomock.Any(), 1).Return(3, nil)
+			repo.EXPECT().GetRole(gomock.Any(), 1).Return(RoleCustomer, nil)
+
+			out, err := s.LoginUser(context.Background(), &InDTO{Login: "alice", Password: password})
+			if err != nil {
+				t.Fatalf("LoginUser: %v", err)
+			}
+			if out.UserID != 1 || out.TokenVersion != 3 {
+				t.Errorf("out = %+v, want user 1 with token version 3", out)
+			}
+		})
+	}
+}
+
+func TestRegisterUserHashRejectsLongPassword(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	repo := mock.NewMockRepositoryInterface(ctrl)
+	h, err := hasher.NewHasherService(&config.PasswordSettings{
+		HashAlgorithm: config.PasswordHashAlgorithmBcrypt,
+		BcryptCost:    bcrypt.MinCost,
+	})
+	if err != nil {
+		t.Fatalf("NewHasherService: %v", err)
+	}
+	// Политика настроена без учета bcrypt: пароль проходит ее, но не хешируется
+	policy := NewPasswordPolicy(&config.PasswordSettings{PolicyMinLength: 6, PolicyMaxLength: 100})
+	s := NewRegisterService(zap.NewNop().Sugar(), repo, h, policy)
+
+	_, err = s.RegisterUser(context.Background(), &InDTO{Login: "alice", Password: strings.Repeat("x", 80)})
+	if !errors.Is(err, ErrPasswordTooLong) {
+		t.Fatalf("error = %v, want %v", err, ErrPasswordTooLong)
+	}
+}
+
+func TestLoginUserIgnoresPasswordPolicy(t *testing.T) {
+	settings := &config.PasswordSettings{
+		HashAlgorithm:    config.PasswordHashAlgorithmArgon2id,
+		Argon2Time:       1,
+		Argon2Memory:     64,
+		Argon2Threads:    1,
+		Argon2KeyLength:  16,
+		Argon2SaltLength: 8,
+		PolicyMinLength:  8,
+		PolicyMaxLength:  200,
+	}
+	h, err := hasher.NewHasherService(settings)
+	if err != nil {
+		t.Fatalf("NewHasherService: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		password string
+	}{
+		{name: "shorter than policy minimum", password: "abc"},
+		// 120 байт кириллицы: больше прежнего жесткого лимита, но в пределах настроенного максимума
+		{name: "longer than 100 bytes", password: strings.Repeat("п", 60)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hash, err := h.Hash(tt.password)
+			if err != nil {
+				t.Fatalf("Hash: %v", err)
+			}
+			ctrl := gomock.NewController(t)
+			repo := mock.NewMockRepositoryInterface(ctrl)
+			s := NewRegisterService(zap.NewNop().Sugar(), repo, h, NewPasswordPolicy(settings))
+
+			repo.EXPECT().GetUserHashPassword(gomock.Any(), "alice").Return(1, hash, nil)
+			repo.EXPECT().GetTokenVersion(gomock.Any(), 1).Return(0, nil)
+			repo.EXPECT().GetRole(gomock.Any(), 1).Return(RoleCustomer, nil)
+
+			if _, err = s.LoginUser(context.Background(), &InDTO{Login: "alice", Password: tt.password}); err != nil {
+				t.Fatalf("LoginUser: %v", err)
+			}
+		})
+	}
+}
@@ -4,8 +4,10 @@ import (
 	"context"
 	"gophermart-service/internal/base"
 	"gophermart-service/internal/config"
-
 	viewsRepo "gophermart-service/internal/repository/views"
+	"gophermart-service/internal/tracing"
+
+	"go.opentelemetry.io/otel/attribute"
 )
 
 func NewUserBalanceService(
@@ -23,7 +25,10 @@ type Service struct {
 	repo   viewsRepo.RepositoryInterface
 }
 
-func (s *Service) GetBalance(ctx context.Context, userID int) (*GetUserBalanceDTO, error) {
+func (s *Service) GetBalance(ctx context.Context, userID int) (_ *GetUserBalanceDTO, err error) {
+	ctx, span := tracing.Start(ctx, "balance.GetBalance", attribute.Int("user.id", userID))
+	defer func() { tracing.End(span, err) }()
+
 	requestID := base.GetRequestID(ctx)
 
 	s.logger.Infow(
@@ -0,0 +1,24 @@
+package oidc
+
+// StartOutDTO представляет начатый вход: адрес страницы провайдера и state запроса авторизации
+type StartOutDTO struct {
+	AuthURL string
+	State   string
+}
+
+// CallbackInDTO представляет возврат от провайдера. BrowserState — state, сохраненный в браузере
+// при начале входа: без него чужой адрес возврата не может завершить вход в этом браузере.
+type CallbackInDTO struct {
+	State        string
+	BrowserState string
+	Code         string
+}
+
+// OutDTO представляет пользователя, вошедшего через OIDC провайдера
+type OutDTO struct {
+	UserID       int    `json:"user_id"`
+	Login        string `json:"login"`
+	TokenVersion int    `json:"token_version"`
+	Role         string `json:"role"`
+	Created      bool   `json:"created"`
+}
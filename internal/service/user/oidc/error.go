@@ -0,0 +1,17 @@
+package oidc
+
+import "errors"
+
+var (
+	ErrDisabled              = errors.New("oidc login is disabled")
+	ErrInvalidState          = errors.New("oidc state is invalid or expired")
+	ErrCodeIsRequired        = errors.New("authorization code is required")
+	ErrAuthenticationFailed  = errors.New("oidc authentication failed")
+	ErrFailedToGenerate      = errors.New("failed to generate oidc request")
+	ErrFailedToAllocateLogin = errors.New("failed to allocate login for oidc user")
+)
+
+func IsErrDisabled(err error) bool             { return errors.Is(err, ErrDisabled) }
+func IsErrInvalidState(err error) bool         { return errors.Is(err, ErrInvalidState) }
+func IsErrCodeIsRequired(err error) bool       { return errors.Is(err, ErrCodeIsRequired) }
+func IsErrAuthenticationFailed(err error) bool { return errors.Is(err, ErrAuthenticationFailed) }
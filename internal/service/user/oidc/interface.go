@@ -0,0 +1,12 @@
+package oidc
+
+import "context"
+
+type ServiceInterface interface {
+	// Start создает запрос авторизации и возвращает адрес страницы входа провайдера и state,
+	// который нужно привязать к браузеру
+	Start(ctx context.Context) (*StartOutDTO, error)
+	// Callback завершает вход: проверяет state и его привязку к браузеру, обменивает код
+	// и находит или создает пользователя
+	Callback(ctx context.Context, dtoIn *CallbackInDTO) (*OutDTO, error)
+}
@@ -0,0 +1,278 @@
+package oidc
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"gophermart-service/internal/base"
+	"gophermart-service/internal/config"
+	oidcProvider "gophermart-service/internal/integration/oidc"
+	identitiesRepo "gophermart-service/internal/repository/identities"
+	usersRepo "gophermart-service/internal/repository/users"
+	userAuth "gophermart-service/internal/service/user/auth"
+	"strings"
+	"time"
+)
+
+const (
+	stateBytes        = 24
+	verifierBytes     = 48
+	maxLoginAttempts  = 5
+	loginSuffixBytes  = 3
+	fallbackLoginBase = "oidc"
+)
+
+// Service представляет сервис входа через внешнего OpenID Connect провайдера
+type Service struct {
+	logger         config.LoggerInterface
+	settings       *config.OIDCSettings
+	provider       oidcProvider.ProviderInterface
+	usersRepo      usersRepo.RepositoryInterface
+	identitiesRepo identitiesRepo.RepositoryInterface
+}
+
+// NewOIDCService создает новый экземпляр сервиса входа через OIDC
+func NewOIDCService(
+	logger config.LoggerInterface,
+	settings *config.OIDCSettings,
+	provider oidcProvider.ProviderInterface,
+	usersRepo usersRepo.RepositoryInterface,
+	identitiesRepo identitiesRepo.RepositoryInterface,
+) ServiceInterface {
+	return &Service{
+		logger:         logger,
+		settings:       settings,
+		provider:       provider,
+		usersRepo:      usersRepo,
+		identitiesRepo: identitiesRepo,
+	}
+}
+
+func (s *Service) Start(ctx context.Context) (*StartOutDTO, error) {
+	requestID := base.GetRequestID(ctx)
+
+	if !s.settings.Enabled {
+		return nil, ErrDisabled
+	}
+
+	state, err := randomString(stateBytes)
+	if err != nil {
+		return nil, ErrFailedToGenerate
+	}
+	nonce, err := randomString(stateBytes)
+	if err != nil {
+		return nil, ErrFailedToGenerate
+	}
+	codeVerifier, err := randomString(verifierBytes)
+	if err != nil {
+		return nil, ErrFailedToGenerate
+	}
+
+	authURL, err := s.provider.AuthCodeURL(ctx, state, nonce, codeChallengeS256(codeVerifier))
+	if err != nil {
+		s.logger.Errorw("Failed to build oidc authorization url", "requestID", requestID, "error", err)
+		return nil, err
+	}
+
+	if err = s.identitiesRepo.SaveAuthRequest(ctx, &identitiesRepo.AuthRequest{
+		State:        state,
+		Nonce:        nonce,
+		CodeVerifier: codeVerifier,
+		ExpiresAt:    time.Now().Add(s.settings.StateTTL),
+	}); err != nil {
+		s.logger.Errorw("Failed to store oidc auth request", "requestID", requestID, "error", err)
+		return nil, err
+	}
+
+	return &StartOutDTO{AuthURL: authURL, State: state}, nil
+}
+
+func (s *Service) Callback(ctx context.Context, dtoIn *CallbackInDTO) (*OutDTO, error) {
+	requestID := base.GetRequestID(ctx)
+
+	if !s.settings.Enabled {
+		return nil, ErrDisabled
+	}
+	if strings.TrimSpace(dtoIn.Code) == "" {
+		return nil, ErrCodeIsRequired
+	}
+
+	// Защита от login CSRF: адрес возврата с чужими code и state не завершает вход
+	// в браузере, который этот вход не начинал
+	if dtoIn.State == "" || subtle.ConstantTimeCompare([]byte(dtoIn.State), []byte(dtoIn.BrowserState)) != 1 {
+		s.logger.Warnw("OIDC state is not bound to this browser", "requestID", requestID)
+		return nil, ErrInvalidState
+	}
+
+	authRequest, err := s.identitiesRepo.ConsumeAuthRequest(ctx, dtoIn.State)
+	if err != nil {
+		if identitiesRepo.IsErrAuthRequestNotFound(err) {
+			s.logger.Warnw("Unknown or expired oidc state", "requestID", requestID)
+			return nil, ErrInvalidState
+		}
+		return nil, err
+	}
+
+	tokens, err := s.provider.Exchange(ctx, dtoIn.Code, authRequest.CodeVerifier)
+	if err != nil {
+		s.logger.Warnw("OIDC code exchange failed", "requestID", requestID, "error", err)
+		return nil, ErrAuthenticationFailed
+	}
+
+	claims, err := s.provider.VerifyIDToken(ctx, tokens.IDToken, authRequest.Nonce)
+	if err != nil {
+		s.logger.Warnw("OIDC id_token rejected", "requestID", requestID, "error", err)
+		return nil, ErrAuthenticationFailed
+	}
+
+	identity := &identitiesRepo.Identity{
+		Issuer:  s.provider.Issuer(),
+		Subject: claims.Subject,
+	}
+	if claims.EmailVerified {
+		identity.Email = claims.Email
+	}
+
+	userID, created, err := s.findOrCreateUser(ctx, identity, claims)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := s.buildOutDTO(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	result.Created = created
+
+	s.logger.Infow("User logged in via oidc",
+		"requestID", requestID,
+		"userID", userID,
+		"issuer", identity.Issuer,
+		"created", created)
+
+	return result, nil
+}
+
+// findOrCreateUser возвращает пользователя, связанного с внешней учетной записью, или создает нового.
+// Существующие пользователи по совпадению логина или email не связываются, чтобы исключить захват аккаунта.
+func (s *Service) findOrCreateUser(
+	ctx context.Context,
+	identity *identitiesRepo.Identity,
+	claims *oidcProvider.IDTokenClaims,
+) (int, bool, error) {
+	userID, err := s.identitiesRepo.GetUserID(ctx, identity.Issuer, identity.Subject)
+	if err == nil {
+		if err = s.identitiesRepo.TouchLastLogin(ctx, identity.Issuer, identity.Subject); err != nil {
+			s.logger.Warnw("Failed to update identity last login", "userID", userID, "error", err)
+		}
+		return userID, false, nil
+	}
+	if !identitiesRepo.IsErrIdentityNotFound(err) {
+		return 0, false, err
+	}
+
+	// Пароля у такого пользователя нет: значение не является хешем и не пройдет проверку при входе
+	unusableHash, err := randomString(stateBytes)
+	if err != nil {
+		return 0, false, ErrFailedToGenerate
+	}
+	unusableHash = "!" + unusableHash
+
+	baseLogin := loginFromClaims(claims)
+	login := baseLogin
+	for attempt := 0; attempt < maxLoginAttempts; attempt++ {
+		userID, err = s.identitiesRepo.CreateUserWithIdentity(ctx, login, unusableHash, identity)
+		if err == nil {
+			return userID, true, nil
+		}
+		if !identitiesRepo.IsErrLoginAlreadyExists(err) {
+			return 0, false, err
+		}
+
+		suffix := make([]byte, loginSuffixBytes)
+		if _, err = rand.Read(suffix); err != nil {
+			return 0, false, ErrFailedToGenerate
+		}
+		login = truncate(baseLogin, userAuth.MaxLoginLength-len(suffix)*2-1) + "-" + hex.EncodeToString(suffix)
+	}
+
+	return 0, false, ErrFailedToAllocateLogin
+}
+
+func (s *Service) buildOutDTO(ctx context.Context, userID int) (*OutDTO, error) {
+	login, err := s.usersRepo.GetLoginByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	tokenVersion, err := s.usersRepo.GetTokenVersion(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	role, err := s.usersRepo.GetRole(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &OutDTO{
+		UserID:       userID,
+		Login:        login,
+		TokenVersion: tokenVersion,
+		Role:         role,
+	}, nil
+}
+
+// loginFromClaims подбирает логин для нового пользователя: preferred_username, локальная часть email
+// или производное от subject
+func loginFromClaims(claims *oidcProvider.IDTokenClaims) string {
+	candidates := []string{claims.PreferredUsername}
+	if claims.EmailVerified {
+		if local, _, ok := strings.Cut(claims.Email, "@"); ok {
+			candidates = append(candidates, local)
+		}
+	}
+
+	for _, candidate := range candidates {
+		login := sanitizeLogin(candidate)
+		if len(login) >= userAuth.MinLoginLength && !userAuth.IsReservedLogin(login) {
+			return truncate(login, userAuth.MaxLoginLength)
+		}
+	}
+
+	sum := sha256.Sum256([]byte(claims.Issuer + "|" + claims.Subject))
+	return fallbackLoginBase + "-" + hex.EncodeToString(sum[:6])
+}
+
+func sanitizeLogin(value string) string {
+	var builder strings.Builder
+	for _, r := range strings.ToLower(strings.TrimSpace(value)) {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9', r == '.', r == '_', r == '-':
+			builder.WriteRune(r)
+		}
+	}
+	return builder.String()
+}
+
+func truncate(value string, maxLength int) string {
+	if len(value) > maxLength {
+		return value[:maxLength]
+	}
+	return value
+}
+
+func randomString(size int) (string, error) {
+	buf := make([]byte, size)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
+
+// codeChallengeS256 вычисляет PKCE code_challenge по RFC 7636
+func codeChallengeS256(codeVerifier string) string {
+	sum := sha256.Sum256([]byte(codeVerifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
@@ -0,0 +1,96 @@
+package oidc
+
+import (
+	"context"
+	"errors"
+	"gophermart-service/internal/config"
+	oidcProvider "gophermart-service/internal/integration/oidc"
+	identitiesRepo "gophermart-service/internal/repository/identities"
+	userAuth "gophermart-service/internal/service/user/auth"
+	"strings"
+	"testing"
+
+	"go.uber.org/zap"
+)
+
+var errStateConsumed = errors.New("state consumed")
+
+// consumeRecorder сообщает, что проверка привязки state пройдена и запрос авторизации запрошен из БД
+type consumeRecorder struct {
+	identitiesRepo.RepositoryInterface
+}
+
+func (consumeRecorder) ConsumeAuthRequest(context.Context, string) (*identitiesRepo.AuthRequest, error) {
+	return nil, errStateConsumed
+}
+
+func TestCallbackRequiresBrowserBoundState(t *testing.T) {
+	s := &Service{
+		logger:         zap.NewNop().Sugar(),
+		settings:       &config.OIDCSettings{Enabled: true},
+		identitiesRepo: consumeRecorder{},
+	}
+
+	tests := []struct {
+		name         string
+		state        string
+		browserState string
+		wantErr      error
+	}{
+		{name: "matching cookie", state: "state-a", browserState: "state-a", wantErr: errStateConsumed},
+		{name: "missing cookie", state: "state-a", browserState: "", wantErr: ErrInvalidState},
+		{name: "cookie from another login", state: "attacker-state", browserState: "victim-state", wantErr: ErrInvalidState},
+		{name: "empty state", state: "", browserState: "", wantErr: ErrInvalidState},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := s.Callback(context.Background(), &CallbackInDTO{
+				State:        tt.state,
+				BrowserState: tt.browserState,
+				Code:         "code",
+			})
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Callback error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestLoginFromClaimsSkipsReservedLogins(t *testing.T) {
+	tests := []struct {
+		name       string
+		claims     *oidcProvider.IDTokenClaims
+		wantPrefix string
+	}{
+		{
+			name:       "preferred username",
+			claims:     &oidcProvider.IDTokenClaims{PreferredUsername: "Alice"},
+			wantPrefix: "alice",
+		},
+		{
+			name: "reserved username falls back to email",
+			claims: &oidcProvider.IDTokenClaims{
+				PreferredUsername: "deleted-42",
+				Email:             "bob@example.com",
+				EmailVerified:     true,
+			},
+			wantPrefix: "bob",
+		},
+		{
+			name:       "reserved username without email falls back to subject",
+			claims:     &oidcProvider.IDTokenClaims{PreferredUsername: "deleted-42"},
+			wantPrefix: fallbackLoginBase + "-",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			login := loginFromClaims(tt.claims)
+			if !strings.HasPrefix(login, tt.wantPrefix) {
+				t.Errorf("loginFromClaims() = %q, want prefix %q", login, tt.wantPrefix)
+			}
+			if userAuth.IsReservedLogin(login) {
+				t.Errorf("loginFromClaims() = %q is reserved", login)
+			}
+		})
+	}
+}
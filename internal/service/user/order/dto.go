@@ -8,3 +8,67 @@ type orderDTO struct {
 	Accrual     float32   `json:"accrual"`
 	UploadedAt  time.Time `json:"uploaded_at"`
 }
+
+// ListInDTO параметры списка заказов: фильтры, сортировка и пагинация
+type ListInDTO struct {
+	Statuses []string
+	From     string
+	To       string
+	Sort     string
+	Cursor   string
+	Limit    int
+	Offset   int
+}
+
+// ListOutDTO страница списка заказов; NextCursor пуст на последней странице
+type ListOutDTO struct {
+	Orders     []*orderDTO
+	NextCursor string
+}
+
+// OrderDetailsDTO заказ вместе с историей его обработки
+type OrderDetailsDTO struct {
+	OrderNumber string           `json:"number"`
+	Status      string           `json:"status"`
+	Accrual     float32          `json:"accrual"`
+	UploadedAt  time.Time        `json:"uploaded_at"`
+	History     []*OrderEventDTO `json:"history"`
+}
+
+// OrderEventDTO событие истории обработки заказа
+type OrderEventDTO struct {
+	Type          string    `json:"type"`
+	Status        string    `json:"status"`
+	AccrualStatus *string   `json:"accrual_status,omitempty"`
+	Accrual       *float32  `json:"accrual,omitempty"`
+	Detail        *string   `json:"detail,omitempty"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// Результаты обработки отдельного номера при пакетной загрузке
+const (
+	BatchItemAccepted  = "accepted"
+	BatchItemDuplicate = "duplicate"
+	BatchItemForeign   = "owned_by_another_user"
+	BatchItemInvalid   = "invalid"
+	BatchItemFailed    = "failed"
+)
+
+// BatchItemDTO результат загрузки одного номера заказа из пакета
+type BatchItemDTO struct {
+	OrderNumber string `json:"number"`
+	Result      string `json:"result"`
+}
+
+// BatchOutDTO итог пакетной загрузки: результаты в порядке номеров запроса и сводка по ним
+type BatchOutDTO struct {
+	Items   []*BatchItemDTO `json:"items"`
+	Summary map[string]int  `json:"summary"`
+}
+
+// WorkersDTO состояние воркеров фоновой обработки заказов
+type WorkersDTO struct {
+	Running int
+	Total   int
+	Stopped bool
+}
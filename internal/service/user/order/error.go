@@ -7,7 +7,10 @@ var (
 	ErrOrderAlreadyProcessed     = errors.New("order already processed")
 	ErrFailedToAddOrder          = errors.New("failed to add order")
 	ErrBadOrderNumber            = errors.New("bad order number")
-	ErrNoOrders                  = errors.New("no orders found")
+	ErrOrderNotFound             = errors.New("order not found")
+	ErrInvalidStatusFilter       = errors.New("unknown order status in filter")
+	ErrEmptyBatch                = errors.New("no order numbers in batch")
+	ErrBatchTooLarge             = errors.New("too many order numbers in batch")
 )
 
 func IsErrOrderAlreadyExistsForUser(err error) bool {
@@ -18,3 +21,4 @@ func IsErrOrderAlreadyProcessedByAnotherUser(err error) bool {
 }
 func IsErrFailedToAddOrder(err error) bool { return errors.Is(err, ErrFailedToAddOrder) }
 func IsErrBadOrderNumber(err error) bool   { return errors.Is(err, ErrBadOrderNumber) }
+func IsErrOrderNotFound(err error) bool    { return errors.Is(err, ErrOrderNotFound) }
@@ -4,7 +4,10 @@ import "context"
 
 type ServiceInterface interface {
 	LoadNewOrderNumber(ctx context.Context, userID int, orderNumber string) error
+	LoadOrderNumbersBatch(ctx context.Context, userID int, orderNumbers []string) (*BatchOutDTO, error)
 	ValidateOrderNumber(ctx context.Context, orderNumber string) error
-	GetUserOrders(ctx context.Context, userID int, limit, offset int) ([]*orderDTO, error)
+	ListUserOrders(ctx context.Context, userID int, in *ListInDTO) (*ListOutDTO, error)
+	GetUserOrder(ctx context.Context, userID int, orderNumber string) (*OrderDetailsDTO, error)
+	WorkerStatus() *WorkersDTO
 	Stop()
 }
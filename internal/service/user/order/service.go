@@ -3,37 +3,61 @@ package order
 import (
 	"context"
 	"errors"
+	"fmt"
 	"gophermart-service/internal/base"
 	"gophermart-service/internal/config"
 	"gophermart-service/internal/integration/accrual"
+	"gophermart-service/internal/metrics"
 	ordersRepo "gophermart-service/internal/repository/orders"
+	"gophermart-service/internal/service/pagination"
+	"gophermart-service/internal/service/user/updates"
+	"gophermart-service/internal/service/user/webhook"
+	"gophermart-service/internal/tracing"
+	"slices"
 	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
+
+	"go.opentelemetry.io/otel/attribute"
 )
 
 const (
 	maxWorkers         = 3
 	batchSize          = 10
 	processingInterval = 5 * time.Second
+	maxBatchSize       = 1000
+)
+
+var (
+	orderStatuses   = []string{"NEW", "PROCESSING", "INVALID", "PROCESSED"}
+	listSortFields  = []string{ordersRepo.SortUploadedAt, ordersRepo.SortAccrual}
+	defaultListSort = pagination.Sort{Field: ordersRepo.SortUploadedAt, Desc: true}
 )
 
 func NewOrderService(
 	logger config.LoggerInterface,
 	repo ordersRepo.RepositoryInterface,
 	accrualClient accrual.ClientInterface,
+	publisher updates.PublisherInterface,
+	webhooks webhook.PublisherInterface,
 ) ServiceInterface {
 
 	service := &Service{
 		logger:        logger,
 		repo:          repo,
 		accrualClient: accrualClient,
+		publisher:     publisher,
+		webhooks:      webhooks,
 		stopChan:      make(chan struct{}),
 		rateLimitChan: make(chan time.Duration, 1), // буферизованный канал для rate limiting
 	}
 
 	// Запускаем воркеры для батчевой обработки заказов
 	for workerID := 1; workerID <= maxWorkers; workerID++ {
+		service.wg.Add(1)
+		service.running.Add(1)
 		go service.batchOrderProcessor(workerID)
 	}
 
@@ -44,11 +68,21 @@ type Service struct {
 	logger        config.LoggerInterface
 	repo          ordersRepo.RepositoryInterface
 	accrualClient accrual.ClientInterface
+	publisher     updates.PublisherInterface
+	webhooks      webhook.PublisherInterface
 	stopChan      chan struct{}
 	rateLimitChan chan time.Duration // канал для передачи времени ожидания при rate limiting
+	stopOnce      sync.Once
+	wg            sync.WaitGroup
+	running       atomic.Int32
 }
 
-func (s *Service) LoadNewOrderNumber(ctx context.Context, userID int, orderNumber string) error {
+func (s *Service) LoadNewOrderNumber(ctx context.Context, userID int, orderNumber string) (err error) {
+	ctx, span := tracing.Start(ctx, "order.LoadNewOrderNumber",
+		attribute.Int("user.id", userID),
+		attribute.String("order.number", orderNumber))
+	defer func() { tracing.End(span, err) }()
+
 	requestID := base.GetRequestID(ctx)
 
 	s.logger.Infow("Load new order number initiated",
@@ -105,6 +139,70 @@ func (s *Service) LoadNewOrderNumber(ctx context.Context, userID int, orderNumbe
 	return nil
 }
 
+// LoadOrderNumbersBatch загружает пакет номеров заказов. Каждый номер обрабатывается
+// независимо: ошибка одного номера не прерывает загрузку остальных.
+func (s *Service) LoadOrderNumbersBatch(ctx context.Context, userID int, orderNumbers []string) (_ *BatchOutDTO, err error) {
+	ctx, span := tracing.Start(ctx, "order.LoadOrderNumbersBatch",
+		attribute.Int("user.id", userID),
+		attribute.Int("orders.count", len(orderNumbers)))
+	defer func() { tracing.End(span, err) }()
+
+	requestID := base.GetRequestID(ctx)
+
+	if len(orderNumbers) == 0 {
+		return nil, ErrEmptyBatch
+	}
+	if len(orderNumbers) > maxBatchSize {
+		return nil, ErrBatchTooLarge
+	}
+
+	s.logger.Infow("Load order numbers batch initiated",
+		"requestID", requestID,
+		"userID", userID,
+		"count", len(orderNumbers))
+
+	result := &BatchOutDTO{
+		Items: make([]*BatchItemDTO, 0, len(orderNumbers)),
+		Summary: map[string]int{
+			BatchItemAccepted:  0,
+			BatchItemDuplicate: 0,
+			BatchItemForeign:   0,
+			BatchItemInvalid:   0,
+			BatchItemFailed:    0,
+		},
+	}
+	for _, orderNumber := range orderNumbers {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		item := &BatchItemDTO{OrderNumber: orderNumber}
+		err := s.LoadNewOrderNumber(ctx, userID, orderNumber)
+		switch {
+		case err == nil:
+			item.Result = BatchItemAccepted
+		case IsErrOrderAlreadyExistsForUser(err):
+			item.Result = BatchItemDuplicate
+		case IsErrOrderAlreadyProcessedByAnotherUser(err):
+			item.Result = BatchItemForeign
+		case IsErrBadOrderNumber(err):
+			item.Result = BatchItemInvalid
+		default:
+			item.Result = BatchItemFailed
+		}
+
+		result.Items = append(result.Items, item)
+		result.Summary[item.Result]++
+	}
+
+	s.logger.Infow("Load order numbers batch completed",
+		"requestID", requestID,
+		"userID", userID,
+		"summary", result.Summary)
+
+	return result, nil
+}
+
 func (s *Service) ValidateOrderNumber(ctx context.Context, orderNumber string) error {
 	requestID := base.GetRequestID(ctx)
 
@@ -117,30 +215,43 @@ func (s *Service) ValidateOrderNumber(ctx context.Context, orderNumber string) e
 	return ErrBadOrderNumber
 }
 
-func (s *Service) GetUserOrders(ctx context.Context, userID int, limit, offset int) ([]*orderDTO, error) {
+func (s *Service) ListUserOrders(ctx context.Context, userID int, in *ListInDTO) (_ *ListOutDTO, err error) {
+	ctx, span := tracing.Start(ctx, "order.ListUserOrders", attribute.Int("user.id", userID))
+	defer func() { tracing.End(span, err) }()
+
 	requestID := base.GetRequestID(ctx)
 
-	s.logger.Infow("Get user orders request",
+	s.logger.Infow("List user orders request",
 		"requestID", requestID,
 		"userID", userID,
 	)
 
-	orders, err := s.repo.GetUserOrders(ctx, userID, limit, offset)
+	filter, sort, err := s.buildListFilter(userID, in)
 	if err != nil {
 		return nil, err
 	}
 
-	if len(orders) == 0 {
-		s.logger.Infow("No orders found for user",
-			"requestID", requestID,
-			"userID", userID,
-		)
-		return nil, ErrNoOrders
+	// Запрашиваем на одну запись больше, чтобы понять, есть ли следующая страница
+	orders, err := s.repo.ListUserOrders(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ListOutDTO{Orders: make([]*orderDTO, 0, len(orders))}
+	if len(orders) == filter.Limit {
+		orders = orders[:filter.Limit-1]
+		last := orders[len(orders)-1]
+		cursor := &pagination.Cursor{Sort: sort.String(), ID: last.ID}
+		if sort.Field == ordersRepo.SortAccrual {
+			cursor.Value = pagination.FormatAmount(last.Accrual)
+		} else {
+			cursor.Value = pagination.FormatTime(last.UploadedAt)
+		}
+		result.NextCursor = cursor.Encode()
 	}
 
-	var result []*orderDTO
 	for _, order := range orders {
-		result = append(result, &orderDTO{
+		result.Orders = append(result.Orders, &orderDTO{
 			OrderNumber: order.OrderNumber,
 			Status:      order.Status,
 			Accrual:     order.Accrual,
@@ -151,12 +262,120 @@ func (s *Service) GetUserOrders(ctx context.Context, userID int, limit, offset i
 	s.logger.Infow("User orders retrieved successfully",
 		"requestID", requestID,
 		"userID", userID,
-		"orders_count", len(result),
+		"orders_count", len(result.Orders),
 	)
 
 	return result, nil
 }
 
+// buildListFilter проверяет параметры списка и переводит их в фильтр репозитория
+func (s *Service) buildListFilter(userID int, in *ListInDTO) (*ordersRepo.ListFilter, pagination.Sort, error) {
+	sort, err := pagination.ParseSort(in.Sort, listSortFields, defaultListSort)
+	if err != nil {
+		return nil, sort, err
+	}
+
+	cursor, err := pagination.DecodeCursor(in.Cursor, sort)
+	if err != nil {
+		return nil, sort, err
+	}
+	if cursor != nil && in.Offset > 0 {
+		return nil, sort, fmt.Errorf("%w: cursor cannot be combined with offset", pagination.ErrInvalidCursor)
+	}
+
+	dateRange, err := pagination.ParseDateRange(in.From, in.To)
+	if err != nil {
+		return nil, sort, err
+	}
+
+	for _, status := range in.Statuses {
+		if !slices.Contains(orderStatuses, status) {
+			return nil, sort, fmt.Errorf("%w: %s", ErrInvalidStatusFilter, status)
+		}
+	}
+
+	filter := &ordersRepo.ListFilter{
+		UserID:    userID,
+		Statuses:  in.Statuses,
+		From:      dateRange.From,
+		To:        dateRange.To,
+		SortField: sort.Field,
+		Desc:      sort.Desc,
+		Offset:    in.Offset,
+		Limit:     pagination.NormalizeLimit(in.Limit) + 1,
+	}
+
+	if cursor != nil {
+		filter.AfterID = cursor.ID
+		if sort.Field == ordersRepo.SortAccrual {
+			value, err := strconv.ParseFloat(cursor.Value, 64)
+			if err != nil {
+				return nil, sort, pagination.ErrInvalidCursor
+			}
+			filter.AfterValue = value
+		} else {
+			value, err := cursor.TimeValue()
+			if err != nil {
+				return nil, sort, err
+			}
+			filter.AfterValue = value
+		}
+	}
+
+	return filter, sort, nil
+}
+
+func (s *Service) GetUserOrder(ctx context.Context, userID int, orderNumber string) (_ *OrderDetailsDTO, err error) {
+	ctx, span := tracing.Start(ctx, "order.GetUserOrder",
+		attribute.Int("user.id", userID),
+		attribute.String("order.number", orderNumber))
+	defer func() { tracing.End(span, err) }()
+
+	requestID := base.GetRequestID(ctx)
+
+	order, err := s.repo.GetUserOrder(ctx, userID, orderNumber)
+	if err != nil {
+		if errors.Is(err, ordersRepo.ErrOrderNotFound) {
+			return nil, ErrOrderNotFound
+		}
+		s.logger.Errorw("Failed to get user order",
+			"requestID", requestID,
+			"userID", userID,
+			"orderNumber", orderNumber,
+			"error", err)
+		return nil, err
+	}
+
+	events, err := s.repo.GetOrderEvents(ctx, order.ID)
+	if err != nil {
+		s.logger.Errorw("Failed to get order events",
+			"requestID", requestID,
+			"orderID", order.ID,
+			"error", err)
+		return nil, err
+	}
+
+	result := &OrderDetailsDTO{
+		OrderNumber: order.OrderNumber,
+		Status:      order.Status,
+		Accrual:     order.Accrual,
+		UploadedAt:  order.UploadedAt,
+		History:     make([]*OrderEventDTO, 0, len(events)),
+	}
+	for _, event := range events {
+		result.History = append(result.History, &OrderEventDTO{
+			Type:          event.EventType,
+			Status:        event.Status,
+			AccrualStatus: event.AccrualStatus,
+			Accrual:       event.Accrual,
+			Detail:        event.Detail,
+			CreatedAt:     event.CreatedAt,
+		})
+	}
+
+	return result, nil
+}
+
 func (s *Service) luhnCheck(number string) bool {
 	// Удаляем пробелы и дефисы
 	number = strings.ReplaceAll(number, " ", "")
@@ -192,6 +411,9 @@ func (s *Service) luhnCheck(number string) bool {
 }
 
 func (s *Service) batchOrderProcessor(workerID int) {
+	defer s.wg.Done()
+	defer s.running.Add(-1)
+
 	s.logger.Infow("Batch order processor started", "worker_id", workerID)
 	defer s.logger.Infow("Batch order processor stopped", "worker_id", workerID)
 
@@ -207,6 +429,8 @@ func (s *Service) batchOrderProcessor(workerID int) {
 			s.logger.Warnw("Rate limit detected, sleeping all workers",
 				"worker_id", workerID,
 				"retry_after", retryAfter)
+			metrics.RateLimitSleeps.Inc()
+			metrics.RateLimitSleepSeconds.Add(retryAfter.Seconds())
 			s.sleepWithGracefulShutdown(workerID, retryAfter)
 
 		case <-s.stopChan:
@@ -239,7 +463,14 @@ func (s *Service) processBatchOrders(workerID int) {
 		"orders_count", len(orders))
 
 	for _, order := range orders {
-		s.processOrder(workerID, order)
+		// Начатый заказ дорабатываем до конца, а оставшиеся в статусе NEW заберет следующий запуск
+		select {
+		case <-s.stopChan:
+			s.logger.Infow("Batch processing interrupted by stop signal", "worker_id", workerID)
+			return
+		default:
+		}
+		metrics.OrdersProcessed.WithLabelValues(s.processOrder(workerID, order)).Inc()
 	}
 }
 
@@ -264,8 +495,18 @@ func (s *Service) sleepWithGracefulShutdown(workerID int, duration time.Duration
 	}
 }
 
-func (s *Service) processOrder(workerID int, order *ordersRepo.Order) {
-	ctx := context.Background()
+// processOrder опрашивает систему начислений по заказу и возвращает результат обработки для метрик
+func (s *Service) processOrder(workerID int, order *ordersRepo.Order) (result string) {
+	// Каждый заказ обрабатывается в отдельной трассе: фоновая обработка не связана с запросом,
+	// загрузившим заказ, а ее спаны объединяют запросы к БД и к системе начислений
+	ctx, span := tracing.Start(context.Background(), "order.process",
+		attribute.Int("worker.id", workerID),
+		attribute.Int("order.id", order.ID),
+		attribute.String("order.number", order.OrderNumber))
+	defer func() {
+		span.SetAttributes(attribute.String("order.result", result))
+		span.End()
+	}()
 
 	s.logger.Debugw("Processing order",
 		"worker_id", workerID,
@@ -280,7 +521,7 @@ func (s *Service) processOrder(workerID int, order *ordersRepo.Order) {
 			"order_id", order.ID,
 			"order_number", order.OrderNumber,
 			"error", err.Error())
-		return
+		return metrics.OrderResultFailed
 	}
 
 	// Получаем информацию о заказе из системы accrual
@@ -302,6 +543,11 @@ func (s *Service) processOrder(workerID int, order *ordersRepo.Order) {
 				default:
 				}
 
+				s.addPollEvent(ctx, workerID, order, &ordersRepo.OrderEvent{
+					Status: "NEW",
+					Detail: stringPtr("accrual system rate limit exceeded"),
+				})
+
 				// Возвращаем статус обратно на NEW для повторной обработки
 				if updateErr := s.repo.UpdateOrderStatus(ctx, order.ID, "NEW"); updateErr != nil {
 					s.logger.Errorw("Failed to revert order status to NEW",
@@ -309,7 +555,7 @@ func (s *Service) processOrder(workerID int, order *ordersRepo.Order) {
 						"order_id", order.ID,
 						"error", updateErr.Error())
 				}
-				return
+				return metrics.OrderResultRateLimited
 			}
 		}
 
@@ -318,6 +564,10 @@ func (s *Service) processOrder(workerID int, order *ordersRepo.Order) {
 			"order_id", order.ID,
 			"order_number", order.OrderNumber,
 			"error", err.Error())
+		s.addPollEvent(ctx, workerID, order, &ordersRepo.OrderEvent{
+			Status: "NEW",
+			Detail: stringPtr("accrual system request failed"),
+		})
 		// Возвращаем статус обратно на NEW для повторной обработки
 		if updateErr := s.repo.UpdateOrderStatus(ctx, order.ID, "NEW"); updateErr != nil {
 			s.logger.Errorw("Failed to revert order status to NEW",
@@ -325,7 +575,7 @@ func (s *Service) processOrder(workerID int, order *ordersRepo.Order) {
 				"order_id", order.ID,
 				"error", updateErr.Error())
 		}
-		return
+		return metrics.OrderResultAccrualError
 	}
 
 	if orderInfo == nil {
@@ -333,6 +583,10 @@ func (s *Service) processOrder(workerID int, order *ordersRepo.Order) {
 			"worker_id", workerID,
 			"order_id", order.ID,
 			"order_number", order.OrderNumber)
+		s.addPollEvent(ctx, workerID, order, &ordersRepo.OrderEvent{
+			Status: "NEW",
+			Detail: stringPtr("order is not registered in accrual system"),
+		})
 		// Возвращаем статус обратно на NEW для повторной обработки
 		if updateErr := s.repo.UpdateOrderStatus(ctx, order.ID, "NEW"); updateErr != nil {
 			s.logger.Errorw("Failed to revert order status to NEW",
@@ -340,16 +594,51 @@ func (s *Service) processOrder(workerID int, order *ordersRepo.Order) {
 				"order_id", order.ID,
 				"error", updateErr.Error())
 		}
-		return
+		return metrics.OrderResultNotRegistered
 	}
 
-	// Обновляем заказ с финальным статусом и начислением
+	accrualStatus := string(orderInfo.Status)
+	accrualAmount := orderInfo.GetAccrual()
+
+	// Расчет еще не окончен: фиксируем результат опроса и возвращаем заказ в очередь
+	if !orderInfo.Status.IsFinalStatus() {
+		s.logger.Debugw("Order accrual is not final yet",
+			"worker_id", workerID,
+			"order_id", order.ID,
+			"order_number", order.OrderNumber,
+			"accrual_status", accrualStatus)
+		s.addPollEvent(ctx, workerID, order, &ordersRepo.OrderEvent{
+			Status:        "NEW",
+			AccrualStatus: &accrualStatus,
+		})
+		if updateErr := s.repo.UpdateOrderStatus(ctx, order.ID, "NEW"); updateErr != nil {
+			s.logger.Errorw("Failed to revert order status to NEW",
+				"worker_id", workerID,
+				"order_id", order.ID,
+				"error", updateErr.Error())
+		}
+		return metrics.OrderResultPending
+	}
+
+	s.addPollEvent(ctx, workerID, order, &ordersRepo.OrderEvent{
+		Status:        "PROCESSING",
+		AccrualStatus: &accrualStatus,
+		Accrual:       &accrualAmount,
+	})
+
+	// Обновляем заказ с финальным статусом и начислением; событие смены статуса пишется в той же транзакции
+	statusEvent := &ordersRepo.OrderEvent{
+		EventType: ordersRepo.EventStatusChanged,
+		Status:    accrualStatus,
+		Accrual:   &accrualAmount,
+	}
 	if err = s.repo.UpdateOrder(
 		ctx,
 		order.UserID,
 		order.OrderNumber,
 		string(orderInfo.Status),
 		orderInfo.GetAccrual(),
+		statusEvent,
 	); err != nil {
 		s.logger.Errorw("Failed to update order with final status",
 			"worker_id", workerID,
@@ -363,7 +652,17 @@ func (s *Service) processOrder(workerID int, order *ordersRepo.Order) {
 				"order_id", order.ID,
 				"error", updateErr.Error())
 		}
-		return
+		return metrics.OrderResultFailed
+	}
+
+	s.publisher.PublishOrderStatus(ctx, order.UserID, order.OrderNumber, statusEvent)
+	if orderInfo.Status == accrual.OrderStatusProcessed {
+		s.webhooks.Publish(ctx, order.UserID, webhook.EventOrderProcessed, &webhook.OrderProcessedDTO{
+			OrderNumber: order.OrderNumber,
+			Status:      accrualStatus,
+			Accrual:     accrualAmount,
+			ProcessedAt: time.Now().UTC(),
+		})
 	}
 
 	s.logger.Infow("Order processed successfully",
@@ -373,16 +672,54 @@ func (s *Service) processOrder(workerID int, order *ordersRepo.Order) {
 		"user_id", order.UserID,
 		"status", string(orderInfo.Status),
 		"accrual", orderInfo.GetAccrual())
+
+	if orderInfo.Status == accrual.OrderStatusInvalid {
+		return metrics.OrderResultInvalid
+	}
+	return metrics.OrderResultProcessed
 }
 
-func (s *Service) Stop() {
-	s.logger.Info("Stopping order service...")
+// addPollEvent записывает результат опроса в историю заказа, если он отличается от предыдущего;
+// ошибка записи не прерывает обработку
+func (s *Service) addPollEvent(ctx context.Context, workerID int, order *ordersRepo.Order, event *ordersRepo.OrderEvent) {
+	event.OrderID = order.ID
+	event.EventType = ordersRepo.EventAccrualPoll
+	if _, err := s.repo.AddPollEvent(ctx, event); err != nil {
+		s.logger.Errorw("Failed to add order event",
+			"worker_id", workerID,
+			"order_id", order.ID,
+			"event_type", event.EventType,
+			"error", err.Error())
+	}
+}
 
-	// Отправляем сигнал остановки всем воркерам
-	close(s.stopChan)
+func stringPtr(value string) *string {
+	return &value
+}
 
-	// Закрываем канал rate limiting
-	close(s.rateLimitChan)
+// WorkerStatus возвращает число работающих воркеров; меньше запущенного оно становится
+// только после остановки сервиса или аварийного завершения воркера
+func (s *Service) WorkerStatus() *WorkersDTO {
+	status := &WorkersDTO{
+		Running: int(s.running.Load()),
+		Total:   maxWorkers,
+	}
+	select {
+	case <-s.stopChan:
+		status.Stopped = true
+	default:
+	}
+	return status
+}
+
+// Stop останавливает воркеры и дожидается, пока они доработают текущие заказы.
+// Канал rate limiting не закрывается: воркер может отправить в него сигнал до остановки.
+func (s *Service) Stop() {
+	s.stopOnce.Do(func() {
+		s.logger.Info("Stopping order service...")
+		close(s.stopChan)
+	})
+	s.wg.Wait()
 
 	s.logger.Info("Order service stopped")
 }
@@ -0,0 +1,21 @@
+package password
+
+// ChangeInDTO представляет запрос на смену пароля
+type ChangeInDTO struct {
+	OldPassword string `json:"old_password"`
+	NewPassword string `json:"new_password"`
+
+	// Текущая сессия остается активной, остальные сессии пользователя завершаются
+	SessionID int `json:"-"`
+}
+
+// ResetInDTO представляет запрос на установку нового пароля по токену сброса
+type ResetInDTO struct {
+	Token       string `json:"token"`
+	NewPassword string `json:"new_password"`
+}
+
+// ChangeOutDTO представляет результат смены пароля
+type ChangeOutDTO struct {
+	TokenVersion int `json:"token_version"`
+}
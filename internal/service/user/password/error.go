@@ -0,0 +1,19 @@
+package password
+
+import "errors"
+
+var (
+	ErrOldPasswordIsRequired   = errors.New("old password is required")
+	ErrWrongOldPassword        = errors.New("old password is wrong")
+	ErrSamePassword            = errors.New("new password must differ from the old one")
+	ErrResetTokenIsRequired    = errors.New("reset token is required")
+	ErrInvalidResetToken       = errors.New("reset token is invalid, expired or already used")
+	ErrFailedToGenerateToken   = errors.New("failed to generate reset token")
+	ErrFailedToProcessPassword = errors.New("failed to process password")
+)
+
+func IsErrOldPasswordIsRequired(err error) bool { return errors.Is(err, ErrOldPasswordIsRequired) }
+func IsErrWrongOldPassword(err error) bool      { return errors.Is(err, ErrWrongOldPassword) }
+func IsErrSamePassword(err error) bool          { return errors.Is(err, ErrSamePassword) }
+func IsErrResetTokenIsRequired(err error) bool  { return errors.Is(err, ErrResetTokenIsRequired) }
+func IsErrInvalidResetToken(err error) bool     { return errors.Is(err, ErrInvalidResetToken) }
@@ -0,0 +1,9 @@
+package password
+
+import "context"
+
+type ServiceInterface interface {
+	ChangePassword(ctx context.Context, userID int, dtoIn *ChangeInDTO) (*ChangeOutDTO, error)
+	RequestReset(ctx context.Context, login string) error
+	ResetPassword(ctx context.Context, dtoIn *ResetInDTO) error
+}
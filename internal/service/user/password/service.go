@@ -0,0 +1,275 @@
+package password
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"gophermart-service/internal/base"
+	"gophermart-service/internal/config"
+	"gophermart-service/internal/integration/notifier"
+	passwordResetRepo "gophermart-service/internal/repository/passwordreset"
+	sessionsRepo "gophermart-service/internal/repository/sessions"
+	usersRepo "gophermart-service/internal/repository/users"
+	"gophermart-service/internal/service/hasher"
+	userAuth "gophermart-service/internal/service/user/auth"
+	"strings"
+	"time"
+)
+
+const resetTokenBytes = 32
+
+// Service представляет сервис смены и сброса пароля
+type Service struct {
+	logger            config.LoggerInterface
+	settings          *config.PasswordSettings
+	usersRepo         usersRepo.RepositoryInterface
+	passwordResetRepo passwordResetRepo.RepositoryInterface
+	sessionsRepo      sessionsRepo.RepositoryInterface
+	authService       userAuth.ServiceInterface
+	notifier          notifier.NotifierInterface
+}
+
+// NewPasswordService создает новый экземпляр сервиса смены и сброса пароля
+func NewPasswordService(
+	logger config.LoggerInterface,
+	settings *config.PasswordSettings,
+	usersRepo usersRepo.RepositoryInterface,
+	passwordResetRepo passwordResetRepo.RepositoryInterface,
+	sessionsRepo sessionsRepo.RepositoryInterface,
+	authService userAuth.ServiceInterface,
+	notifier notifier.NotifierInterface,
+) ServiceInterface {
+	return &Service{
+		logger:            logger,
+		settings:          settings,
+		usersRepo:         usersRepo,
+		passwordResetRepo: passwordResetRepo,
+		sessionsRepo:      sessionsRepo,
+		authService:       authService,
+		notifier:          notifier,
+	}
+}
+
+// ChangePassword меняет пароль аутентифицированного пользователя и отзывает его токены
+func (s *Service) ChangePassword(ctx context.Context, userID int, dtoIn *ChangeInDTO) (*ChangeOutDTO, error) {
+	requestID := base.GetRequestID(ctx)
+
+	s.logger.Infow("Password change initiated",
+		"requestID", requestID,
+		"userID", userID)
+
+	if strings.TrimSpace(dtoIn.OldPassword) == "" {
+		return nil, ErrOldPasswordIsRequired
+	}
+	if dtoIn.OldPassword == dtoIn.NewPassword {
+		return nil, ErrSamePassword
+	}
+	if err := s.validateNewPassword(ctx, userID, dtoIn.NewPassword); err != nil {
+		return nil, err
+	}
+
+	hashPassword, err := s.usersRepo.GetUserHashPasswordByID(ctx, userID)
+	if err != nil {
+		if usersRepo.IsErrUserNotFound(err) {
+			return nil, userAuth.ErrUserNotFound
+		}
+		return nil, err
+	}
+
+	if err = s.authService.VerifyPassword(hashPassword, dtoIn.OldPassword); err != nil {
+		s.logger.Warnw("Password change rejected: wrong old password",
+			"requestID", requestID,
+			"userID", userID)
+		return nil, ErrWrongOldPassword
+	}
+
+	tokenVersion, err := s.updatePassword(ctx, userID, dtoIn.NewPassword, dtoIn.SessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Infow("Password changed successfully",
+		"requestID", requestID,
+		"userID", userID)
+
+	return &ChangeOutDTO{TokenVersion: tokenVersion}, nil
+}
+
+// RequestReset выпускает одноразовый токен сброса пароля и отправляет его пользователю.
+// Отсутствие пользователя не считается ошибкой, чтобы не раскрывать существование логина.
+func (s *Service) RequestReset(ctx context.Context, login string) error {
+	requestID := base.GetRequestID(ctx)
+
+	s.logger.Infow("Password reset requested",
+		"requestID", requestID,
+		"login", login)
+
+	userID, err := s.usersRepo.GetUserIDByLogin(ctx, login)
+	if err != nil {
+		if usersRepo.IsErrUserNotFound(err) {
+			s.logger.Warnw("Password reset requested for unknown login",
+				"requestID", requestID,
+				"login", login)
+			return nil
+		}
+		return err
+	}
+
+	token, err := generateResetToken()
+	if err != nil {
+		s.logger.Errorw("Failed to generate reset token",
+			"requestID", requestID,
+			"error", err)
+		return ErrFailedToGenerateToken
+	}
+
+	expiresAt := time.Now().Add(s.settings.ResetTokenTTL)
+	if err = s.passwordResetRepo.Add(ctx, userID, hashResetToken(token), expiresAt); err != nil {
+		s.logger.Errorw("Failed to store reset token",
+			"requestID", requestID,
+			"userID", userID,
+			"error", err)
+		return err
+	}
+
+	if err = s.notifier.Send(ctx, &notifier.Message{
+		To:      login,
+		Subject: "Сброс пароля",
+		Body:    s.buildResetMessage(token, expiresAt),
+	}); err != nil {
+		s.logger.Errorw("Failed to send reset token",
+			"requestID", requestID,
+			"userID", userID,
+			"error", err)
+		return err
+	}
+
+	s.logger.Infow("Password reset token issued",
+		"requestID", requestID,
+		"userID", userID,
+		"expiresAt", expiresAt)
+
+	return nil
+}
+
+// ResetPassword устанавливает новый пароль по одноразовому токену сброса
+func (s *Service) ResetPassword(ctx context.Context, dtoIn *ResetInDTO) error {
+	requestID := base.GetRequestID(ctx)
+
+	if strings.TrimSpace(dtoIn.Token) == "" {
+		return ErrResetTokenIsRequired
+	}
+	tokenHash := hashResetToken(dtoIn.Token)
+
+	// Пароль проверяется до погашения токена, чтобы отклоненный пароль не сжигал токен
+	userID, err := s.passwordResetRepo.GetUserID(ctx, tokenHash)
+	if err != nil {
+		if passwordResetRepo.IsErrTokenNotFound(err) {
+			s.logger.Warnw("Invalid password reset token", "requestID", requestID)
+			return ErrInvalidResetToken
+		}
+		return err
+	}
+	if err = s.validateNewPassword(ctx, userID, dtoIn.NewPassword); err != nil {
+		return err
+	}
+
+	userID, err = s.passwordResetRepo.Consume(ctx, tokenHash)
+	if err != nil {
+		if passwordResetRepo.IsErrTokenNotFound(err) {
+			s.logger.Warnw("Invalid password reset token", "requestID", requestID)
+			return ErrInvalidResetToken
+		}
+		return err
+	}
+
+	if _, err = s.updatePassword(ctx, userID, dtoIn.NewPassword, 0); err != nil {
+		return err
+	}
+
+	s.logger.Infow("Password reset completed",
+		"requestID", requestID,
+		"userID", userID)
+
+	return nil
+}
+
+// validateNewPassword проверяет новый пароль пользователя политикой сложности
+func (s *Service) validateNewPassword(ctx context.Context, userID int, newPassword string) error {
+	login, err := s.usersRepo.GetLoginByID(ctx, userID)
+	if err != nil {
+		if usersRepo.IsErrUserNotFound(err) {
+			return userAuth.ErrUserNotFound
+		}
+		return err
+	}
+
+	return s.authService.ValidateNewPassword(login, newPassword)
+}
+
+// updatePassword сохраняет новый пароль, отзывает выданные токены, неиспользованные токены сброса
+// и завершает все сессии пользователя, кроме keepSessionID
+func (s *Service) updatePassword(ctx context.Context, userID int, newPassword string, keepSessionID int) (int, error) {
+	requestID := base.GetRequestID(ctx)
+
+	passwordHash, err := s.authService.HashPassword(newPassword)
+	if err != nil {
+		if hasher.IsErrPasswordTooLong(err) {
+			return 0, userAuth.ErrPasswordTooLong
+		}
+		s.logger.Errorw("Password hashing failed",
+			"requestID", requestID,
+			"error", err)
+		return 0, ErrFailedToProcessPassword
+	}
+
+	tokenVersion, err := s.usersRepo.UpdatePassword(ctx, userID, passwordHash)
+	if err != nil {
+		s.logger.Errorw("Failed to update password",
+			"requestID", requestID,
+			"userID", userID,
+			"error", err)
+		return 0, err
+	}
+
+	if err = s.passwordResetRepo.DeleteUserTokens(ctx, userID); err != nil {
+		s.logger.Errorw("Failed to delete password reset tokens",
+			"requestID", requestID,
+			"userID", userID,
+			"error", err)
+	}
+
+	if err = s.sessionsRepo.RevokeAllExcept(ctx, userID, keepSessionID); err != nil {
+		s.logger.Errorw("Failed to terminate sessions",
+			"requestID", requestID,
+			"userID", userID,
+			"error", err)
+	}
+
+	return tokenVersion, nil
+}
+
+func (s *Service) buildResetMessage(token string, expiresAt time.Time) string {
+	if s.settings.ResetURL != "" {
+		return fmt.Sprintf("Для сброса пароля перейдите по ссылке %s?token=%s до %s",
+			s.settings.ResetURL, token, expiresAt.Format(time.RFC3339))
+	}
+	return fmt.Sprintf("Токен для сброса пароля: %s (действителен до %s)",
+		token, expiresAt.Format(time.RFC3339))
+}
+
+func generateResetToken() (string, error) {
+	buf := make([]byte, resetTokenBytes)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
+
+// hashResetToken возвращает SHA-256 от токена: в БД хранится только хеш
+func hashResetToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
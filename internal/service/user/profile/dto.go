@@ -0,0 +1,48 @@
+package profile
+
+import (
+	"bytes"
+	"encoding/json"
+	"time"
+)
+
+// OutDTO представляет профиль пользователя
+type OutDTO struct {
+	Login              string     `json:"login"`
+	DisplayName        *string    `json:"display_name"`
+	Email              *string    `json:"email"`
+	EmailVerified      bool       `json:"email_verified"`
+	Phone              *string    `json:"phone"`
+	MarketingConsent   bool       `json:"marketing_consent"`
+	MarketingConsentAt *time.Time `json:"marketing_consent_at,omitempty"`
+}
+
+// UpdateInDTO представляет частичное обновление профиля (PATCH).
+// Отсутствующее поле не меняется, значение null очищает поле.
+type UpdateInDTO struct {
+	DisplayName      OptionalString `json:"display_name"`
+	Email            OptionalString `json:"email"`
+	Phone            OptionalString `json:"phone"`
+	MarketingConsent *bool          `json:"marketing_consent"`
+}
+
+// OptionalString различает отсутствующее поле и явный null в JSON
+type OptionalString struct {
+	Set   bool
+	Value *string
+}
+
+func (o *OptionalString) UnmarshalJSON(data []byte) error {
+	o.Set = true
+	if bytes.Equal(data, []byte("null")) {
+		o.Value = nil
+		return nil
+	}
+
+	var value string
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+	o.Value = &value
+	return nil
+}
@@ -0,0 +1,33 @@
+package profile
+
+import "errors"
+
+var (
+	ErrUserNotFound             = errors.New("user not found")
+	ErrDisplayNameTooLong       = errors.New("display name is too long")
+	ErrInvalidEmail             = errors.New("invalid email")
+	ErrInvalidPhone             = errors.New("invalid phone number, expected E.164 format like +79991234567")
+	ErrEmailAlreadyVerified     = errors.New("email is already used by another user")
+	ErrVerificationTokenInvalid = errors.New("email verification token is invalid or expired")
+	ErrVerificationTokenMissing = errors.New("email verification token is required")
+	ErrFailedToGenerateToken    = errors.New("failed to generate email verification token")
+)
+
+func IsErrUserNotFound(err error) bool         { return errors.Is(err, ErrUserNotFound) }
+func IsErrDisplayNameTooLong(err error) bool   { return errors.Is(err, ErrDisplayNameTooLong) }
+func IsErrInvalidEmail(err error) bool         { return errors.Is(err, ErrInvalidEmail) }
+func IsErrInvalidPhone(err error) bool         { return errors.Is(err, ErrInvalidPhone) }
+func IsErrEmailAlreadyVerified(err error) bool { return errors.Is(err, ErrEmailAlreadyVerified) }
+func IsErrVerificationTokenInvalid(err error) bool {
+	return errors.Is(err, ErrVerificationTokenInvalid)
+}
+func IsErrVerificationTokenMissing(err error) bool {
+	return errors.Is(err, ErrVerificationTokenMissing)
+}
+
+// IsValidationError сообщает, что ошибка вызвана некорректными данными профиля
+func IsValidationError(err error) bool {
+	return IsErrDisplayNameTooLong(err) ||
+		IsErrInvalidEmail(err) ||
+		IsErrInvalidPhone(err)
+}
@@ -0,0 +1,9 @@
+package profile
+
+import "context"
+
+type ServiceInterface interface {
+	Get(ctx context.Context, userID int) (*OutDTO, error)
+	Update(ctx context.Context, userID int, dtoIn *UpdateInDTO) (*OutDTO, error)
+	VerifyEmail(ctx context.Context, token string) error
+}
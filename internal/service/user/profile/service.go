@@ -0,0 +1,216 @@
+package profile
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"gophermart-service/internal/base"
+	"gophermart-service/internal/config"
+	"gophermart-service/internal/integration/notifier"
+	emailVerificationRepo "gophermart-service/internal/repository/emailverification"
+	usersRepo "gophermart-service/internal/repository/users"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const verificationTokenBytes = 32
+
+// Service представляет сервис профиля пользователя
+type Service struct {
+	logger                config.LoggerInterface
+	settings              *config.ProfileSettings
+	usersRepo             usersRepo.RepositoryInterface
+	emailVerificationRepo emailVerificationRepo.RepositoryInterface
+	notifier              notifier.NotifierInterface
+}
+
+// NewProfileService создает новый экземпляр сервиса профиля
+func NewProfileService(
+	logger config.LoggerInterface,
+	settings *config.ProfileSettings,
+	usersRepo usersRepo.RepositoryInterface,
+	emailVerificationRepo emailVerificationRepo.RepositoryInterface,
+	notifier notifier.NotifierInterface,
+) ServiceInterface {
+	return &Service{
+		logger:                logger,
+		settings:              settings,
+		usersRepo:             usersRepo,
+		emailVerificationRepo: emailVerificationRepo,
+		notifier:              notifier,
+	}
+}
+
+func (s *Service) Get(ctx context.Context, userID int) (*OutDTO, error) {
+	profile, err := s.usersRepo.GetProfile(ctx, userID)
+	if err != nil {
+		if usersRepo.IsErrUserNotFound(err) {
+			return nil, ErrUserNotFound
+		}
+		return nil, err
+	}
+	return toOutDTO(profile), nil
+}
+
+// Update применяет частичное обновление профиля. При смене email подтверждение сбрасывается
+// и на новый адрес отправляется ссылка для подтверждения.
+func (s *Service) Update(ctx context.Context, userID int, dtoIn *UpdateInDTO) (*OutDTO, error) {
+	requestID := base.GetRequestID(ctx)
+
+	profile, err := s.usersRepo.GetProfile(ctx, userID)
+	if err != nil {
+		if usersRepo.IsErrUserNotFound(err) {
+			return nil, ErrUserNotFound
+		}
+		return nil, err
+	}
+
+	if dtoIn.DisplayName.Set {
+		if profile.DisplayName, err = normalizeDisplayName(dtoIn.DisplayName.Value); err != nil {
+			return nil, err
+		}
+	}
+	if dtoIn.Phone.Set {
+		if profile.Phone, err = normalizePhone(dtoIn.Phone.Value); err != nil {
+			return nil, err
+		}
+	}
+
+	emailChanged := false
+	if dtoIn.Email.Set {
+		email, err := normalizeEmail(dtoIn.Email.Value)
+		if err != nil {
+			return nil, err
+		}
+		if !sameEmail(profile.Email, email) {
+			profile.Email = email
+			profile.EmailVerifiedAt = nil
+			emailChanged = true
+		}
+	}
+
+	if dtoIn.MarketingConsent != nil && *dtoIn.MarketingConsent != profile.MarketingConsent {
+		profile.MarketingConsent = *dtoIn.MarketingConsent
+		profile.MarketingConsentAt = nil
+		if profile.MarketingConsent {
+			now := time.Now()
+			profile.MarketingConsentAt = &now
+		}
+	}
+
+	if err = s.usersRepo.UpdateProfile(ctx, profile); err != nil {
+		if usersRepo.IsErrEmailAlreadyVerified(err) {
+			return nil, ErrEmailAlreadyVerified
+		}
+		s.logger.Errorw("Failed to update profile", "requestID", requestID, "userID", userID, "error", err)
+		return nil, err
+	}
+
+	if emailChanged {
+		if err = s.emailVerificationRepo.DeleteUserTokens(ctx, userID); err != nil {
+			s.logger.Warnw("Failed to delete email verification tokens", "requestID", requestID, "userID", userID, "error", err)
+		}
+		if profile.Email != nil {
+			if err = s.sendVerification(ctx, userID, *profile.Email); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	s.logger.Infow("Profile updated", "requestID", requestID, "userID", userID, "emailChanged", emailChanged)
+	return toOutDTO(profile), nil
+}
+
+// VerifyEmail подтверждает email по токену из письма
+func (s *Service) VerifyEmail(ctx context.Context, token string) error {
+	requestID := base.GetRequestID(ctx)
+
+	if strings.TrimSpace(token) == "" {
+		return ErrVerificationTokenMissing
+	}
+
+	userID, email, err := s.emailVerificationRepo.Consume(ctx, hashToken(token))
+	if err != nil {
+		if emailVerificationRepo.IsErrTokenNotFound(err) {
+			return ErrVerificationTokenInvalid
+		}
+		return err
+	}
+
+	if err = s.usersRepo.MarkEmailVerified(ctx, userID, email); err != nil {
+		switch {
+		case usersRepo.IsErrUserNotFound(err):
+			// Email изменен после отправки письма
+			return ErrVerificationTokenInvalid
+		case usersRepo.IsErrEmailAlreadyVerified(err):
+			return ErrEmailAlreadyVerified
+		}
+		s.logger.Errorw("Failed to mark email verified", "requestID", requestID, "userID", userID, "error", err)
+		return err
+	}
+
+	s.logger.Infow("Email verified", "requestID", requestID, "userID", userID)
+	return nil
+}
+
+func (s *Service) sendVerification(ctx context.Context, userID int, email string) error {
+	requestID := base.GetRequestID(ctx)
+
+	buf := make([]byte, verificationTokenBytes)
+	if _, err := rand.Read(buf); err != nil {
+		return ErrFailedToGenerateToken
+	}
+	token := hex.EncodeToString(buf)
+	expiresAt := time.Now().Add(s.settings.EmailVerificationTTL)
+
+	if err := s.emailVerificationRepo.Add(ctx, userID, email, hashToken(token), expiresAt); err != nil {
+		s.logger.Errorw("Failed to store email verification token", "requestID", requestID, "userID", userID, "error", err)
+		return err
+	}
+
+	if err := s.notifier.Send(ctx, &notifier.Message{
+		To:      email,
+		Subject: "Подтверждение email",
+		Body:    s.buildVerificationMessage(token, expiresAt),
+	}); err != nil {
+		s.logger.Errorw("Failed to send email verification", "requestID", requestID, "userID", userID, "error", err)
+		return err
+	}
+	return nil
+}
+
+func (s *Service) buildVerificationMessage(token string, expiresAt time.Time) string {
+	if s.settings.EmailVerificationURL != "" {
+		return fmt.Sprintf("Для подтверждения email перейдите по ссылке %s?token=%s до %s",
+			s.settings.EmailVerificationURL, url.QueryEscape(token), expiresAt.Format(time.RFC3339))
+	}
+	return fmt.Sprintf("Токен подтверждения email: %s (действителен до %s)",
+		token, expiresAt.Format(time.RFC3339))
+}
+
+func toOutDTO(profile *usersRepo.Profile) *OutDTO {
+	return &OutDTO{
+		Login:              profile.Login,
+		DisplayName:        profile.DisplayName,
+		Email:              profile.Email,
+		EmailVerified:      profile.EmailVerifiedAt != nil,
+		Phone:              profile.Phone,
+		MarketingConsent:   profile.MarketingConsent,
+		MarketingConsentAt: profile.MarketingConsentAt,
+	}
+}
+
+func sameEmail(current, next *string) bool {
+	if current == nil || next == nil {
+		return current == nil && next == nil
+	}
+	return strings.EqualFold(*current, *next)
+}
+
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,78 @@
+package profile
+
+import (
+	"net/mail"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	MaxDisplayNameLength = 100
+	MaxEmailLength       = 255
+)
+
+// phonePattern описывает номер телефона в формате E.164
+var phonePattern = regexp.MustCompile(`^\+[1-9][0-9]{6,14}$`)
+
+// normalizeDisplayName обрезает пробелы; пустое значение очищает поле
+func normalizeDisplayName(value *string) (*string, error) {
+	if value == nil {
+		return nil, nil
+	}
+	displayName := strings.TrimSpace(*value)
+	if displayName == "" {
+		return nil, nil
+	}
+	if utf8.RuneCountInString(displayName) > MaxDisplayNameLength {
+		return nil, ErrDisplayNameTooLong
+	}
+	return &displayName, nil
+}
+
+// normalizeEmail проверяет адрес и приводит доменную часть к нижнему регистру
+func normalizeEmail(value *string) (*string, error) {
+	if value == nil {
+		return nil, nil
+	}
+	email := strings.TrimSpace(*value)
+	if email == "" {
+		return nil, nil
+	}
+	if len(email) > MaxEmailLength {
+		return nil, ErrInvalidEmail
+	}
+
+	address, err := mail.ParseAddress(email)
+	if err != nil || address.Address != email || address.Name != "" {
+		return nil, ErrInvalidEmail
+	}
+	local, domain, ok := strings.Cut(email, "@")
+	if !ok || local == "" || !strings.Contains(domain, ".") {
+		return nil, ErrInvalidEmail
+	}
+
+	email = local + "@" + strings.ToLower(domain)
+	return &email, nil
+}
+
+// normalizePhone удаляет пробелы, дефисы и скобки и проверяет формат E.164
+func normalizePhone(value *string) (*string, error) {
+	if value == nil {
+		return nil, nil
+	}
+	phone := strings.Map(func(r rune) rune {
+		switch r {
+		case ' ', '-', '(', ')':
+			return -1
+		}
+		return r
+	}, strings.TrimSpace(*value))
+	if phone == "" {
+		return nil, nil
+	}
+	if !phonePattern.MatchString(phone) {
+		return nil, ErrInvalidPhone
+	}
+	return &phone, nil
+}
@@ -0,0 +1,22 @@
+package session
+
+import "time"
+
+// CreateInDTO описывает клиента, которому выдается токен
+type CreateInDTO struct {
+	UserID    int
+	UserAgent string
+	IPAddress string
+	ExpiresAt time.Time
+}
+
+// OutDTO представляет активную сессию пользователя
+type OutDTO struct {
+	ID         int       `json:"id"`
+	UserAgent  string    `json:"user_agent"`
+	IPAddress  string    `json:"ip_address"`
+	CreatedAt  time.Time `json:"created_at"`
+	LastSeenAt time.Time `json:"last_seen_at"`
+	ExpiresAt  time.Time `json:"expires_at"`
+	Current    bool      `json:"current"`
+}
@@ -0,0 +1,11 @@
+package session
+
+import "errors"
+
+var (
+	ErrSessionNotFound   = errors.New("session not found")
+	ErrSessionTerminated = errors.New("session is terminated")
+)
+
+func IsErrSessionNotFound(err error) bool   { return errors.Is(err, ErrSessionNotFound) }
+func IsErrSessionTerminated(err error) bool { return errors.Is(err, ErrSessionTerminated) }
@@ -0,0 +1,11 @@
+package session
+
+import "context"
+
+type ServiceInterface interface {
+	Create(ctx context.Context, dtoIn *CreateInDTO) (int, error)
+	List(ctx context.Context, userID, currentSessionID int) ([]*OutDTO, error)
+	Terminate(ctx context.Context, userID, sessionID int) error
+	TerminateOthers(ctx context.Context, userID, currentSessionID int) error
+	Validate(ctx context.Context, userID, sessionID int) error
+}
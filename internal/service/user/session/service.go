@@ -0,0 +1,143 @@
+package session
+
+import (
+	"context"
+	"gophermart-service/internal/base"
+	"gophermart-service/internal/config"
+	sessionsRepo "gophermart-service/internal/repository/sessions"
+	"time"
+)
+
+const (
+	maxUserAgentLength = 512
+	maxIPAddressLength = 64
+
+	// lastSeenInterval ограничивает частоту обновления времени последней активности
+	lastSeenInterval = time.Minute
+)
+
+// Service представляет сервис сессий пользователей
+type Service struct {
+	logger config.LoggerInterface
+	repo   sessionsRepo.RepositoryInterface
+}
+
+// NewSessionService создает новый экземпляр сервиса сессий
+func NewSessionService(
+	logger config.LoggerInterface,
+	repo sessionsRepo.RepositoryInterface,
+) ServiceInterface {
+	return &Service{
+		logger: logger,
+		repo:   repo,
+	}
+}
+
+// Create регистрирует новую сессию и возвращает ее идентификатор для claim sid
+func (s *Service) Create(ctx context.Context, dtoIn *CreateInDTO) (int, error) {
+	sessionID, err := s.repo.Add(ctx, &sessionsRepo.Session{
+		UserID:    dtoIn.UserID,
+		UserAgent: truncate(dtoIn.UserAgent, maxUserAgentLength),
+		IPAddress: truncate(dtoIn.IPAddress, maxIPAddressLength),
+		ExpiresAt: dtoIn.ExpiresAt,
+	})
+	if err != nil {
+		s.logger.Errorw("Failed to create session",
+			"requestID", base.GetRequestID(ctx),
+			"userID", dtoIn.UserID,
+			"error", err)
+		return 0, err
+	}
+	return sessionID, nil
+}
+
+// List возвращает активные сессии пользователя, помечая текущую
+func (s *Service) List(ctx context.Context, userID, currentSessionID int) ([]*OutDTO, error) {
+	sessions, err := s.repo.GetActiveUserSessions(ctx, userID)
+	if err != nil {
+		s.logger.Errorw("Failed to get sessions",
+			"requestID", base.GetRequestID(ctx),
+			"userID", userID,
+			"error", err)
+		return nil, err
+	}
+
+	result := make([]*OutDTO, 0, len(sessions))
+	for _, session := range sessions {
+		result = append(result, &OutDTO{
+			ID:         session.ID,
+			UserAgent:  session.UserAgent,
+			IPAddress:  session.IPAddress,
+			CreatedAt:  session.CreatedAt,
+			LastSeenAt: session.LastSeenAt,
+			ExpiresAt:  session.ExpiresAt,
+			Current:    session.ID == currentSessionID,
+		})
+	}
+	return result, nil
+}
+
+// Terminate завершает сессию пользователя; выданные в ней токены перестают приниматься
+func (s *Service) Terminate(ctx context.Context, userID, sessionID int) error {
+	requestID := base.GetRequestID(ctx)
+
+	if err := s.repo.Revoke(ctx, userID, sessionID); err != nil {
+		if sessionsRepo.IsErrSessionNotFound(err) {
+			return ErrSessionNotFound
+		}
+		s.logger.Errorw("Failed to terminate session",
+			"requestID", requestID,
+			"userID", userID,
+			"sessionID", sessionID,
+			"error", err)
+		return err
+	}
+
+	s.logger.Infow("Session terminated", "requestID", requestID, "userID", userID, "sessionID", sessionID)
+	return nil
+}
+
+// TerminateOthers завершает все сессии пользователя, кроме текущей
+func (s *Service) TerminateOthers(ctx context.Context, userID, currentSessionID int) error {
+	if err := s.repo.RevokeAllExcept(ctx, userID, currentSessionID); err != nil {
+		s.logger.Errorw("Failed to terminate other sessions",
+			"requestID", base.GetRequestID(ctx),
+			"userID", userID,
+			"error", err)
+		return err
+	}
+	return nil
+}
+
+// Validate проверяет, что сессия активна, и обновляет время последней активности
+func (s *Service) Validate(ctx context.Context, userID, sessionID int) error {
+	session, err := s.repo.Get(ctx, userID, sessionID)
+	if err != nil {
+		if sessionsRepo.IsErrSessionNotFound(err) {
+			return ErrSessionNotFound
+		}
+		return err
+	}
+
+	now := time.Now()
+	if session.RevokedAt != nil || !session.ExpiresAt.After(now) {
+		return ErrSessionTerminated
+	}
+
+	if now.Sub(session.LastSeenAt) >= lastSeenInterval {
+		if err = s.repo.TouchLastSeen(ctx, sessionID); err != nil {
+			s.logger.Warnw("Failed to update session last seen",
+				"requestID", base.GetRequestID(ctx),
+				"sessionID", sessionID,
+				"error", err)
+		}
+	}
+	return nil
+}
+
+func truncate(value string, maxLength int) string {
+	if len(value) > maxLength {
+		return value[:maxLength]
+	}
+	return value
+}
@@ -0,0 +1,33 @@
+package statement
+
+import (
+	"context"
+	"io"
+)
+
+// Форматы выписки
+const (
+	FormatCSV = "csv"
+	FormatPDF = "pdf"
+)
+
+// ExportInDTO параметры выписки: формат и период в формате списков (RFC 3339 или YYYY-MM-DD)
+type ExportInDTO struct {
+	Format string
+	From   string
+	To     string
+}
+
+// Export подготовленная выписка. Параметры уже проверены и первая страница прочитана,
+// поэтому ошибки до начала передачи можно вернуть клиенту обычным ответом.
+type Export struct {
+	Filename    string
+	ContentType string
+
+	stream func(ctx context.Context, w io.Writer) error
+}
+
+// Stream постранично читает записи из БД и пишет выписку в w
+func (e *Export) Stream(ctx context.Context, w io.Writer) error {
+	return e.stream(ctx, w)
+}
@@ -0,0 +1,7 @@
+package statement
+
+import "errors"
+
+var ErrUnsupportedFormat = errors.New("unsupported export format, expected csv or pdf")
+
+func IsErrUnsupportedFormat(err error) bool { return errors.Is(err, ErrUnsupportedFormat) }
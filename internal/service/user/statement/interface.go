@@ -0,0 +1,8 @@
+package statement
+
+import "context"
+
+type ServiceInterface interface {
+	ExportOrders(ctx context.Context, userID int, in *ExportInDTO) (*Export, error)
+	ExportWithdrawals(ctx context.Context, userID int, in *ExportInDTO) (*Export, error)
+}
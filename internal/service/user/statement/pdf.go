@@ -0,0 +1,228 @@
+package statement
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Разметка страницы A4 в пунктах
+const (
+	pdfPageWidth    = 595.0
+	pdfPageHeight   = 842.0
+	pdfMargin       = 40.0
+	pdfLineHeight   = 14.0
+	pdfFontSize     = 9.0
+	pdfTitleSize    = 14.0
+	pdfFooterOffset = 25.0
+)
+
+// Номера объектов, известные заранее. Дерево страниц пишется последним,
+// когда известны все страницы, но ссылаться на него можно сразу.
+const (
+	pdfCatalogObject  = 1
+	pdfPagesObject    = 2
+	pdfFontObject     = 3
+	pdfBoldFontObject = 4
+	pdfFirstFreeObj   = 5
+)
+
+// pdfWriter пишет простую табличную выписку в PDF постранично: каждая заполненная страница
+// сразу уходит клиенту, в памяти остаются только смещения объектов для таблицы xref.
+// Используются стандартные шрифты Helvetica, поэтому текст ограничен символами ASCII.
+type pdfWriter struct {
+	w       *countingWriter
+	table   *table
+	offsets map[int]int64
+	pages   []int
+	nextObj int
+
+	page *bytes.Buffer
+	y    float64
+}
+
+func newPDFWriter(w io.Writer, t *table) (rowWriter, error) {
+	writer := &pdfWriter{
+		w:       &countingWriter{w: w},
+		table:   t,
+		offsets: make(map[int]int64),
+		nextObj: pdfFirstFreeObj,
+	}
+
+	if _, err := io.WriteString(writer.w, "%PDF-1.4\n%\xe2\xe3\xcf\xd3\n"); err != nil {
+		return nil, err
+	}
+	objects := []struct {
+		id   int
+		body string
+	}{
+		{pdfCatalogObject, fmt.Sprintf("<< /Type /Catalog /Pages %d 0 R >>", pdfPagesObject)},
+		{pdfFontObject, "<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>"},
+		{pdfBoldFontObject, "<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>"},
+	}
+	for _, object := range objects {
+		if err := writer.writeObject(object.id, object.body); err != nil {
+			return nil, err
+		}
+	}
+
+	writer.startPage()
+	return writer, nil
+}
+
+func (w *pdfWriter) WriteRow(values []string) error {
+	if w.y < pdfMargin+pdfLineHeight {
+		if err := w.finishPage(); err != nil {
+			return err
+		}
+		w.startPage()
+	}
+
+	x := pdfMargin
+	for i, value := range values {
+		w.text("F1", pdfFontSize, x, w.y, value)
+		if i < len(w.table.Columns) {
+			x += w.table.Columns[i].Width
+		}
+	}
+	w.y -= pdfLineHeight
+	return nil
+}
+
+// Flush ничего не делает: страница уходит клиенту целиком, когда заполнена
+func (w *pdfWriter) Flush() error {
+	return nil
+}
+
+func (w *pdfWriter) Close(summary []string) error {
+	if len(summary) > 0 {
+		w.y -= pdfLineHeight / 2
+		for _, line := range summary {
+			if w.y < pdfMargin+pdfLineHeight {
+				if err := w.finishPage(); err != nil {
+					return err
+				}
+				w.startPage()
+			}
+			w.text("F2", pdfFontSize, pdfMargin, w.y, line)
+			w.y -= pdfLineHeight
+		}
+	}
+	if err := w.finishPage(); err != nil {
+		return err
+	}
+
+	kids := make([]string, 0, len(w.pages))
+	for _, id := range w.pages {
+		kids = append(kids, fmt.Sprintf("%d 0 R", id))
+	}
+	if err := w.writeObject(pdfPagesObject, fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>",
+		strings.Join(kids, " "), len(w.pages))); err != nil {
+		return err
+	}
+
+	xrefOffset := w.w.n
+	var xref bytes.Buffer
+	fmt.Fprintf(&xref, "xref\n0 %d\n0000000000 65535 f \n", w.nextObj)
+	for id := 1; id < w.nextObj; id++ {
+		fmt.Fprintf(&xref, "%010d 00000 n \n", w.offsets[id])
+	}
+	fmt.Fprintf(&xref, "trailer\n<< /Size %d /Root %d 0 R >>\nstartxref\n%d\n%%%%EOF\n",
+		w.nextObj, pdfCatalogObject, xrefOffset)
+	_, err := w.w.Write(xref.Bytes())
+	return err
+}
+
+// startPage начинает страницу: на первой заголовок выписки, на каждой шапка таблицы
+func (w *pdfWriter) startPage() {
+	w.page = &bytes.Buffer{}
+	w.y = pdfPageHeight - pdfMargin
+
+	if len(w.pages) == 0 {
+		w.text("F2", pdfTitleSize, pdfMargin, w.y, w.table.Title)
+		w.y -= pdfLineHeight * 1.5
+		for _, line := range w.table.Subtitle {
+			w.text("F1", pdfFontSize, pdfMargin, w.y, line)
+			w.y -= pdfLineHeight
+		}
+		w.y -= pdfLineHeight
+	}
+
+	x := pdfMargin
+	for _, c := range w.table.Columns {
+		w.text("F2", pdfFontSize, x, w.y, c.Title)
+		x += c.Width
+	}
+	lineY := w.y - 4
+	fmt.Fprintf(w.page, "0.5 w %.2f %.2f m %.2f %.2f l S\n", pdfMargin, lineY, pdfPageWidth-pdfMargin, lineY)
+	w.y -= pdfLineHeight * 1.3
+}
+
+// finishPage пишет содержимое и объект страницы
+func (w *pdfWriter) finishPage() error {
+	pageNumber := len(w.pages) + 1
+	w.text("F1", pdfFontSize-1, pdfMargin, pdfFooterOffset, fmt.Sprintf("Page %d", pageNumber))
+
+	contentID := w.allocate()
+	content := w.page.Bytes()
+	if err := w.writeObject(contentID, fmt.Sprintf("<< /Length %d >>\nstream\n%s\nendstream", len(content), content)); err != nil {
+		return err
+	}
+
+	pageID := w.allocate()
+	if err := w.writeObject(pageID, fmt.Sprintf(
+		"<< /Type /Page /Parent %d 0 R /MediaBox [0 0 %.0f %.0f] "+
+			"/Resources << /Font << /F1 %d 0 R /F2 %d 0 R >> >> /Contents %d 0 R >>",
+		pdfPagesObject, pdfPageWidth, pdfPageHeight, pdfFontObject, pdfBoldFontObject, contentID)); err != nil {
+		return err
+	}
+	w.pages = append(w.pages, pageID)
+	w.page = nil
+	return nil
+}
+
+func (w *pdfWriter) text(font string, size, x, y float64, value string) {
+	fmt.Fprintf(w.page, "BT /%s %.1f Tf %.2f %.2f Td (%s) Tj ET\n", font, size, x, y, pdfEscape(value))
+}
+
+func (w *pdfWriter) allocate() int {
+	id := w.nextObj
+	w.nextObj++
+	return id
+}
+
+func (w *pdfWriter) writeObject(id int, body string) error {
+	w.offsets[id] = w.w.n
+	_, err := fmt.Fprintf(w.w, "%d 0 obj\n%s\nendobj\n", id, body)
+	return err
+}
+
+// pdfEscape экранирует строку PDF и заменяет символы вне ASCII, которых нет в стандартном шрифте
+func pdfEscape(value string) string {
+	var b strings.Builder
+	for _, r := range value {
+		switch {
+		case r == '(' || r == ')' || r == '\\':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case r < 32 || r > 126:
+			b.WriteByte('?')
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// countingWriter считает записанные байты для смещений в таблице xref
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+	return n, err
+}
@@ -0,0 +1,145 @@
+package statement
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+var (
+	startXRefPattern  = regexp.MustCompile(`startxref\n(\d+)\n%%EOF\n$`)
+	xrefHeaderPattern = regexp.MustCompile(`^xref\n0 (\d+)\n`)
+	trailerPattern    = regexp.MustCompile(`trailer\n<< /Size (\d+) /Root 1 0 R >>`)
+	pageCountPattern  = regexp.MustCompile(`/Type /Pages /Kids \[[^\]]*\] /Count (\d+)`)
+	streamPattern     = regexp.MustCompile(`<< /Length (\d+) >>\nstream\n`)
+)
+
+// renderPDF пишет выписку из rows строк и возвращает документ целиком
+func renderPDF(t *testing.T, rows int, summary []string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	writer, err := newPDFWriter(&buf, &table{
+		Title:    "Statement",
+		Subtitle: []string{"user 1"},
+		Columns:  []column{{Title: "order", Width: 170}, {Title: "sum", Width: 90}},
+	})
+	if err != nil {
+		t.Fatalf("newPDFWriter: %v", err)
+	}
+	for i := range rows {
+		if err = writer.WriteRow([]string{fmt.Sprintf("order-%d", i), "1.00"}); err != nil {
+			t.Fatalf("WriteRow: %v", err)
+		}
+	}
+	if err = writer.Close(summary); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func atoi(t *testing.T, value []byte) int {
+	t.Helper()
+	n, err := strconv.Atoi(string(value))
+	if err != nil {
+		t.Fatalf("atoi %q: %v", value, err)
+	}
+	return n
+}
+
+func TestPDFWriterXRef(t *testing.T) {
+	tests := []struct {
+		name      string
+		rows      int
+		summary   []string
+		wantPages int
+	}{
+		{name: "empty statement", rows: 0, wantPages: 1},
+		{name: "single page with summary", rows: 10, summary: []string{"total: 10.00"}, wantPages: 1},
+		{name: "several pages", rows: 150, summary: []string{"total: 150.00"}, wantPages: 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc := renderPDF(t, tt.rows, tt.summary)
+
+			if !bytes.HasPrefix(doc, []byte("%PDF-1.4\n")) {
+				t.Fatalf("missing PDF header: %q", doc[:min(len(doc), 16)])
+			}
+			match := startXRefPattern.FindSubmatch(doc)
+			if match == nil {
+				t.Fatalf("missing startxref trailer: %q", doc[max(0, len(doc)-64):])
+			}
+			xrefOffset := atoi(t, match[1])
+			if xrefOffset >= len(doc) {
+				t.Fatalf("startxref %d is beyond the document of %d bytes", xrefOffset, len(doc))
+			}
+
+			xref := doc[xrefOffset:]
+			header := xrefHeaderPattern.FindSubmatch(xref)
+			if header == nil {
+				t.Fatalf("startxref does not point at the xref table: %q", xref[:min(len(xref), 16)])
+			}
+			size := atoi(t, header[1])
+
+			// Каждая запись — ровно 20 байт: 10 цифр смещения, 5 цифр поколения, тип и конец строки
+			entries := xref[len(header[0]):]
+			if len(entries) < size*20 {
+				t.Fatalf("xref has %d bytes of entries, want %d", len(entries), size*20)
+			}
+			if first := string(entries[:20]); first != "0000000000 65535 f \n" {
+				t.Errorf("free entry = %q", first)
+			}
+			for id := 1; id < size; id++ {
+				entry := string(entries[id*20 : (id+1)*20])
+				if !strings.HasSuffix(entry, " 00000 n \n") {
+					t.Fatalf("entry %d = %q, want an in-use entry", id, entry)
+				}
+				offset := atoi(t, []byte(entry[:10]))
+				want := fmt.Sprintf("%d 0 obj\n", id)
+				if !bytes.HasPrefix(doc[offset:], []byte(want)) {
+					t.Errorf("object %d offset %d points at %q", id, offset, doc[offset:min(len(doc), offset+16)])
+				}
+			}
+
+			trailer := trailerPattern.FindSubmatch(entries[size*20:])
+			if trailer == nil || atoi(t, trailer[1]) != size {
+				t.Errorf("trailer Size does not match %d xref entries", size)
+			}
+
+			pages := pageCountPattern.FindSubmatch(doc)
+			if pages == nil || atoi(t, pages[1]) != tt.wantPages {
+				t.Errorf("page count = %s, want %d", pages, tt.wantPages)
+			}
+			// Каталог, шрифты и дерево страниц плюс содержимое и объект на каждую страницу
+			if want := pdfFirstFreeObj + 2*tt.wantPages; size != want {
+				t.Errorf("xref size = %d, want %d", size, want)
+			}
+
+			for _, stream := range streamPattern.FindAllSubmatchIndex(doc, -1) {
+				length := atoi(t, doc[stream[2]:stream[3]])
+				if end := stream[1] + length; !bytes.HasPrefix(doc[end:], []byte("\nendstream")) {
+					t.Errorf("stream at %d: /Length %d does not end at endstream", stream[0], length)
+				}
+			}
+		})
+	}
+}
+
+func TestPDFEscape(t *testing.T) {
+	tests := []struct {
+		value string
+		want  string
+	}{
+		{value: "order 123", want: "order 123"},
+		{value: `a(b)c\d`, want: `a\(b\)c\\d`},
+		{value: "Выписка", want: "???????"},
+		{value: "tab\there", want: "tab?here"},
+	}
+	for _, tt := range tests {
+		if got := pdfEscape(tt.value); got != tt.want {
+			t.Errorf("pdfEscape(%q) = %q, want %q", tt.value, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,263 @@
+package statement
+
+import (
+	"context"
+	"fmt"
+	"gophermart-service/internal/base"
+	"gophermart-service/internal/config"
+	ordersRepo "gophermart-service/internal/repository/orders"
+	withdrawRepo "gophermart-service/internal/repository/withdraw"
+	"gophermart-service/internal/service/pagination"
+	"io"
+	"strings"
+	"time"
+)
+
+// pageSize сколько записей читается из БД за один запрос при выгрузке
+const pageSize = 500
+
+const (
+	csvTimeLayout = time.RFC3339
+	pdfTimeLayout = "2006-01-02 15:04 UTC"
+)
+
+// Service формирует выписки по заказам и списаниям пользователя в CSV и PDF.
+// Записи читаются постранично по ключу и сразу пишутся клиенту.
+type Service struct {
+	logger       config.LoggerInterface
+	ordersRepo   ordersRepo.ReaderRepositoryInterface
+	withdrawRepo withdrawRepo.RepositoryReaderInterface
+}
+
+// NewStatementService создает новый экземпляр сервиса выписок
+func NewStatementService(
+	logger config.LoggerInterface,
+	ordersRepo ordersRepo.ReaderRepositoryInterface,
+	withdrawRepo withdrawRepo.RepositoryReaderInterface,
+) ServiceInterface {
+	return &Service{
+		logger:       logger,
+		ordersRepo:   ordersRepo,
+		withdrawRepo: withdrawRepo,
+	}
+}
+
+// ExportOrders готовит выписку по заказам пользователя в порядке загрузки
+func (s *Service) ExportOrders(ctx context.Context, userID int, in *ExportInDTO) (*Export, error) {
+	format, dateRange, err := parseExportParams(in)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := &ordersRepo.ListFilter{
+		UserID:    userID,
+		From:      dateRange.From,
+		To:        dateRange.To,
+		SortField: ordersRepo.SortUploadedAt,
+		Limit:     pageSize,
+	}
+	var total float64
+	var count int
+	done := false
+
+	t := &table{
+		Title:    "Gophermart orders statement",
+		Subtitle: subtitle(userID, dateRange),
+		Columns: []column{
+			{Title: "number", Width: 170},
+			{Title: "status", Width: 100},
+			{Title: "accrual", Width: 90},
+			{Title: "uploaded_at", Width: 150},
+		},
+		Next: func(ctx context.Context) ([][]string, error) {
+			if done {
+				return nil, nil
+			}
+			orders, err := s.ordersRepo.ListUserOrders(ctx, filter)
+			if err != nil {
+				s.logger.Errorw("Failed to read orders for statement",
+					"requestID", base.GetRequestID(ctx),
+					"userID", userID,
+					"error", err)
+				return nil, err
+			}
+			done = len(orders) < pageSize
+			if len(orders) > 0 {
+				last := orders[len(orders)-1]
+				filter.AfterValue = last.UploadedAt
+				filter.AfterID = last.ID
+			}
+
+			rows := make([][]string, 0, len(orders))
+			for _, order := range orders {
+				total += float64(order.Accrual)
+				count++
+				rows = append(rows, []string{
+					order.OrderNumber,
+					order.Status,
+					formatAmount(order.Accrual),
+					formatTime(order.UploadedAt, format),
+				})
+			}
+			return rows, nil
+		},
+	}
+	t.Summary = func() []string {
+		return []string{
+			fmt.Sprintf("Orders: %d", count),
+			fmt.Sprintf("Total accrual: %.2f", total),
+		}
+	}
+
+	return s.prepare(ctx, userID, "orders", format, t)
+}
+
+// ExportWithdrawals готовит выписку по списаниям пользователя в порядке проведения
+func (s *Service) ExportWithdrawals(ctx context.Context, userID int, in *ExportInDTO) (*Export, error) {
+	format, dateRange, err := parseExportParams(in)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := &withdrawRepo.ListFilter{
+		UserID:    userID,
+		From:      dateRange.From,
+		To:        dateRange.To,
+		SortField: withdrawRepo.SortProcessedAt,
+		Limit:     pageSize,
+	}
+	var total float64
+	var count int
+	done := false
+
+	t := &table{
+		Title:    "Gophermart withdrawals statement",
+		Subtitle: subtitle(userID, dateRange),
+		Columns: []column{
+			{Title: "order", Width: 170},
+			{Title: "sum", Width: 90},
+			{Title: "processed_at", Width: 150},
+		},
+		Next: func(ctx context.Context) ([][]string, error) {
+			if done {
+				return nil, nil
+			}
+			withdrawals, err := s.withdrawRepo.ListUserWithdrawals(ctx, filter)
+			if err != nil {
+				s.logger.Errorw("Failed to read withdrawals for statement",
+					"requestID", base.GetRequestID(ctx),
+					"userID", userID,
+					"error", err)
+				return nil, err
+			}
+			done = len(withdrawals) < pageSize
+			if len(withdrawals) > 0 {
+				last := withdrawals[len(withdrawals)-1]
+				filter.AfterValue = last.ProcessedAt
+				filter.AfterID = last.ID
+			}
+
+			rows := make([][]string, 0, len(withdrawals))
+			for _, withdrawal := range withdrawals {
+				total += float64(withdrawal.Sum)
+				count++
+				rows = append(rows, []string{
+					withdrawal.Order,
+					formatAmount(withdrawal.Sum),
+					formatTime(withdrawal.ProcessedAt, format),
+				})
+			}
+			return rows, nil
+		},
+	}
+	t.Summary = func() []string {
+		return []string{
+			fmt.Sprintf("Withdrawals: %d", count),
+			fmt.Sprintf("Total withdrawn: %.2f", total),
+		}
+	}
+
+	return s.prepare(ctx, userID, "withdrawals", format, t)
+}
+
+// prepare читает первую страницу, чтобы ошибка БД вернулась до начала ответа
+func (s *Service) prepare(ctx context.Context, userID int, kind, format string, t *table) (*Export, error) {
+	first, err := t.Next(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Infow("Statement export started",
+		"requestID", base.GetRequestID(ctx),
+		"userID", userID,
+		"kind", kind,
+		"format", format)
+
+	export := &Export{
+		Filename: fmt.Sprintf("gophermart-%s-%d-%s.%s", kind, userID, time.Now().UTC().Format("20060102"), format),
+	}
+	if format == FormatPDF {
+		export.ContentType = "application/pdf"
+		export.stream = func(ctx context.Context, w io.Writer) error {
+			writer, err := newPDFWriter(w, t)
+			if err != nil {
+				return err
+			}
+			return t.stream(ctx, writer, first)
+		}
+	} else {
+		export.ContentType = "text/csv; charset=utf-8"
+		export.stream = func(ctx context.Context, w io.Writer) error {
+			writer, err := newCSVWriter(w, t.Columns)
+			if err != nil {
+				return err
+			}
+			return t.stream(ctx, writer, first)
+		}
+	}
+	return export, nil
+}
+
+func parseExportParams(in *ExportInDTO) (string, pagination.DateRange, error) {
+	format := strings.ToLower(strings.TrimSpace(in.Format))
+	if format == "" {
+		format = FormatCSV
+	}
+	if format != FormatCSV && format != FormatPDF {
+		return "", pagination.DateRange{}, fmt.Errorf("%w: %s", ErrUnsupportedFormat, in.Format)
+	}
+
+	dateRange, err := pagination.ParseDateRange(in.From, in.To)
+	if err != nil {
+		return "", pagination.DateRange{}, err
+	}
+	return format, dateRange, nil
+}
+
+func subtitle(userID int, dateRange pagination.DateRange) []string {
+	period := "all time"
+	switch {
+	case dateRange.From != nil && dateRange.To != nil:
+		period = fmt.Sprintf("from %s to %s", dateRange.From.UTC().Format(pdfTimeLayout), dateRange.To.UTC().Format(pdfTimeLayout))
+	case dateRange.From != nil:
+		period = "from " + dateRange.From.UTC().Format(pdfTimeLayout)
+	case dateRange.To != nil:
+		period = "to " + dateRange.To.UTC().Format(pdfTimeLayout)
+	}
+	return []string{
+		fmt.Sprintf("User ID: %d", userID),
+		"Period: " + period,
+		"Generated: " + time.Now().UTC().Format(pdfTimeLayout),
+	}
+}
+
+func formatAmount(value float32) string {
+	return fmt.Sprintf("%.2f", value)
+}
+
+func formatTime(value time.Time, format string) string {
+	if format == FormatPDF {
+		return value.UTC().Format(pdfTimeLayout)
+	}
+	return value.UTC().Format(csvTimeLayout)
+}
@@ -0,0 +1,88 @@
+package statement
+
+import (
+	"context"
+	"encoding/csv"
+	"io"
+)
+
+// column колонка выписки; Width задает ширину в пунктах для PDF
+type column struct {
+	Title string
+	Width float64
+}
+
+// table описание выписки: колонки и источник строк, отдающий по странице за вызов.
+// Пустая страница означает конец данных.
+type table struct {
+	Title    string
+	Subtitle []string
+	Columns  []column
+	Next     func(ctx context.Context) ([][]string, error)
+	Summary  func() []string
+}
+
+// rowWriter пишет строки выписки в выбранном формате
+type rowWriter interface {
+	WriteRow(values []string) error
+	// Flush отдает накопленные строки клиенту после каждой страницы
+	Flush() error
+	Close(summary []string) error
+}
+
+// stream пишет выписку, начиная с уже прочитанной первой страницы
+func (t *table) stream(ctx context.Context, writer rowWriter, first [][]string) error {
+	rows := first
+	for len(rows) > 0 {
+		for _, row := range rows {
+			if err := writer.WriteRow(row); err != nil {
+				return err
+			}
+		}
+		if err := writer.Flush(); err != nil {
+			return err
+		}
+
+		var err error
+		if rows, err = t.Next(ctx); err != nil {
+			return err
+		}
+	}
+
+	var summary []string
+	if t.Summary != nil {
+		summary = t.Summary()
+	}
+	return writer.Close(summary)
+}
+
+// csvWriter выписка CSV: строка заголовков и по строке на запись, без итогов,
+// чтобы файл без правок импортировался в учетные системы
+type csvWriter struct {
+	w *csv.Writer
+}
+
+func newCSVWriter(w io.Writer, columns []column) (rowWriter, error) {
+	writer := &csvWriter{w: csv.NewWriter(w)}
+	titles := make([]string, 0, len(columns))
+	for _, c := range columns {
+		titles = append(titles, c.Title)
+	}
+	if err := writer.w.Write(titles); err != nil {
+		return nil, err
+	}
+	return writer, nil
+}
+
+func (w *csvWriter) WriteRow(values []string) error {
+	return w.w.Write(values)
+}
+
+func (w *csvWriter) Flush() error {
+	w.w.Flush()
+	return w.w.Error()
+}
+
+func (w *csvWriter) Close(_ []string) error {
+	return w.Flush()
+}
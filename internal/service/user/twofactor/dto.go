@@ -0,0 +1,22 @@
+package twofactor
+
+import "time"
+
+// EnrollOutDTO представляет данные для подключения приложения-аутентификатора
+type EnrollOutDTO struct {
+	Secret     string `json:"secret"`
+	OTPAuthURI string `json:"otpauth_uri"`
+}
+
+// ChallengeInDTO представляет второй шаг входа: данные промежуточного токена и код
+type ChallengeInDTO struct {
+	UserID      int
+	ChallengeID string
+	ExpiresAt   time.Time
+	Code        string
+}
+
+// ConfirmOutDTO представляет результат включения 2FA
+type ConfirmOutDTO struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
@@ -0,0 +1,23 @@
+package twofactor
+
+import "errors"
+
+var (
+	ErrCodeIsRequired     = errors.New("two-factor code is required")
+	ErrInvalidCode        = errors.New("invalid two-factor code")
+	ErrNotEnrolled        = errors.New("two-factor authentication is not enrolled")
+	ErrAlreadyEnabled     = errors.New("two-factor authentication is already enabled")
+	ErrNotEnabled         = errors.New("two-factor authentication is not enabled")
+	ErrFailedToGenerate   = errors.New("failed to generate two-factor secret")
+	ErrTwoFactorRequired  = errors.New("two-factor authentication required")
+	ErrChallengeIsInvalid = errors.New("two-factor challenge is invalid or expired")
+	ErrTooManyAttempts    = errors.New("too many invalid two-factor codes, try again later")
+)
+
+func IsErrCodeIsRequired(err error) bool     { return errors.Is(err, ErrCodeIsRequired) }
+func IsErrInvalidCode(err error) bool        { return errors.Is(err, ErrInvalidCode) }
+func IsErrNotEnrolled(err error) bool        { return errors.Is(err, ErrNotEnrolled) }
+func IsErrAlreadyEnabled(err error) bool     { return errors.Is(err, ErrAlreadyEnabled) }
+func IsErrNotEnabled(err error) bool         { return errors.Is(err, ErrNotEnabled) }
+func IsErrChallengeIsInvalid(err error) bool { return errors.Is(err, ErrChallengeIsInvalid) }
+func IsErrTooManyAttempts(err error) bool    { return errors.Is(err, ErrTooManyAttempts) }
@@ -0,0 +1,12 @@
+package twofactor
+
+import "context"
+
+type ServiceInterface interface {
+	Enroll(ctx context.Context, userID int, login string) (*EnrollOutDTO, error)
+	Confirm(ctx context.Context, userID int, code string) (*ConfirmOutDTO, error)
+	Disable(ctx context.Context, userID int, code string) error
+	IsEnabled(ctx context.Context, userID int) (bool, error)
+	Verify(ctx context.Context, userID int, code string) error
+	VerifyChallenge(ctx context.Context, dtoIn *ChallengeInDTO) error
+}
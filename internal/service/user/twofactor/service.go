@@ -0,0 +1,280 @@
+package twofactor
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"gophermart-service/internal/base"
+	"gophermart-service/internal/config"
+	twoFactorRepo "gophermart-service/internal/repository/twofactor"
+	"strings"
+	"time"
+)
+
+const recoveryCodeBytes = 5 // 10 hex-символов в формате xxxxx-xxxxx
+
+// Service представляет сервис двухфакторной аутентификации по TOTP
+type Service struct {
+	logger   config.LoggerInterface
+	settings *config.TwoFactorSettings
+	repo     twoFactorRepo.RepositoryInterface
+}
+
+// NewTwoFactorService создает новый экземпляр сервиса двухфакторной аутентификации
+func NewTwoFactorService(
+	logger config.LoggerInterface,
+	settings *config.TwoFactorSettings,
+	repo twoFactorRepo.RepositoryInterface,
+) ServiceInterface {
+	return &Service{
+		logger:   logger,
+		settings: settings,
+		repo:     repo,
+	}
+}
+
+// Enroll создает новый секрет TOTP; 2FA включается только после подтверждения кодом
+func (s *Service) Enroll(ctx context.Context, userID int, login string) (*EnrollOutDTO, error) {
+	requestID := base.GetRequestID(ctx)
+
+	enabled, err := s.IsEnabled(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if enabled {
+		return nil, ErrAlreadyEnabled
+	}
+
+	secret, err := generateSecret()
+	if err != nil {
+		s.logger.Errorw("Failed to generate TOTP secret", "requestID", requestID, "error", err)
+		return nil, ErrFailedToGenerate
+	}
+
+	if err = s.repo.SaveSecret(ctx, userID, secret); err != nil {
+		s.logger.Errorw("Failed to save TOTP secret", "requestID", requestID, "userID", userID, "error", err)
+		return nil, err
+	}
+
+	s.logger.Infow("TOTP enrolment started", "requestID", requestID, "userID", userID)
+
+	return &EnrollOutDTO{
+		Secret:     secret,
+		OTPAuthURI: buildURI(s.settings.Issuer, login, secret),
+	}, nil
+}
+
+// Confirm проверяет первый код из приложения, включает 2FA и выдает коды восстановления
+func (s *Service) Confirm(ctx context.Context, userID int, code string) (*ConfirmOutDTO, error) {
+	requestID := base.GetRequestID(ctx)
+
+	code = normalizeCode(code)
+	if code == "" {
+		return nil, ErrCodeIsRequired
+	}
+
+	totp, err := s.repo.GetTOTP(ctx, userID)
+	if err != nil {
+		if twoFactorRepo.IsErrTOTPNotFound(err) {
+			return nil, ErrNotEnrolled
+		}
+		return nil, err
+	}
+	if totp.Enabled {
+		return nil, ErrAlreadyEnabled
+	}
+
+	step, ok := validateCode(totp.Secret, code, time.Now())
+	if !ok {
+		s.logger.Warnw("Invalid TOTP code on confirmation", "requestID", requestID, "userID", userID)
+		return nil, ErrInvalidCode
+	}
+
+	recoveryCodes, hashes, err := s.generateRecoveryCodes()
+	if err != nil {
+		s.logger.Errorw("Failed to generate recovery codes", "requestID", requestID, "error", err)
+		return nil, ErrFailedToGenerate
+	}
+
+	if err = s.repo.Enable(ctx, userID, hashes); err != nil {
+		s.logger.Errorw("Failed to enable TOTP", "requestID", requestID, "userID", userID, "error", err)
+		return nil, err
+	}
+	if err = s.repo.MarkStepUsed(ctx, userID, step); err != nil {
+		s.logger.Warnw("Failed to mark TOTP step as used", "requestID", requestID, "userID", userID, "error", err)
+	}
+
+	s.logger.Infow("TOTP enabled", "requestID", requestID, "userID", userID)
+
+	return &ConfirmOutDTO{RecoveryCodes: recoveryCodes}, nil
+}
+
+// Disable отключает 2FA после проверки кода из приложения или кода восстановления
+func (s *Service) Disable(ctx context.Context, userID int, code string) error {
+	requestID := base.GetRequestID(ctx)
+
+	if err := s.Verify(ctx, userID, code); err != nil {
+		return err
+	}
+
+	if err := s.repo.Delete(ctx, userID); err != nil {
+		s.logger.Errorw("Failed to disable TOTP", "requestID", requestID, "userID", userID, "error", err)
+		return err
+	}
+
+	s.logger.Infow("TOTP disabled", "requestID", requestID, "userID", userID)
+	return nil
+}
+
+// IsEnabled сообщает, включена ли у пользователя 2FA
+func (s *Service) IsEnabled(ctx context.Context, userID int) (bool, error) {
+	totp, err := s.repo.GetTOTP(ctx, userID)
+	if err != nil {
+		if twoFactorRepo.IsErrTOTPNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return totp.Enabled, nil
+}
+
+// VerifyChallenge завершает второй шаг входа. Промежуточный токен принимается один раз, поэтому
+// каждая попытка подобрать код требует повторного ввода пароля.
+func (s *Service) VerifyChallenge(ctx context.Context, dtoIn *ChallengeInDTO) error {
+	requestID := base.GetRequestID(ctx)
+
+	if normalizeCode(dtoIn.Code) == "" {
+		return ErrCodeIsRequired
+	}
+	if dtoIn.ChallengeID == "" {
+		return ErrChallengeIsInvalid
+	}
+
+	if err := s.repo.ConsumeChallenge(ctx, dtoIn.ChallengeID, dtoIn.UserID, dtoIn.ExpiresAt); err != nil {
+		if twoFactorRepo.IsErrChallengeAlreadyUsed(err) {
+			s.logger.Warnw("Two-factor challenge reuse rejected", "requestID", requestID, "userID", dtoIn.UserID)
+			return ErrChallengeIsInvalid
+		}
+		return err
+	}
+
+	return s.Verify(ctx, dtoIn.UserID, dtoIn.Code)
+}
+
+// Verify проверяет код TOTP или одноразовый код восстановления. Неверные коды подряд
+// учитываются, и после MaxFailedAttempts проверка блокируется на LockoutDuration.
+func (s *Service) Verify(ctx context.Context, userID int, code string) error {
+	requestID := base.GetRequestID(ctx)
+
+	code = normalizeCode(code)
+	if code == "" {
+		return ErrCodeIsRequired
+	}
+
+	totp, err := s.repo.GetTOTP(ctx, userID)
+	if err != nil {
+		if twoFactorRepo.IsErrTOTPNotFound(err) {
+			return ErrNotEnabled
+		}
+		return err
+	}
+	if !totp.Enabled {
+		return ErrNotEnabled
+	}
+	if totp.LockedUntil != nil && time.Now().Before(*totp.LockedUntil) {
+		s.logger.Warnw("Two-factor verification is locked", "requestID", requestID, "userID", userID)
+		return ErrTooManyAttempts
+	}
+
+	if err = s.checkCode(ctx, totp, code); err != nil {
+		if IsErrInvalidCode(err) {
+			return s.registerFailure(ctx, userID)
+		}
+		return err
+	}
+
+	if totp.FailedAttempts > 0 || totp.LockedUntil != nil {
+		if err = s.repo.ResetFailures(ctx, userID); err != nil {
+			s.logger.Warnw("Failed to reset two-factor failures", "requestID", requestID, "userID", userID, "error", err)
+		}
+	}
+	return nil
+}
+
+// checkCode принимает код TOTP, еще не использованный в своем временном шаге, или код восстановления
+func (s *Service) checkCode(ctx context.Context, totp *twoFactorRepo.TOTP, code string) error {
+	requestID := base.GetRequestID(ctx)
+
+	if step, ok := validateCode(totp.Secret, code, time.Now()); ok {
+		if err := s.repo.MarkStepUsed(ctx, totp.UserID, step); err != nil {
+			if twoFactorRepo.IsErrStepAlreadyUsed(err) {
+				s.logger.Warnw("TOTP code replay rejected", "requestID", requestID, "userID", totp.UserID)
+				return ErrInvalidCode
+			}
+			return err
+		}
+		return nil
+	}
+
+	if err := s.repo.UseRecoveryCode(ctx, totp.UserID, hashRecoveryCode(code)); err != nil {
+		if twoFactorRepo.IsErrRecoveryCodeNotFound(err) {
+			s.logger.Warnw("Invalid two-factor code", "requestID", requestID, "userID", totp.UserID)
+			return ErrInvalidCode
+		}
+		return err
+	}
+
+	s.logger.Infow("Recovery code used", "requestID", requestID, "userID", totp.UserID)
+	return nil
+}
+
+// registerFailure учитывает неверный код и возвращает ErrInvalidCode или, если лимит
+// исчерпан этой попыткой, ErrTooManyAttempts
+func (s *Service) registerFailure(ctx context.Context, userID int) error {
+	requestID := base.GetRequestID(ctx)
+
+	lockUntil := time.Now().Add(s.settings.LockoutDuration)
+	locked, err := s.repo.RegisterFailure(ctx, userID, s.settings.MaxFailedAttempts, lockUntil)
+	if err != nil {
+		s.logger.Errorw("Failed to register two-factor failure", "requestID", requestID, "userID", userID, "error", err)
+		return err
+	}
+	if locked {
+		s.logger.Warnw("Two-factor verification locked after repeated failures",
+			"requestID", requestID,
+			"userID", userID,
+			"locked_until", lockUntil)
+		return ErrTooManyAttempts
+	}
+	return ErrInvalidCode
+}
+
+func (s *Service) generateRecoveryCodes() ([]string, []string, error) {
+	codes := make([]string, 0, s.settings.RecoveryCodesCount)
+	hashes := make([]string, 0, s.settings.RecoveryCodesCount)
+
+	for i := 0; i < s.settings.RecoveryCodesCount; i++ {
+		buf := make([]byte, recoveryCodeBytes)
+		if _, err := rand.Read(buf); err != nil {
+			return nil, nil, err
+		}
+		raw := hex.EncodeToString(buf)
+		codes = append(codes, raw[:5]+"-"+raw[5:])
+		hashes = append(hashes, hashRecoveryCode(raw))
+	}
+
+	return codes, hashes, nil
+}
+
+// normalizeCode убирает пробелы и дефисы, которые пользователи вводят вместе с кодом
+func normalizeCode(code string) string {
+	code = strings.ToLower(strings.TrimSpace(code))
+	code = strings.ReplaceAll(code, " ", "")
+	return strings.ReplaceAll(code, "-", "")
+}
+
+func hashRecoveryCode(code string) string {
+	sum := sha256.Sum256([]byte(code))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,183 @@
+package twofactor
+
+import (
+	"context"
+	"errors"
+	"gophermart-service/internal/config"
+	twoFactorRepo "gophermart-service/internal/repository/twofactor"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// memoryRepo хранит состояние 2FA одного пользователя в памяти и повторяет семантику SQL-запросов
+type memoryRepo struct {
+	twoFactorRepo.RepositoryInterface
+	totp       *twoFactorRepo.TOTP
+	challenges map[string]bool
+}
+
+func (r *memoryRepo) GetTOTP(context.Context, int) (*twoFactorRepo.TOTP, error) {
+	if r.totp == nil {
+		return nil, twoFactorRepo.ErrTOTPNotFound
+	}
+	totp := *r.totp
+	return &totp, nil
+}
+
+func (r *memoryRepo) MarkStepUsed(_ context.Context, _ int, step int64) error {
+	if r.totp.LastUsedStep != nil && *r.totp.LastUsedStep >= step {
+		return twoFactorRepo.ErrStepAlreadyUsed
+	}
+	r.totp.LastUsedStep = &step
+	return nil
+}
+
+func (r *memoryRepo) UseRecoveryCode(context.Context, int, string) error {
+	return twoFactorRepo.ErrRecoveryCodeNotFound
+}
+
+func (r *memoryRepo) RegisterFailure(_ context.Context, _, maxAttempts int, lockUntil time.Time) (bool, error) {
+	if r.totp.FailedAttempts+1 >= maxAttempts {
+		r.totp.FailedAttempts = 0
+		r.totp.LockedUntil = &lockUntil
+		return true, nil
+	}
+	r.totp.FailedAttempts++
+	return false, nil
+}
+
+func (r *memoryRepo) ResetFailures(context.Context, int) error {
+	r.totp.FailedAttempts = 0
+	r.totp.LockedUntil = nil
+	return nil
+}
+
+func (r *memoryRepo) ConsumeChallenge(_ context.Context, challengeID string, _ int, _ time.Time) error {
+	if r.challenges[challengeID] {
+		return twoFactorRepo.ErrChallengeAlreadyUsed
+	}
+	r.challenges[challengeID] = true
+	return nil
+}
+
+const testSecret = "JBSWY3DPEHPK3PXPJBSWY3DPEHPK3PXP"
+
+func newTestService(repo *memoryRepo) *Service {
+	return &Service{
+		logger: zap.NewNop().Sugar(),
+		settings: &config.TwoFactorSettings{
+			RecoveryCodesCount: 10,
+			MaxFailedAttempts:  3,
+			LockoutDuration:    time.Minute,
+		},
+		repo: repo,
+	}
+}
+
+func newEnabledRepo() *memoryRepo {
+	return &memoryRepo{
+		totp:       &twoFactorRepo.TOTP{UserID: 1, Secret: testSecret, Enabled: true},
+		challenges: make(map[string]bool),
+	}
+}
+
+func currentCode(t *testing.T) string {
+	t.Helper()
+	code, err := generateCode(testSecret, timeStep(time.Now()))
+	if err != nil {
+		t.Fatalf("generateCode: %v", err)
+	}
+	return code
+}
+
+// wrongCode возвращает шестизначный код, не совпадающий ни с одним кодом допустимого окна
+func wrongCode(t *testing.T) string {
+	t.Helper()
+	for _, candidate := range []string{"000000", "111111", "222222", "333333"} {
+		if _, ok := validateCode(testSecret, candidate, time.Now()); !ok {
+			return candidate
+		}
+	}
+	t.Fatal("no wrong code candidate")
+	return ""
+}
+
+func TestVerifyLocksAfterRepeatedFailures(t *testing.T) {
+	repo := newEnabledRepo()
+	s := newTestService(repo)
+	ctx := context.Background()
+	wrong := wrongCode(t)
+
+	wantErrs := []error{ErrInvalidCode, ErrInvalidCode, ErrTooManyAttempts}
+	for i, want := range wantErrs {
+		if err := s.Verify(ctx, 1, wrong); !errors.Is(err, want) {
+			t.Fatalf("attempt %d: error = %v, want %v", i+1, err, want)
+		}
+	}
+
+	// Во время блокировки не принимается даже верный код
+	if err := s.Verify(ctx, 1, currentCode(t)); !errors.Is(err, ErrTooManyAttempts) {
+		t.Fatalf("locked verify error = %v, want %v", err, ErrTooManyAttempts)
+	}
+
+	// После окончания блокировки верный код принимается и счетчик сбрасывается
+	expired := time.Now().Add(-time.Second)
+	repo.totp.LockedUntil = &expired
+	if err := s.Verify(ctx, 1, currentCode(t)); err != nil {
+		t.Fatalf("verify after lockout: %v", err)
+	}
+	if repo.totp.FailedAttempts != 0 || repo.totp.LockedUntil != nil {
+		t.Errorf("failures not reset: attempts = %d, locked_until = %v", repo.totp.FailedAttempts, repo.totp.LockedUntil)
+	}
+}
+
+func TestVerifySuccessResetsFailures(t *testing.T) {
+	repo := newEnabledRepo()
+	s := newTestService(repo)
+	ctx := context.Background()
+
+	if err := s.Verify(ctx, 1, wrongCode(t)); !errors.Is(err, ErrInvalidCode) {
+		t.Fatalf("error = %v, want %v", err, ErrInvalidCode)
+	}
+	if err := s.Verify(ctx, 1, currentCode(t)); err != nil {
+		t.Fatalf("verify: %v", err)
+	}
+	if repo.totp.FailedAttempts != 0 {
+		t.Errorf("failed attempts = %d, want 0", repo.totp.FailedAttempts)
+	}
+}
+
+func TestVerifyChallengeIsSingleUse(t *testing.T) {
+	repo := newEnabledRepo()
+	s := newTestService(repo)
+	ctx := context.Background()
+
+	challenge := &ChallengeInDTO{UserID: 1, ChallengeID: "jti-1", ExpiresAt: time.Now().Add(time.Minute)}
+
+	challenge.Code = wrongCode(t)
+	if err := s.VerifyChallenge(ctx, challenge); !errors.Is(err, ErrInvalidCode) {
+		t.Fatalf("first attempt error = %v, want %v", err, ErrInvalidCode)
+	}
+
+	// Повторная попытка с тем же токеном отклоняется даже с верным кодом
+	challenge.Code = currentCode(t)
+	if err := s.VerifyChallenge(ctx, challenge); !errors.Is(err, ErrChallengeIsInvalid) {
+		t.Fatalf("reused challenge error = %v, want %v", err, ErrChallengeIsInvalid)
+	}
+
+	challenge.ChallengeID = "jti-2"
+	if err := s.VerifyChallenge(ctx, challenge); err != nil {
+		t.Fatalf("fresh challenge: %v", err)
+	}
+}
+
+func TestVerifyChallengeWithoutID(t *testing.T) {
+	s := newTestService(newEnabledRepo())
+
+	err := s.VerifyChallenge(context.Background(), &ChallengeInDTO{UserID: 1, Code: "123456"})
+	if !errors.Is(err, ErrChallengeIsInvalid) {
+		t.Fatalf("error = %v, want %v", err, ErrChallengeIsInvalid)
+	}
+}
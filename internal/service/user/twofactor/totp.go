@@ -0,0 +1,95 @@
+package twofactor
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/binary"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Параметры TOTP (RFC 6238), совместимые с Google Authenticator и аналогами
+const (
+	totpDigits     = 6
+	totpPeriod     = 30 * time.Second
+	totpSkewSteps  = 1 // допустимое расхождение часов клиента в шагах
+	totpSecretSize = 20
+)
+
+var base32NoPadding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// generateSecret создает случайный секрет в кодировке base32
+func generateSecret() (string, error) {
+	buf := make([]byte, totpSecretSize)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return base32NoPadding.EncodeToString(buf), nil
+}
+
+// buildURI формирует otpauth URI для импорта секрета в приложение-аутентификатор
+func buildURI(issuer, login, secret string) string {
+	label := url.PathEscape(issuer + ":" + login)
+
+	params := url.Values{}
+	params.Set("secret", secret)
+	params.Set("issuer", issuer)
+	params.Set("algorithm", "SHA1")
+	params.Set("digits", fmt.Sprintf("%d", totpDigits))
+	params.Set("period", fmt.Sprintf("%d", int(totpPeriod.Seconds())))
+
+	return "otpauth://totp/" + label + "?" + params.Encode()
+}
+
+// timeStep возвращает номер временного шага для момента t
+func timeStep(t time.Time) int64 {
+	return t.Unix() / int64(totpPeriod.Seconds())
+}
+
+// generateCode вычисляет код для временного шага (HOTP по RFC 4226)
+func generateCode(secret string, step int64) (string, error) {
+	key, err := base32NoPadding.DecodeString(strings.ToUpper(secret))
+	if err != nil {
+		return "", err
+	}
+
+	var msg [8]byte
+	binary.BigEndian.PutUint64(msg[:], uint64(step))
+
+	mac := hmac.New(sha1.New, key)
+	mac.Write(msg[:])
+	sum := mac.Sum(nil)
+
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+
+	mod := uint32(1)
+	for i := 0; i < totpDigits; i++ {
+		mod *= 10
+	}
+	return fmt.Sprintf("%0*d", totpDigits, value%mod), nil
+}
+
+// validateCode проверяет код с учетом расхождения часов и возвращает шаг, которому он соответствует
+func validateCode(secret, code string, now time.Time) (int64, bool) {
+	if len(code) != totpDigits {
+		return 0, false
+	}
+
+	current := timeStep(now)
+	for delta := int64(-totpSkewSteps); delta <= totpSkewSteps; delta++ {
+		expected, err := generateCode(secret, current+delta)
+		if err != nil {
+			return 0, false
+		}
+		if subtle.ConstantTimeCompare([]byte(expected), []byte(code)) == 1 {
+			return current + delta, true
+		}
+	}
+	return 0, false
+}
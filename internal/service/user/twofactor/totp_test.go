@@ -0,0 +1,111 @@
+package twofactor
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+// rfc6238Secret ASCII-секрет "12345678901234567890" из приложения B RFC 6238 в base32
+const rfc6238Secret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+func TestGenerateCodeRFC6238(t *testing.T) {
+	// Векторы SHA1 из приложения B RFC 6238; шестизначный код — последние шесть цифр восьмизначного
+	tests := []struct {
+		unix int64
+		want string
+	}{
+		{unix: 59, want: "287082"},
+		{unix: 1111111109, want: "081804"},
+		{unix: 1111111111, want: "050471"},
+		{unix: 1234567890, want: "005924"},
+		{unix: 2000000000, want: "279037"},
+		{unix: 20000000000, want: "353130"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			got, err := generateCode(rfc6238Secret, timeStep(time.Unix(tt.unix, 0)))
+			if err != nil {
+				t.Fatalf("generateCode: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("code at %d = %s, want %s", tt.unix, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGenerateCodeSecretFormat(t *testing.T) {
+	upper, err := generateCode(rfc6238Secret, 1)
+	if err != nil {
+		t.Fatalf("generateCode: %v", err)
+	}
+	lower, err := generateCode(strings.ToLower(rfc6238Secret), 1)
+	if err != nil {
+		t.Fatalf("generateCode lowercase secret: %v", err)
+	}
+	if upper != lower {
+		t.Errorf("lowercase secret code = %s, want %s", lower, upper)
+	}
+
+	if _, err = generateCode("not base32!", 1); err == nil {
+		t.Error("generateCode accepted an invalid secret")
+	}
+}
+
+func TestTimeStep(t *testing.T) {
+	tests := []struct {
+		unix int64
+		want int64
+	}{
+		{unix: 0, want: 0},
+		{unix: 29, want: 0},
+		{unix: 30, want: 1},
+		{unix: 59, want: 1},
+		{unix: 1111111109, want: 37037036},
+	}
+	for _, tt := range tests {
+		if got := timeStep(time.Unix(tt.unix, 0)); got != tt.want {
+			t.Errorf("timeStep(%d) = %d, want %d", tt.unix, got, tt.want)
+		}
+	}
+}
+
+func TestValidateCodeSkew(t *testing.T) {
+	now := time.Unix(1111111111, 0)
+	current := timeStep(now)
+
+	codeAt := func(step int64) string {
+		code, err := generateCode(rfc6238Secret, step)
+		if err != nil {
+			t.Fatalf("generateCode: %v", err)
+		}
+		return code
+	}
+
+	tests := []struct {
+		name     string
+		code     string
+		wantStep int64
+		wantOK   bool
+	}{
+		{name: "current step", code: codeAt(current), wantStep: current, wantOK: true},
+		{name: "previous step", code: codeAt(current - 1), wantStep: current - 1, wantOK: true},
+		{name: "next step", code: codeAt(current + 1), wantStep: current + 1, wantOK: true},
+		{name: "two steps behind", code: codeAt(current - 2)},
+		{name: "two steps ahead", code: codeAt(current + 2)},
+		{name: "too short", code: codeAt(current)[:5]},
+		{name: "too long", code: codeAt(current) + "0"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			step, ok := validateCode(rfc6238Secret, tt.code, now)
+			if ok != tt.wantOK {
+				t.Fatalf("validateCode(%q) ok = %v, want %v", tt.code, ok, tt.wantOK)
+			}
+			if ok && step != tt.wantStep {
+				t.Errorf("step = %d, want %d", step, tt.wantStep)
+			}
+		})
+	}
+}
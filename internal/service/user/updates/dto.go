@@ -0,0 +1,44 @@
+package updates
+
+import (
+	userBalance "gophermart-service/internal/service/user/balance"
+	"time"
+)
+
+// Типы событий потока обновлений
+const (
+	EventOrder   = "order"
+	EventBalance = "balance"
+)
+
+// OrderUpdateDTO новый статус заказа
+type OrderUpdateDTO struct {
+	OrderNumber string    `json:"number"`
+	Status      string    `json:"status"`
+	Accrual     *float32  `json:"accrual,omitempty"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// Notification одно сообщение потока. ID совпадает с идентификатором события заказа
+// и используется клиентом как Last-Event-ID; у снимка баланса ID равен нулю.
+type Notification struct {
+	ID    int64
+	Event string
+	Data  any
+}
+
+// Subscription подписка на обновления пользователя. Канал закрывается, если клиент
+// не успевает читать сообщения: после переподключения он догоняет их через Replay.
+type Subscription struct {
+	C      <-chan *Notification
+	cancel func()
+}
+
+// Close отменяет подписку
+func (s *Subscription) Close() {
+	s.cancel()
+}
+
+func balanceNotification(balance *userBalance.GetUserBalanceDTO) *Notification {
+	return &Notification{Event: EventBalance, Data: balance}
+}
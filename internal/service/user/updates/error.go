@@ -0,0 +1,11 @@
+package updates
+
+import "errors"
+
+var (
+	ErrTooManySubscriptions = errors.New("too many open update streams")
+	ErrClosed               = errors.New("update streams are closed")
+)
+
+func IsErrTooManySubscriptions(err error) bool { return errors.Is(err, ErrTooManySubscriptions) }
+func IsErrClosed(err error) bool               { return errors.Is(err, ErrClosed) }
@@ -0,0 +1,22 @@
+package updates
+
+import (
+	"context"
+	ordersRepo "gophermart-service/internal/repository/orders"
+)
+
+type ServiceInterface interface {
+	PublisherInterface
+
+	Subscribe(userID int) (*Subscription, error)
+	Replay(ctx context.Context, userID int, afterID int64) ([]*Notification, error)
+	Balance(ctx context.Context, userID int) (*Notification, error)
+	// Close закрывает все подписки и запрещает новые: открытые потоки завершаются,
+	// и остановка HTTP-сервера не ждет их до истечения таймаута
+	Close()
+}
+
+// PublisherInterface часть сервиса, через которую обработчик заказов сообщает об изменениях
+type PublisherInterface interface {
+	PublishOrderStatus(ctx context.Context, userID int, orderNumber string, event *ordersRepo.OrderEvent)
+}
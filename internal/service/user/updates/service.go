@@ -0,0 +1,191 @@
+package updates
+
+import (
+	"context"
+	"gophermart-service/internal/base"
+	"gophermart-service/internal/config"
+	ordersRepo "gophermart-service/internal/repository/orders"
+	userBalance "gophermart-service/internal/service/user/balance"
+	"sync"
+)
+
+const (
+	// subscriptionBuffer сколько сообщений может накопиться у медленного клиента до отключения
+	subscriptionBuffer = 16
+	// maxSubscriptionsPerUser ограничивает число одновременно открытых потоков одного пользователя
+	maxSubscriptionsPerUser = 5
+	// replayPageSize размер страницы при догрузке пропущенных событий
+	replayPageSize = 500
+)
+
+// Service раздает обновления заказов и баланса подписчикам текущего процесса.
+// Сообщения о заказах строятся из событий order_events, поэтому после переподключения
+// клиент получает пропущенное из БД, а не из памяти.
+type Service struct {
+	logger         config.LoggerInterface
+	ordersRepo     ordersRepo.RepositoryInterface
+	balanceService userBalance.ServiceInterface
+
+	mu          sync.Mutex
+	subscribers map[int]map[chan *Notification]struct{}
+	closed      bool
+}
+
+// NewUpdatesService создает новый экземпляр сервиса обновлений
+func NewUpdatesService(
+	logger config.LoggerInterface,
+	ordersRepo ordersRepo.RepositoryInterface,
+	balanceService userBalance.ServiceInterface,
+) ServiceInterface {
+	return &Service{
+		logger:         logger,
+		ordersRepo:     ordersRepo,
+		balanceService: balanceService,
+		subscribers:    make(map[int]map[chan *Notification]struct{}),
+	}
+}
+
+// Subscribe открывает подписку на обновления пользователя
+func (s *Service) Subscribe(userID int) (*Subscription, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return nil, ErrClosed
+	}
+	if len(s.subscribers[userID]) >= maxSubscriptionsPerUser {
+		return nil, ErrTooManySubscriptions
+	}
+	if s.subscribers[userID] == nil {
+		s.subscribers[userID] = make(map[chan *Notification]struct{})
+	}
+
+	ch := make(chan *Notification, subscriptionBuffer)
+	s.subscribers[userID][ch] = struct{}{}
+
+	var once sync.Once
+	return &Subscription{
+		C: ch,
+		cancel: func() {
+			once.Do(func() {
+				s.mu.Lock()
+				defer s.mu.Unlock()
+				s.remove(userID, ch)
+			})
+		},
+	}, nil
+}
+
+// Replay возвращает изменения статусов заказов пользователя после события afterID
+func (s *Service) Replay(ctx context.Context, userID int, afterID int64) ([]*Notification, error) {
+	var notifications []*Notification
+	for {
+		events, err := s.ordersRepo.GetUserEventsAfter(ctx, userID, ordersRepo.EventStatusChanged, afterID, replayPageSize)
+		if err != nil {
+			s.logger.Errorw("Replay order updates failed",
+				"requestID", base.GetRequestID(ctx),
+				"userID", userID,
+				"afterID", afterID,
+				"error", err)
+			return nil, err
+		}
+		for _, event := range events {
+			notifications = append(notifications, orderNotification(event.OrderNumber, &event.OrderEvent))
+		}
+		if len(events) < replayPageSize {
+			return notifications, nil
+		}
+		afterID = events[len(events)-1].ID
+	}
+}
+
+// Balance возвращает снимок текущего баланса пользователя
+func (s *Service) Balance(ctx context.Context, userID int) (*Notification, error) {
+	balance, err := s.balanceService.GetBalance(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return balanceNotification(balance), nil
+}
+
+// PublishOrderStatus рассылает новый статус заказа, а для начисленного заказа и новый баланс
+func (s *Service) PublishOrderStatus(ctx context.Context, userID int, orderNumber string, event *ordersRepo.OrderEvent) {
+	if !s.hasSubscribers(userID) {
+		return
+	}
+
+	s.broadcast(userID, orderNotification(orderNumber, event))
+
+	if event.Accrual == nil || *event.Accrual <= 0 {
+		return
+	}
+	balance, err := s.Balance(ctx, userID)
+	if err != nil {
+		s.logger.Errorw("Failed to load balance for update",
+			"userID", userID,
+			"orderNumber", orderNumber,
+			"error", err)
+		return
+	}
+	s.broadcast(userID, balance)
+}
+
+func (s *Service) hasSubscribers(userID int) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.subscribers[userID]) > 0
+}
+
+// broadcast отправляет сообщение всем подпискам пользователя без блокировки:
+// переполненная подписка закрывается, и клиент догоняет события после переподключения
+func (s *Service) broadcast(userID int, notification *Notification) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for ch := range s.subscribers[userID] {
+		select {
+		case ch <- notification:
+		default:
+			s.logger.Warnw("Update subscriber is too slow, dropping subscription", "userID", userID)
+			s.remove(userID, ch)
+		}
+	}
+}
+
+// Close закрывает все подписки и запрещает новые
+func (s *Service) Close() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.closed = true
+	for userID, channels := range s.subscribers {
+		for ch := range channels {
+			s.remove(userID, ch)
+		}
+	}
+}
+
+// remove удаляет подписку; вызывается под s.mu
+func (s *Service) remove(userID int, ch chan *Notification) {
+	if _, ok := s.subscribers[userID][ch]; !ok {
+		return
+	}
+	delete(s.subscribers[userID], ch)
+	close(ch)
+	if len(s.subscribers[userID]) == 0 {
+		delete(s.subscribers, userID)
+	}
+}
+
+func orderNotification(orderNumber string, event *ordersRepo.OrderEvent) *Notification {
+	return &Notification{
+		ID:    event.ID,
+		Event: EventOrder,
+		Data: &OrderUpdateDTO{
+			OrderNumber: orderNumber,
+			Status:      event.Status,
+			Accrual:     event.Accrual,
+			UpdatedAt:   event.CreatedAt,
+		},
+	}
+}
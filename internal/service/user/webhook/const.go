@@ -0,0 +1,31 @@
+package webhook
+
+// События, на которые можно подписать вебхук
+const (
+	EventOrderProcessed    = "order.processed"
+	EventWithdrawalCreated = "withdrawal.created"
+)
+
+// KnownEvents перечисляет все поддерживаемые события
+var KnownEvents = []string{EventOrderProcessed, EventWithdrawalCreated}
+
+// Заголовки исходящих запросов
+const (
+	HeaderSignature = "X-Gophermart-Signature"
+	HeaderEvent     = "X-Gophermart-Event"
+	HeaderDelivery  = "X-Gophermart-Delivery"
+)
+
+const (
+	minSecretLength = 16
+	maxSecretLength = 128
+	secretBytes     = 32
+
+	// dispatchBatchSize число доставок, отправляемых параллельно за один цикл
+	dispatchBatchSize = 10
+	// maxErrorLength ограничение длины сохраняемого текста ошибки
+	maxErrorLength = 512
+
+	defaultDeliveriesLimit = 50
+	maxDeliveriesLimit     = 200
+)
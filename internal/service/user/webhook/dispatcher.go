@@ -0,0 +1,170 @@
+package webhook
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	webhookClient "gophermart-service/internal/integration/webhook"
+	webhooksRepo "gophermart-service/internal/repository/webhooks"
+	"math/rand/v2"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// dispatcher периодически забирает готовые доставки и отправляет их до остановки сервиса
+func (s *Service) dispatcher() {
+	defer s.wg.Done()
+
+	ticker := time.NewTicker(s.settings.PollInterval)
+	defer ticker.Stop()
+
+	s.logger.Infow("Webhook dispatcher started", "poll_interval", s.settings.PollInterval)
+
+	for {
+		select {
+		case <-s.stopChan:
+			s.logger.Info("Webhook dispatcher stopped")
+			return
+		case <-ticker.C:
+			// Забираем пачки, пока очередь не опустеет, чтобы не ждать тика при накопившихся событиях
+			for s.dispatchBatch() == dispatchBatchSize {
+				select {
+				case <-s.stopChan:
+					s.logger.Info("Webhook dispatcher stopped")
+					return
+				default:
+				}
+			}
+		}
+	}
+}
+
+// dispatchBatch отправляет одну пачку доставок параллельно и возвращает ее размер
+func (s *Service) dispatchBatch() int {
+	ctx := context.Background()
+
+	// Доставка остается за этим экземпляром на время одной попытки с запасом
+	lease := s.settings.HTTPTimeout + time.Minute
+	deliveries, err := s.repo.ClaimDue(ctx, dispatchBatchSize, lease)
+	if err != nil {
+		s.logger.Errorw("Failed to claim webhook deliveries", "error", err)
+		return 0
+	}
+
+	var wg sync.WaitGroup
+	for _, delivery := range deliveries {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			s.deliver(ctx, delivery)
+		}()
+	}
+	wg.Wait()
+
+	return len(deliveries)
+}
+
+// deliver выполняет одну попытку доставки и сохраняет ее результат
+func (s *Service) deliver(ctx context.Context, delivery *webhooksRepo.Delivery) {
+	timestamp := time.Now().Unix()
+	response, err := s.sender.Send(ctx, &webhookClient.Request{
+		URL: delivery.URL,
+		Headers: map[string]string{
+			HeaderEvent:     delivery.EventType,
+			HeaderDelivery:  strconv.FormatInt(delivery.ID, 10),
+			HeaderSignature: Sign(delivery.Secret, timestamp, []byte(delivery.Payload)),
+		},
+		Body: []byte(delivery.Payload),
+	})
+
+	result := &webhooksRepo.AttemptResult{DeliveryID: delivery.ID}
+	switch {
+	case err != nil:
+		result.Error = truncateError(err.Error())
+	case response.IsSuccess():
+		result.Delivered = true
+		result.ResponseStatus = &response.StatusCode
+	default:
+		result.ResponseStatus = &response.StatusCode
+		result.Error = truncateError(fmt.Sprintf("unexpected status %d", response.StatusCode))
+	}
+
+	attempt := delivery.Attempts + 1
+	if !result.Delivered && attempt < s.settings.MaxAttempts {
+		next := time.Now().Add(s.backoff(attempt))
+		result.NextAttemptAt = &next
+	}
+
+	if err = s.repo.SaveAttempt(ctx, result); err != nil {
+		s.logger.Errorw("Failed to save webhook delivery attempt",
+			"deliveryID", delivery.ID,
+			"webhookID", delivery.WebhookID,
+			"error", err)
+		return
+	}
+
+	switch {
+	case result.Delivered:
+		s.logger.Infow("Webhook delivered",
+			"deliveryID", delivery.ID,
+			"webhookID", delivery.WebhookID,
+			"event", delivery.EventType,
+			"attempt", attempt)
+	case result.NextAttemptAt == nil:
+		s.logger.Warnw("Webhook delivery failed permanently",
+			"deliveryID", delivery.ID,
+			"webhookID", delivery.WebhookID,
+			"event", delivery.EventType,
+			"attempts", attempt,
+			"error", *result.Error)
+	default:
+		s.logger.Warnw("Webhook delivery failed, will retry",
+			"deliveryID", delivery.ID,
+			"webhookID", delivery.WebhookID,
+			"event", delivery.EventType,
+			"attempt", attempt,
+			"next_attempt_at", *result.NextAttemptAt,
+			"error", *result.Error)
+	}
+}
+
+// backoff возвращает задержку перед следующей попыткой: экспонента от InitialBackoff
+// с ограничением MaxBackoff и случайной добавкой до 10%, чтобы повторы не шли волной
+func (s *Service) backoff(attempt int) time.Duration {
+	delay := s.settings.InitialBackoff
+	for i := 1; i < attempt && delay < s.settings.MaxBackoff; i++ {
+		delay *= 2
+	}
+	delay = min(delay, s.settings.MaxBackoff)
+	return delay + rand.N(delay/10+1)
+}
+
+// Sign возвращает значение заголовка подписи вида t=<unix>,v1=<hex>, где v1 —
+// HMAC-SHA256 по секрету вебхука от строки "<unix>.<тело запроса>".
+// Получатель пересчитывает подпись и отклоняет запросы со старой меткой времени.
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return fmt.Sprintf("t=%d,v1=%s", timestamp, hex.EncodeToString(mac.Sum(nil)))
+}
+
+func truncateError(message string) *string {
+	if len(message) > maxErrorLength {
+		message = message[:maxErrorLength]
+	}
+	return &message
+}
+
+// Stop останавливает диспетчер и дожидается завершения текущих попыток доставки
+func (s *Service) Stop() {
+	s.stopOnce.Do(func() {
+		s.logger.Info("Stopping webhook service...")
+		close(s.stopChan)
+	})
+	s.wg.Wait()
+}
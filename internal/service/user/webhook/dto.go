@@ -0,0 +1,71 @@
+package webhook
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// CreateInDTO запрос на создание подписки; пустой Secret генерируется сервером
+type CreateInDTO struct {
+	URL    string   `json:"url"`
+	Events []string `json:"events"`
+	Secret string   `json:"secret"`
+}
+
+// CreateOutDTO созданная подписка; секрет показывается только один раз
+type CreateOutDTO struct {
+	OutDTO
+	Secret string `json:"secret"`
+}
+
+// OutDTO подписка в списке вебхуков пользователя
+type OutDTO struct {
+	ID        int       `json:"id"`
+	URL       string    `json:"url"`
+	Events    []string  `json:"events"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// DeliveriesInDTO фильтр журнала доставок
+type DeliveriesInDTO struct {
+	Status string
+	Limit  int
+}
+
+// DeliveryOutDTO запись журнала доставок
+type DeliveryOutDTO struct {
+	ID             int64           `json:"id"`
+	Event          string          `json:"event"`
+	Status         string          `json:"status"`
+	Attempts       int             `json:"attempts"`
+	Payload        json.RawMessage `json:"payload"`
+	ResponseStatus *int            `json:"response_status,omitempty"`
+	LastError      *string         `json:"last_error,omitempty"`
+	LastAttemptAt  *time.Time      `json:"last_attempt_at,omitempty"`
+	NextAttemptAt  *time.Time      `json:"next_attempt_at,omitempty"`
+	CreatedAt      time.Time       `json:"created_at"`
+	DeliveredAt    *time.Time      `json:"delivered_at,omitempty"`
+}
+
+// OrderProcessedDTO данные события order.processed
+type OrderProcessedDTO struct {
+	OrderNumber string    `json:"number"`
+	Status      string    `json:"status"`
+	Accrual     float32   `json:"accrual"`
+	ProcessedAt time.Time `json:"processed_at"`
+}
+
+// WithdrawalCreatedDTO данные события withdrawal.created
+type WithdrawalCreatedDTO struct {
+	OrderNumber string    `json:"order"`
+	Sum         float32   `json:"sum"`
+	ProcessedAt time.Time `json:"processed_at"`
+}
+
+// envelope тело запроса вебхука
+type envelope struct {
+	ID        string    `json:"id"`
+	Type      string    `json:"type"`
+	CreatedAt time.Time `json:"created_at"`
+	Data      any       `json:"data"`
+}
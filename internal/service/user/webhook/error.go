@@ -0,0 +1,21 @@
+package webhook
+
+import "errors"
+
+var (
+	ErrURLIsRequired          = errors.New("webhook url is required")
+	ErrInvalidURL             = errors.New("webhook url must be an absolute http(s) url")
+	ErrInsecureURL            = errors.New("webhook url must use https")
+	ErrPrivateURL             = errors.New("webhook url must point to a public address")
+	ErrEventsAreRequired      = errors.New("at least one event is required")
+	ErrUnknownEvent           = errors.New("unknown webhook event")
+	ErrSecretTooShort         = errors.New("webhook secret is too short")
+	ErrSecretTooLong          = errors.New("webhook secret is too long")
+	ErrTooManyWebhooks        = errors.New("webhook limit reached")
+	ErrWebhookNotFound        = errors.New("webhook not found")
+	ErrInvalidDeliveryStatus  = errors.New("unknown delivery status")
+	ErrFailedToGenerateSecret = errors.New("failed to generate webhook secret")
+)
+
+func IsErrWebhookNotFound(err error) bool { return errors.Is(err, ErrWebhookNotFound) }
+func IsErrUnknownEvent(err error) bool    { return errors.Is(err, ErrUnknownEvent) }
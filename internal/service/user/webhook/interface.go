@@ -0,0 +1,18 @@
+package webhook
+
+import "context"
+
+type ServiceInterface interface {
+	PublisherInterface
+
+	Create(ctx context.Context, userID int, dtoIn *CreateInDTO) (*CreateOutDTO, error)
+	List(ctx context.Context, userID int) ([]*OutDTO, error)
+	Delete(ctx context.Context, userID, webhookID int) error
+	ListDeliveries(ctx context.Context, userID, webhookID int, dtoIn *DeliveriesInDTO) ([]*DeliveryOutDTO, error)
+	Stop()
+}
+
+// PublisherInterface часть сервиса, через которую другие сервисы ставят события в очередь доставки
+type PublisherInterface interface {
+	Publish(ctx context.Context, userID int, eventType string, data any)
+}
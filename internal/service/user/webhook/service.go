@@ -0,0 +1,306 @@
+package webhook
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"gophermart-service/internal/base"
+	"gophermart-service/internal/config"
+	webhookClient "gophermart-service/internal/integration/webhook"
+	webhooksRepo "gophermart-service/internal/repository/webhooks"
+	"net/netip"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Service управляет подписками на вебхуки и доставляет события из очереди webhook_deliveries.
+// Доставка выполняется фоновым диспетчером с повторами по экспоненциальной задержке.
+type Service struct {
+	logger   config.LoggerInterface
+	settings *config.WebhookSettings
+	repo     webhooksRepo.RepositoryInterface
+	sender   webhookClient.SenderInterface
+
+	stopChan chan struct{}
+	stopOnce sync.Once
+	wg       sync.WaitGroup
+}
+
+// NewWebhookService создает сервис вебхуков и запускает диспетчер доставок
+func NewWebhookService(
+	logger config.LoggerInterface,
+	settings *config.WebhookSettings,
+	repo webhooksRepo.RepositoryInterface,
+	sender webhookClient.SenderInterface,
+) ServiceInterface {
+	service := &Service{
+		logger:   logger,
+		settings: settings,
+		repo:     repo,
+		sender:   sender,
+		stopChan: make(chan struct{}),
+	}
+
+	service.wg.Add(1)
+	go service.dispatcher()
+
+	return service
+}
+
+// Create создает подписку пользователя на события
+func (s *Service) Create(ctx context.Context, userID int, dtoIn *CreateInDTO) (*CreateOutDTO, error) {
+	requestID := base.GetRequestID(ctx)
+
+	events, err := s.validateCreateRequest(dtoIn)
+	if err != nil {
+		return nil, err
+	}
+
+	count, err := s.repo.CountUserWebhooks(ctx, userID)
+	if err != nil {
+		s.logger.Errorw("Failed to count webhooks", "requestID", requestID, "userID", userID, "error", err)
+		return nil, err
+	}
+	if count >= s.settings.MaxPerUser {
+		return nil, ErrTooManyWebhooks
+	}
+
+	secret := dtoIn.Secret
+	if secret == "" {
+		if secret, err = generateSecret(); err != nil {
+			s.logger.Errorw("Failed to generate webhook secret", "requestID", requestID, "error", err)
+			return nil, ErrFailedToGenerateSecret
+		}
+	}
+
+	created, err := s.repo.Add(ctx, &webhooksRepo.Webhook{
+		UserID: userID,
+		URL:    strings.TrimSpace(dtoIn.URL),
+		Events: events,
+		Secret: secret,
+	})
+	if err != nil {
+		s.logger.Errorw("Failed to store webhook", "requestID", requestID, "userID", userID, "error", err)
+		return nil, err
+	}
+
+	s.logger.Infow("Webhook created",
+		"requestID", requestID,
+		"userID", userID,
+		"webhookID", created.ID,
+		"events", events)
+
+	return &CreateOutDTO{OutDTO: *toOutDTO(created), Secret: secret}, nil
+}
+
+// List возвращает подписки пользователя без секретов
+func (s *Service) List(ctx context.Context, userID int) ([]*OutDTO, error) {
+	webhooks, err := s.repo.GetUserWebhooks(ctx, userID)
+	if err != nil {
+		s.logger.Errorw("Failed to get webhooks",
+			"requestID", base.GetRequestID(ctx),
+			"userID", userID,
+			"error", err)
+		return nil, err
+	}
+
+	result := make([]*OutDTO, 0, len(webhooks))
+	for _, webhook := range webhooks {
+		result = append(result, toOutDTO(webhook))
+	}
+	return result, nil
+}
+
+// Delete удаляет подписку; недоставленные события по ней отменяются
+func (s *Service) Delete(ctx context.Context, userID, webhookID int) error {
+	requestID := base.GetRequestID(ctx)
+
+	if err := s.repo.Delete(ctx, userID, webhookID); err != nil {
+		if webhooksRepo.IsErrWebhookNotFound(err) {
+			return ErrWebhookNotFound
+		}
+		s.logger.Errorw("Failed to delete webhook",
+			"requestID", requestID,
+			"userID", userID,
+			"webhookID", webhookID,
+			"error", err)
+		return err
+	}
+
+	s.logger.Infow("Webhook deleted", "requestID", requestID, "userID", userID, "webhookID", webhookID)
+	return nil
+}
+
+// ListDeliveries возвращает журнал доставок вебхука пользователя
+func (s *Service) ListDeliveries(ctx context.Context, userID, webhookID int, dtoIn *DeliveriesInDTO) ([]*DeliveryOutDTO, error) {
+	requestID := base.GetRequestID(ctx)
+
+	statuses := []string{webhooksRepo.DeliveryPending, webhooksRepo.DeliveryDelivered, webhooksRepo.DeliveryFailed}
+	if dtoIn.Status != "" && !slices.Contains(statuses, dtoIn.Status) {
+		return nil, ErrInvalidDeliveryStatus
+	}
+	limit := dtoIn.Limit
+	if limit <= 0 {
+		limit = defaultDeliveriesLimit
+	}
+	limit = min(limit, maxDeliveriesLimit)
+
+	if _, err := s.repo.GetUserWebhook(ctx, userID, webhookID); err != nil {
+		if webhooksRepo.IsErrWebhookNotFound(err) {
+			return nil, ErrWebhookNotFound
+		}
+		return nil, err
+	}
+
+	deliveries, err := s.repo.GetDeliveries(ctx, webhookID, dtoIn.Status, limit)
+	if err != nil {
+		s.logger.Errorw("Failed to get webhook deliveries",
+			"requestID", requestID,
+			"userID", userID,
+			"webhookID", webhookID,
+			"error", err)
+		return nil, err
+	}
+
+	result := make([]*DeliveryOutDTO, 0, len(deliveries))
+	for _, delivery := range deliveries {
+		out := &DeliveryOutDTO{
+			ID:             delivery.ID,
+			Event:          delivery.EventType,
+			Status:         delivery.Status,
+			Attempts:       delivery.Attempts,
+			Payload:        json.RawMessage(delivery.Payload),
+			ResponseStatus: delivery.ResponseStatus,
+			LastError:      delivery.LastError,
+			LastAttemptAt:  delivery.LastAttemptAt,
+			CreatedAt:      delivery.CreatedAt,
+			DeliveredAt:    delivery.DeliveredAt,
+		}
+		if delivery.Status == webhooksRepo.DeliveryPending {
+			out.NextAttemptAt = &delivery.NextAttemptAt
+		}
+		result = append(result, out)
+	}
+	return result, nil
+}
+
+// Publish ставит событие в очередь доставки всем подписанным вебхукам пользователя.
+// Ошибка постановки логируется и не влияет на операцию, породившую событие.
+func (s *Service) Publish(ctx context.Context, userID int, eventType string, data any) {
+	eventID, err := newEventID()
+	if err != nil {
+		s.logger.Errorw("Failed to generate webhook event id", "userID", userID, "event", eventType, "error", err)
+		return
+	}
+
+	payload, err := json.Marshal(&envelope{
+		ID:        eventID,
+		Type:      eventType,
+		CreatedAt: time.Now().UTC(),
+		Data:      data,
+	})
+	if err != nil {
+		s.logger.Errorw("Failed to encode webhook payload", "userID", userID, "event", eventType, "error", err)
+		return
+	}
+
+	// Событие уже произошло: отмена запроса клиентом не должна отменять постановку в очередь
+	queued, err := s.repo.Enqueue(context.WithoutCancel(ctx), userID, eventType, string(payload))
+	if err != nil {
+		s.logger.Errorw("Failed to enqueue webhook deliveries",
+			"requestID", base.GetRequestID(ctx),
+			"userID", userID,
+			"event", eventType,
+			"error", err)
+		return
+	}
+	if queued > 0 {
+		s.logger.Infow("Webhook deliveries enqueued",
+			"userID", userID,
+			"event", eventType,
+			"eventID", eventID,
+			"deliveries", queued)
+	}
+}
+
+func (s *Service) validateCreateRequest(dtoIn *CreateInDTO) ([]string, error) {
+	rawURL := strings.TrimSpace(dtoIn.URL)
+	if rawURL == "" {
+		return nil, ErrURLIsRequired
+	}
+	parsed, err := url.Parse(rawURL)
+	if err != nil || parsed.Host == "" || (parsed.Scheme != "https" && parsed.Scheme != "http") {
+		return nil, ErrInvalidURL
+	}
+	if parsed.Scheme == "http" && !s.settings.AllowHTTP {
+		return nil, ErrInsecureURL
+	}
+	// Имена хостов проверяются при каждом соединении; здесь отсекаются очевидные внутренние адреса
+	if !s.settings.AllowPrivateNetworks && isPrivateHost(parsed.Hostname()) {
+		return nil, ErrPrivateURL
+	}
+
+	if len(dtoIn.Events) == 0 {
+		return nil, ErrEventsAreRequired
+	}
+	events := make([]string, 0, len(dtoIn.Events))
+	for _, event := range dtoIn.Events {
+		if !slices.Contains(KnownEvents, event) {
+			return nil, fmt.Errorf("%w: %s", ErrUnknownEvent, event)
+		}
+		if !slices.Contains(events, event) {
+			events = append(events, event)
+		}
+	}
+
+	if dtoIn.Secret != "" {
+		if len(dtoIn.Secret) < minSecretLength {
+			return nil, ErrSecretTooShort
+		}
+		if len(dtoIn.Secret) > maxSecretLength {
+			return nil, ErrSecretTooLong
+		}
+	}
+
+	return events, nil
+}
+
+// isPrivateHost сообщает, что хост — localhost или IP-адрес вне публичных диапазонов
+func isPrivateHost(host string) bool {
+	host = strings.ToLower(strings.TrimSuffix(host, "."))
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return true
+	}
+	addr, err := netip.ParseAddr(host)
+	return err == nil && !webhookClient.IsPublicAddress(addr)
+}
+
+func toOutDTO(webhook *webhooksRepo.Webhook) *OutDTO {
+	return &OutDTO{
+		ID:        webhook.ID,
+		URL:       webhook.URL,
+		Events:    webhook.Events,
+		CreatedAt: webhook.CreatedAt,
+	}
+}
+
+func generateSecret() (string, error) {
+	buf := make([]byte, secretBytes)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return "whsec_" + hex.EncodeToString(buf), nil
+}
+
+func newEventID() (string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return "evt_" + hex.EncodeToString(buf), nil
+}
@@ -0,0 +1,35 @@
+package webhook
+
+import (
+	"errors"
+	"gophermart-service/internal/config"
+	"testing"
+)
+
+func TestValidateCreateRequestURL(t *testing.T) {
+	tests := []struct {
+		name    string
+		url     string
+		allow   bool
+		wantErr error
+	}{
+		{name: "public host", url: "https://partner.example.com/hook"},
+		{name: "public ip", url: "https://8.8.8.8/hook"},
+		{name: "loopback ip", url: "https://127.0.0.1:8443/hook", wantErr: ErrPrivateURL},
+		{name: "localhost", url: "https://localhost/hook", wantErr: ErrPrivateURL},
+		{name: "metadata service", url: "https://169.254.169.254/latest", wantErr: ErrPrivateURL},
+		{name: "private range", url: "https://10.0.0.5/hook", wantErr: ErrPrivateURL},
+		{name: "ipv6 loopback", url: "https://[::1]/hook", wantErr: ErrPrivateURL},
+		{name: "private allowed for development", url: "https://127.0.0.1/hook", allow: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Service{settings: &config.WebhookSettings{AllowPrivateNetworks: tt.allow}}
+
+			_, err := s.validateCreateRequest(&CreateInDTO{URL: tt.url, Events: []string{EventOrderProcessed}})
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("validateCreateRequest(%q) error = %v, want %v", tt.url, err, tt.wantErr)
+			}
+		})
+	}
+}
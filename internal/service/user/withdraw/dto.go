@@ -0,0 +1,19 @@
+package withdraw
+
+import withdrawRepo "gophermart-service/internal/repository/withdraw"
+
+// ListInDTO параметры списка списаний: период, сортировка и пагинация
+type ListInDTO struct {
+	From   string
+	To     string
+	Sort   string
+	Cursor string
+	// Limit 0 — весь список одним ответом, без пагинации
+	Limit int
+}
+
+// ListOutDTO страница списка списаний; NextCursor пуст на последней странице
+type ListOutDTO struct {
+	Withdrawals []withdrawRepo.Withdrawal
+	NextCursor  string
+}
@@ -2,10 +2,9 @@ package withdraw
 
 import (
 	"context"
-	withdrawRepo "gophermart-service/internal/repository/withdraw"
 )
 
 type ServiceInterface interface {
-	MakeNewWithdraw(ctx context.Context, userID int, orderNumber string, sum float32) error
-	GetUserWithdrawals(ctx context.Context, userID int) ([]withdrawRepo.Withdrawal, error)
+	MakeNewWithdraw(ctx context.Context, userID int, orderNumber string, sumCents int64) error
+	ListUserWithdrawals(ctx context.Context, userID int, in *ListInDTO) (*ListOutDTO, error)
 }
@@ -5,9 +5,22 @@ import (
 	"errors"
 	"gophermart-service/internal/base"
 	"gophermart-service/internal/config"
+	"gophermart-service/internal/metrics"
 	ordersRepo "gophermart-service/internal/repository/orders"
 	viewsRepo "gophermart-service/internal/repository/views"
 	withdrawRepo "gophermart-service/internal/repository/withdraw"
+	"gophermart-service/internal/service/pagination"
+	"gophermart-service/internal/service/user/webhook"
+	"gophermart-service/internal/tracing"
+	"strconv"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+var (
+	listSortFields  = []string{withdrawRepo.SortProcessedAt, withdrawRepo.SortSum}
+	defaultListSort = pagination.Sort{Field: withdrawRepo.SortProcessedAt, Desc: true}
 )
 
 func NewUserWithdrawService(
@@ -15,12 +28,14 @@ func NewUserWithdrawService(
 	ordersRepo ordersRepo.RepositoryInterface,
 	viewsRepo viewsRepo.RepositoryInterface,
 	withdrawRepo withdrawRepo.RepositoryInterface,
+	webhooks webhook.PublisherInterface,
 ) ServiceInterface {
 	return &Service{
 		logger:       logger,
 		ordersRepo:   ordersRepo,
 		viewsRepo:    viewsRepo,
 		withdrawRepo: withdrawRepo,
+		webhooks:     webhooks,
 	}
 }
 
@@ -29,9 +44,16 @@ type Service struct {
 	ordersRepo   ordersRepo.RepositoryInterface
 	viewsRepo    viewsRepo.RepositoryInterface
 	withdrawRepo withdrawRepo.RepositoryInterface
+	webhooks     webhook.PublisherInterface
 }
 
-func (s *Service) MakeNewWithdraw(ctx context.Context, userID int, orderNumber string, sum float32) error {
+// MakeNewWithdraw списывает сумму в копейках; копейки доходят до DECIMAL(10,2) без промежуточных float
+func (s *Service) MakeNewWithdraw(ctx context.Context, userID int, orderNumber string, sumCents int64) (err error) {
+	ctx, span := tracing.Start(ctx, "withdraw.MakeNewWithdraw",
+		attribute.Int("user.id", userID),
+		attribute.String("order.number", orderNumber))
+	defer func() { tracing.End(span, err) }()
+
 	requestID := base.GetRequestID(ctx)
 
 	s.logger.Infow("Load new order number initiated",
@@ -55,7 +77,7 @@ func (s *Service) MakeNewWithdraw(ctx context.Context, userID int, orderNumber s
 	}
 
 	// Атомарно проверяем баланс и добавляем списание в транзакции
-	if err := s.withdrawRepo.AddNewWithBalanceCheck(ctx, userID, orderNumber, sum); err != nil {
+	if err := s.withdrawRepo.AddNewWithBalanceCheck(ctx, userID, orderNumber, sumCents); err != nil {
 		// Проверяем тип ошибки для корректной обработки
 		if errors.Is(err, withdrawRepo.ErrInsufficientBalance) {
 			s.logger.Warnw("Make new withdraw failed: not enough balance",
@@ -76,19 +98,71 @@ func (s *Service) MakeNewWithdraw(ctx context.Context, userID int, orderNumber s
 		return err
 	}
 
+	sum := float64(sumCents) / 100
+	metrics.WithdrawalAmount.Observe(sum)
+	s.webhooks.Publish(ctx, userID, webhook.EventWithdrawalCreated, &webhook.WithdrawalCreatedDTO{
+		OrderNumber: orderNumber,
+		Sum:         float32(sum),
+		ProcessedAt: time.Now().UTC(),
+	})
+
 	return nil
 }
 
-func (s *Service) GetUserWithdrawals(ctx context.Context, userID int) ([]withdrawRepo.Withdrawal, error) {
+func (s *Service) ListUserWithdrawals(ctx context.Context, userID int, in *ListInDTO) (_ *ListOutDTO, err error) {
+	ctx, span := tracing.Start(ctx, "withdraw.ListUserWithdrawals", attribute.Int("user.id", userID))
+	defer func() { tracing.End(span, err) }()
+
 	requestID := base.GetRequestID(ctx)
 
-	s.logger.Infow("Get user withdrawals initiated",
+	s.logger.Infow("List user withdrawals initiated",
 		"requestID", requestID,
 		"userID", userID)
 
-	withdrawals, err := s.withdrawRepo.GetUserWithdrawals(ctx, userID)
+	sort, err := pagination.ParseSort(in.Sort, listSortFields, defaultListSort)
+	if err != nil {
+		return nil, err
+	}
+	cursor, err := pagination.DecodeCursor(in.Cursor, sort)
+	if err != nil {
+		return nil, err
+	}
+	dateRange, err := pagination.ParseDateRange(in.From, in.To)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := &withdrawRepo.ListFilter{
+		UserID:    userID,
+		From:      dateRange.From,
+		To:        dateRange.To,
+		SortField: sort.Field,
+		Desc:      sort.Desc,
+	}
+	// Запрашиваем на одну запись больше, чтобы понять, есть ли следующая страница
+	if in.Limit > 0 || cursor != nil {
+		filter.Limit = pagination.NormalizeLimit(in.Limit) + 1
+	}
+	if cursor != nil {
+		filter.AfterID = cursor.ID
+		if sort.Field == withdrawRepo.SortSum {
+			value, err := strconv.ParseFloat(cursor.Value, 64)
+			if err != nil {
+				return nil, pagination.ErrInvalidCursor
+			}
+			filter.AfterValue = value
+		} else {
+			value, err := cursor.TimeValue()
+			if err != nil {
+				return nil, err
+			}
+			filter.AfterValue = value
+		}
+	}
+
+	withdrawals, err := s.withdrawRepo.ListUserWithdrawals(ctx, filter)
 	if err != nil {
-		s.logger.Errorw("Get user withdrawals failed",
+		s.logger.Errorw("List user withdrawals failed",
 			"requestID", requestID,
 			"userID", userID,
 			"error", err,
@@ -96,10 +170,24 @@ func (s *Service) GetUserWithdrawals(ctx context.Context, userID int) ([]withdra
 		return nil, err
 	}
 
-	s.logger.Infow("Get user withdrawals completed",
+	result := &ListOutDTO{}
+	if filter.Limit > 0 && len(withdrawals) == filter.Limit {
+		withdrawals = withdrawals[:filter.Limit-1]
+		last := withdrawals[len(withdrawals)-1]
+		next := &pagination.Cursor{Sort: sort.String(), ID: last.ID}
+		if sort.Field == withdrawRepo.SortSum {
+			next.Value = pagination.FormatAmount(last.Sum)
+		} else {
+			next.Value = pagination.FormatTime(last.ProcessedAt)
+		}
+		result.NextCursor = next.Encode()
+	}
+	result.Withdrawals = withdrawals
+
+	s.logger.Infow("List user withdrawals completed",
 		"requestID", requestID,
 		"userID", userID,
 		"withdrawalsCount", len(withdrawals))
 
-	return withdrawals, nil
+	return result, nil
 }
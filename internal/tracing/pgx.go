@@ -0,0 +1,62 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type querySpanKey struct{}
+
+// QueryTracer записывает спан на каждый запрос pgx. Запросы вне трассируемой операции
+// (опрос очередей фоновыми обработчиками) не трассируются, чтобы не плодить корневые спаны.
+type QueryTracer struct{}
+
+// NewQueryTracer создает трассировщик запросов для pgx.ConnConfig.Tracer
+func NewQueryTracer() pgx.QueryTracer {
+	return &QueryTracer{}
+}
+
+func (t *QueryTracer) TraceQueryStart(ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryStartData) context.Context {
+	if !trace.SpanContextFromContext(ctx).IsValid() {
+		return ctx
+	}
+
+	ctx, span := otel.Tracer(tracerName).Start(ctx, querySpanName(data.SQL),
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("db.system", "postgresql"),
+			attribute.String("db.statement", data.SQL),
+		),
+	)
+	return context.WithValue(ctx, querySpanKey{}, span)
+}
+
+func (t *QueryTracer) TraceQueryEnd(ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryEndData) {
+	span, ok := ctx.Value(querySpanKey{}).(trace.Span)
+	if !ok {
+		return
+	}
+
+	span.SetAttributes(attribute.Int64("db.rows_affected", data.CommandTag.RowsAffected()))
+	// Отсутствие строки — ожидаемый результат поиска, а не сбой запроса
+	err := data.Err
+	if errors.Is(err, pgx.ErrNoRows) {
+		err = nil
+	}
+	End(span, err)
+}
+
+// querySpanName называет спан по операции SQL, не включая в имя сам запрос
+func querySpanName(sql string) string {
+	fields := strings.Fields(sql)
+	if len(fields) == 0 {
+		return "db.query"
+	}
+	return "db." + strings.ToLower(fields[0])
+}
@@ -0,0 +1,91 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"gophermart-service/internal/config"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tracerName имя инструментирующей библиотеки для спанов сервиса
+const tracerName = "gophermart-service"
+
+var ErrUnknownExporter = errors.New("unknown tracing exporter")
+
+// ShutdownFunc выгружает накопленные спаны и останавливает экспорт
+type ShutdownFunc func(ctx context.Context) error
+
+// Setup настраивает глобальные провайдер трассировки и W3C-пропагатор (traceparent, baggage).
+// Пропагатор устанавливается всегда, чтобы контекст входящих запросов передавался в исходящие
+// даже при выключенной трассировке.
+func Setup(ctx context.Context, settings *config.TracingSettings) (ShutdownFunc, error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
+
+	if !settings.Enabled {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	exporter, err := newExporter(ctx, settings)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := resource.Merge(
+		resource.Default(),
+		resource.NewSchemaless(attribute.String("service.name", settings.ServiceName)),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build tracing resource: %w", err)
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(settings.SampleRatio))),
+	)
+	otel.SetTracerProvider(provider)
+
+	return provider.Shutdown, nil
+}
+
+func newExporter(ctx context.Context, settings *config.TracingSettings) (sdktrace.SpanExporter, error) {
+	switch settings.Exporter {
+	case config.TracingExporterOTLP:
+		options := []otlptracegrpc.Option{otlptracegrpc.WithEndpoint(settings.OTLPEndpoint)}
+		if settings.OTLPInsecure {
+			options = append(options, otlptracegrpc.WithInsecure())
+		}
+		return otlptracegrpc.New(ctx, options...)
+	case config.TracingExporterStdout:
+		return stdouttrace.New()
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnknownExporter, settings.Exporter)
+	}
+}
+
+// Start открывает спан сервиса, дочерний по отношению к спану из ctx
+func Start(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return otel.Tracer(tracerName).Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// End завершает спан и отмечает его ошибкой, если err не nil
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_password_reset_tokens_user_id;
+
+DROP TABLE IF EXISTS password_reset_tokens;
+
+ALTER TABLE users DROP COLUMN IF EXISTS token_version;
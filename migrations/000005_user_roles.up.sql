@@ -0,0 +1,6 @@
+-- Роль пользователя: customer — клиент, support — служба поддержки, admin — администратор
+-- Первого администратора назначают вручную: UPDATE users SET role = 'admin' WHERE login = '...';
+ALTER TABLE users ADD COLUMN IF NOT EXISTS role VARCHAR(32) NOT NULL DEFAULT 'customer'
+    CHECK (role IN ('customer', 'support', 'admin'));
+
+CREATE INDEX IF NOT EXISTS idx_users_role ON users(role);
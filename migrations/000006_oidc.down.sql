@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS oidc_auth_requests;
+
+DROP INDEX IF EXISTS idx_user_identities_user_id;
+
+DROP TABLE IF EXISTS user_identities;
@@ -0,0 +1,12 @@
+DROP INDEX IF EXISTS idx_email_verification_tokens_user_id;
+
+DROP TABLE IF EXISTS email_verification_tokens;
+
+DROP INDEX IF EXISTS idx_users_verified_email;
+
+ALTER TABLE users DROP COLUMN IF EXISTS marketing_consent_at;
+ALTER TABLE users DROP COLUMN IF EXISTS marketing_consent;
+ALTER TABLE users DROP COLUMN IF EXISTS phone;
+ALTER TABLE users DROP COLUMN IF EXISTS email_verified_at;
+ALTER TABLE users DROP COLUMN IF EXISTS email;
+ALTER TABLE users DROP COLUMN IF EXISTS display_name;
@@ -0,0 +1,21 @@
+-- История обработки заказа: загрузка, результаты опросов системы начислений и финальный статус
+CREATE TABLE IF NOT EXISTS order_events (
+    id BIGINT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    order_id INTEGER NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+    event_type VARCHAR(32) NOT NULL CHECK (event_type IN ('uploaded', 'accrual_poll', 'status_changed')),
+    status VARCHAR(50) NOT NULL,
+    accrual_status VARCHAR(50),
+    accrual DECIMAL(10,2),
+    detail VARCHAR(255),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_order_events_order_id ON order_events(order_id, id);
+
+-- Для ранее загруженных заказов восстанавливаем известные события
+INSERT INTO order_events (order_id, event_type, status, created_at)
+SELECT id, 'uploaded', 'NEW', uploaded_at FROM orders;
+
+INSERT INTO order_events (order_id, event_type, status, accrual, created_at)
+SELECT id, 'status_changed', status, accrual, updated_at FROM orders
+WHERE status IN ('INVALID', 'PROCESSED');
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_withdrawals_user_sum_id;
+DROP INDEX IF EXISTS idx_withdrawals_user_processed_at_id;
+DROP INDEX IF EXISTS idx_orders_user_accrual_id;
+DROP INDEX IF EXISTS idx_orders_user_uploaded_at_id;
@@ -0,0 +1,5 @@
+-- Составные индексы под keyset-пагинацию списков заказов и списаний
+CREATE INDEX IF NOT EXISTS idx_orders_user_uploaded_at_id ON orders(user_id, uploaded_at, id);
+CREATE INDEX IF NOT EXISTS idx_orders_user_accrual_id ON orders(user_id, accrual, id);
+CREATE INDEX IF NOT EXISTS idx_withdrawals_user_processed_at_id ON withdrawals(user_id, processed_at, id);
+CREATE INDEX IF NOT EXISTS idx_withdrawals_user_sum_id ON withdrawals(user_id, sum, id);
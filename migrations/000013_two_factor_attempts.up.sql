@@ -0,0 +1,13 @@
+-- Счетчик неверных кодов подряд и блокировка второго шага входа после превышения лимита
+ALTER TABLE user_totp
+    ADD COLUMN IF NOT EXISTS failed_attempts INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS locked_until TIMESTAMP WITH TIME ZONE;
+
+-- Использованные промежуточные токены 2FA: каждый токен принимается один раз
+CREATE TABLE IF NOT EXISTS used_two_factor_challenges (
+    jti VARCHAR(64) PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_used_two_factor_challenges_expires_at ON used_two_factor_challenges(expires_at);